@@ -2,6 +2,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/lensesio/lenses-go/v5/pkg/alert"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	"github.com/lensesio/lenses-go/v5/pkg/audit"
+	"github.com/lensesio/lenses-go/v5/pkg/completion"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
 	"github.com/lensesio/lenses-go/v5/pkg/connection"
 	"github.com/lensesio/lenses-go/v5/pkg/connector"
@@ -35,6 +37,7 @@ import (
 	"github.com/lensesio/lenses-go/v5/pkg/sql"
 	"github.com/lensesio/lenses-go/v5/pkg/topic"
 	"github.com/lensesio/lenses-go/v5/pkg/topicsettings"
+	"github.com/lensesio/lenses-go/v5/pkg/topology"
 	"github.com/lensesio/lenses-go/v5/pkg/user"
 	"github.com/spf13/cobra"
 )
@@ -67,7 +70,15 @@ func setup(cmd *cobra.Command, args []string) error {
 	// Note that if clientConfig is valid and we are inside the configure command
 	// then the configure will normally continue and save the valid configuration (that normally came from flags).
 	topLevelSubCmd := strings.Split(cmd.CommandPath(), " ")[1]
-	if name := topLevelSubCmd; name == "configure" || name == "version" || name == "context" || name == "contexts" || name == "init-container" || strings.Contains(cmd.CommandPath(), " secrets ") {
+	if name := topLevelSubCmd; name == "configure" || name == "version" || name == "completion" || name == "context" || name == "contexts" || name == "init-container" || strings.Contains(cmd.CommandPath(), " secrets ") {
+		return nil
+	}
+
+	// shell completion must not prompt for a configuration, names are completed only when the configuration is valid.
+	if name := topLevelSubCmd; name == cobra.ShellCompRequestCmd || name == cobra.ShellCompNoDescRequestCmd {
+		if ok {
+			config.SetupClient()
+		}
 		return nil
 	}
 
@@ -114,7 +125,12 @@ func setup(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	return config.SetupClient()
+	if err := config.SetupClient(); err != nil {
+		return err
+	}
+
+	config.ApplyCommandTimeout(cmd)
+	return nil
 }
 
 func main() {
@@ -195,6 +211,9 @@ func main() {
 	app.AddCommand(topic.NewTopicsGroupCommand())
 	app.AddCommand(topic.NewTopicGroupCommand())
 
+	//Topology
+	app.AddCommand(topology.NewTopologyGroupCommand())
+
 	//Elasticsearch Indexes
 	app.AddCommand(elasticsearch.IndexesCommand())
 	app.AddCommand(elasticsearch.IndexCommand())
@@ -240,8 +259,32 @@ func main() {
 	// Add provision command for dynamic config
 	app.AddCommand(provision.NewProvisionCommand())
 
-	if err := app.Run(os.Stdout, os.Args[1:]); err != nil {
+	// Shell completion, including the names of topics, connectors and subjects
+	root := bite.Build(app)
+	completion.Setup(root)
+
+	if err := execute(root, os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
+
+// execute runs the "root" command like the `bite.Application#Run` does,
+// without the bash only `completion` command it adds, see `completion.Setup`.
+func execute(root *cobra.Command, args []string) error {
+	root.SetOutput(os.Stdout)
+	root.SetArgs(args)
+	if !root.DisableFlagParsing {
+		root.ParseFlags(args)
+	}
+
+	err := root.Execute()
+	// the messages registered by the commands per status code, see `bite.FriendlyError`.
+	if resErr, ok := err.(bite.Error); ok {
+		if msg, ok := app.FriendlyErrors[resErr.Code()]; ok {
+			return errors.New(msg)
+		}
+	}
+
+	return err
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
@@ -18,6 +19,9 @@ import (
 
 var aclFlags *pflag.FlagSet
 
+// ErrNoAuthorizer is returned when the Kafka ACLs are not enabled, see `api.ErrNoAuthorizer`.
+var ErrNoAuthorizer = api.ErrNoAuthorizer
+
 // NewGetACLsCommand creates the `acls` command
 func NewGetACLsCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -26,8 +30,10 @@ func NewGetACLsCommand() *cobra.Command {
 		Example:          "acls",
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			// if the API changes: bite.FriendlyError(cmd, errResourceNotAccessibleMessage, "no authorizer is configured on the broker")
 			acls, err := config.Client.GetACLs()
+			if errors.Is(err, ErrNoAuthorizer) {
+				return fmt.Errorf("%v, enable the ACLs by setting an `authorizer.class.name` on the brokers", err)
+			}
 			if err != nil {
 				golog.Errorf("Failed to retrieve acls. [%s]", err.Error())
 				return err
@@ -38,11 +44,13 @@ func NewGetACLsCommand() *cobra.Command {
 				return acls[i].ResourceName < acls[j].ResourceName
 			})
 
-			return bite.PrintObject(cmd, acls)
+			return utils.PrintObject(cmd, acls)
 		},
 	}
 
 	bite.CanPrintJSON(cmd)
+	utils.CanSelectColumns(cmd)
+	utils.CanUseTemplate(cmd)
 
 	return cmd
 }
@@ -120,6 +128,10 @@ func NewDeleteACLCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete ACL [%s]?", acl); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteACL(acl); err != nil {
 				return fmt.Errorf("failed to delete ACL '%s'. [%s]", acl, err.Error())
 			}
@@ -128,6 +140,8 @@ func NewDeleteACLCommand() *cobra.Command {
 			return nil
 		},
 	}
+
+	utils.CanSkipConfirmation(cmd)
 	return cmd
 }
 
@@ -1,12 +1,16 @@
 package acl
 
 import (
+	"errors"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"reflect"
 	"testing"
 
 	"github.com/lensesio/lenses-go/v5/pkg/api"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/test"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
@@ -125,3 +129,23 @@ func Test_populateACL(t *testing.T) {
 	}
 
 }
+
+func TestGetACLsNoAuthorizer(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("No authorizer"))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	require.NoError(t, err)
+	config.Client = client
+
+	_, err = client.GetACLs()
+	require.True(t, errors.Is(err, ErrNoAuthorizer))
+
+	_, err = test.ExecuteCommand(NewGetACLsCommand())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "authorizer.class.name")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/lensesio/lenses-go/v5/pkg"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -77,6 +78,10 @@ func NewDeleteAlertChannelCommand() *cobra.Command {
 		TraverseChildren: true,
 		SilenceErrors:    true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.Confirm(cmd, "Delete alert channel [%s]?", channelID); err != nil {
+				return err
+			}
+
 			err := config.Client.DeleteChannel(pkg.AlertChannelsPath, channelID)
 			if err != nil {
 				return fmt.Errorf("failed to delete alert channel [%s]. [%s]", channelID, err.Error())
@@ -88,6 +93,7 @@ func NewDeleteAlertChannelCommand() *cobra.Command {
 	cmd.Flags().StringVar(&channelID, "channelID", "", "The alert channel id, e.g. d15-4960-9ea6-2ccb4d26ebb4")
 	cmd.MarkFlagRequired("channelID")
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
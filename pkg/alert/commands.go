@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -57,6 +59,45 @@ func NewGetAlertsCommand() *cobra.Command {
 	bite.CanPrintJSON(cmd)
 
 	cmd.AddCommand(DeleteAlertEventsCommand())
+	cmd.AddCommand(NewGetAlertEventsCommand())
+
+	return cmd
+}
+
+// NewGetAlertEventsCommand creates the `alerts history` command
+func NewGetAlertEventsCommand() *cobra.Command {
+	var (
+		since    time.Duration
+		severity string
+		pageSize int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Print the fired alerts, the latest first",
+		Example: `alerts history --since=12h
+alerts history --since=24h --severity=HIGH`,
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := api.AlertEventQuery{Severity: severity, PageSize: pageSize}
+			if since > 0 {
+				query.From = time.Now().Add(-since).UnixNano() / int64(time.Millisecond)
+			}
+
+			events, err := config.Client.GetAlertEvents(query)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve alert events. Error: [%s]", err.Error())
+			}
+			return bite.PrintObject(cmd, events)
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 0, "Print only the alerts fired within the duration, e.g. 12h")
+	cmd.Flags().StringVar(&severity, "severity", "", "Print only the alerts of the severity, e.g. HIGH")
+	cmd.Flags().IntVar(&pageSize, "page-size", 1000, "Number of alert events to retrieve per request")
+
+	bite.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -76,6 +117,10 @@ func DeleteAlertEventsCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete the alert events older than timestamp [%d]?", olderThanTimestamp); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteAlertEvents(olderThanTimestamp); err != nil {
 				return fmt.Errorf("Failed to delete alert events. [%s]", err.Error())
 			}
@@ -86,18 +131,30 @@ func DeleteAlertEventsCommand() *cobra.Command {
 	cmd.Flags().Int64Var(&olderThanTimestamp, "timestamp", 0, "All the alert events older than that timestamp will be removed.")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 	return cmd
 }
 
 // NewGetAlertSettingsCommand creates the `alert settings` command
 func NewGetAlertSettingsCommand() *cobra.Command {
+	var category string
+
 	cmd := &cobra.Command{
 		Use:              "settings",
 		Short:            "Print all alert settings",
-		Example:          "alert settings",
+		Example:          "alert settings [--category=infrastructure]",
 		TraverseChildren: true,
 		SilenceErrors:    true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if category != "" {
+				settings, err := config.Client.GetAlertSettingsByCategory(category)
+				if err != nil {
+					return fmt.Errorf("failed to retrieve alerts' settings. Error: [%s]", err.Error())
+				}
+
+				return bite.PrintJSON(cmd, settings)
+			}
+
 			settings, err := config.Client.GetAlertSettings()
 			if err != nil {
 				return fmt.Errorf("failed to retrieve alerts' settings. Error: [%s]", err.Error())
@@ -108,6 +165,7 @@ func NewGetAlertSettingsCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&category, "category", "", fmt.Sprintf("Print only the settings of a category, one of %v", api.AlertSettingsCategories))
 	bite.CanPrintJSON(cmd)
 
 	return cmd
@@ -428,6 +486,10 @@ func NewDeleteAlertSettingConditionCommand() *cobra.Command {
 		TraverseChildren: true,
 		SilenceErrors:    true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.Confirm(cmd, "Delete condition [%s] of alert setting [%d]?", conditionUUID, alertID); err != nil {
+				return err
+			}
+
 			err := config.Client.DeleteAlertSettingCondition(alertID, conditionUUID)
 			if err != nil {
 				return fmt.Errorf("failed to delete an alert's setting condition. Error: [%s]", err.Error())
@@ -442,6 +504,7 @@ func NewDeleteAlertSettingConditionCommand() *cobra.Command {
 	cmd.Flags().StringVar(&conditionUUID, "condition", "", `Alert condition uuid .e.g. "28bbad2b-69bb-4c01-8e37-28e2e7083aa9"`)
 	cmd.MarkFlagRequired("condition")
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
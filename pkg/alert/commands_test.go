@@ -204,3 +204,34 @@ func TestServerFailures(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAlertEventsCommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "HIGH", r.URL.Query().Get("severity"))
+		// the server ignores the severity filter.
+		w.Write([]byte(`{"values":[
+			{"alertId":1000,"severity":"HIGH","summary":"broker down","timestamp":1600000000000,"resolvedAt":1600000100000},
+			{"alertId":2000,"severity":"LOW","summary":"consumer lag","timestamp":1600000200000},
+			{"alertId":1001,"severity":"HIGH","summary":"under replicated partitions","timestamp":1600000300000}
+		]}`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	events, err := client.GetAlertEvents(api.AlertEventQuery{Severity: "high"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, 1001, events[0].AlertID)
+	assert.True(t, events[1].Resolved())
+
+	cmd := NewGetAlertEventsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	out, err := test.ExecuteCommand(cmd, "--severity=HIGH")
+	assert.Nil(t, err)
+	test.CheckStringContains(t, out, "under replicated partitions")
+	assert.NotContains(t, out, "consumer lag")
+}
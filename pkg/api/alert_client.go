@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/lensesio/lenses-go/v5/pkg"
 )
@@ -203,6 +207,98 @@ func (c *Client) DeleteAlertEvents(timestamp int64) (err error) {
 	return resp.Body.Close()
 }
 
+// AlertEvent is a fired alert, see `GetAlertEvents`.
+// The FiredAt and ResolvedAt are unix milliseconds, ResolvedAt is zero while the alert is still active.
+type AlertEvent struct {
+	AlertID    int    `json:"alertId" yaml:"alertID" header:"ID,text"`
+	Category   string `json:"category,omitempty" yaml:"category,omitempty" header:"Category"`
+	Severity   string `json:"severity" yaml:"severity" header:"Severity"`
+	Instance   string `json:"instance,omitempty" yaml:"instance,omitempty" header:"Instance"`
+	Summary    string `json:"summary" yaml:"summary" header:"Summary"`
+	FiredAt    int64  `json:"timestamp" yaml:"firedAt" header:"Fired At,timestamp(ms|utc|02 Jan 2006 15:04)"`
+	ResolvedAt int64  `json:"resolvedAt,omitempty" yaml:"resolvedAt,omitempty" header:"Resolved At,timestamp(ms|utc|02 Jan 2006 15:04),No"`
+}
+
+// Resolved reports whether the alert event has been resolved.
+func (e AlertEvent) Resolved() bool {
+	return e.ResolvedAt > 0
+}
+
+// AlertEventQuery filters the alert events of `GetAlertEvents`, zero fields are ignored.
+type AlertEventQuery struct {
+	// From and To are the unix milliseconds range of the time the alerts fired.
+	From int64
+	To   int64
+	// Severity is the severity of the alerts, i.e. "HIGH", matched case-insensitively.
+	Severity string
+	// PageSize is the number of events retrieved per request, defaults to 1000.
+	PageSize int
+}
+
+func (q AlertEventQuery) matches(event AlertEvent) bool {
+	if q.From > 0 && event.FiredAt < q.From {
+		return false
+	}
+
+	if q.To > 0 && event.FiredAt > q.To {
+		return false
+	}
+
+	return q.Severity == "" || strings.EqualFold(q.Severity, event.Severity)
+}
+
+// IterateAlertEvents returns an iterator over the history of the fired alerts, page by page.
+// The query filters are sent to the server and they are applied to its results as well,
+// so they are respected by servers that ignore any of them.
+func (c *Client) IterateAlertEvents(query AlertEventQuery) *Iterator[AlertEvent] {
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = 1000
+	}
+
+	params := url.Values{}
+	if query.From > 0 {
+		params.Set("from", strconv.FormatInt(query.From, 10))
+	}
+	if query.To > 0 {
+		params.Set("to", strconv.FormatInt(query.To, 10))
+	}
+	if query.Severity != "" {
+		params.Set("severity", strings.ToUpper(query.Severity))
+	}
+
+	fetch := pageFetcher[AlertEvent](c, pkg.AlertEventsPath, params, pageSize)
+	return NewIterator(func(ctx context.Context, page int) ([]AlertEvent, bool, error) {
+		values, more, err := fetch(ctx, page)
+		if err != nil {
+			return nil, false, err
+		}
+
+		events := make([]AlertEvent, 0, len(values))
+		for _, event := range values {
+			if query.matches(event) {
+				events = append(events, event)
+			}
+		}
+
+		return events, more, nil
+	})
+}
+
+// GetAlertEvents returns the history of the fired alerts, the latest first, see `IterateAlertEvents`.
+func (c *Client) GetAlertEvents(query AlertEventQuery) ([]AlertEvent, error) {
+	events, err := c.IterateAlertEvents(query).Collect(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].FiredAt > events[j].FiredAt
+	})
+
+	return events, nil
+}
+
 // GetAlertSettings returns all the configured alert settings.
 // Alerts are divided into two categories:
 //
@@ -241,6 +337,53 @@ func (c *Client) GetAlertSetting(id int) (setting AlertSetting, err error) {
 	return
 }
 
+// GetAlertSettingsByCategory returns the alert settings of a single "category", one of the `AlertSettingsCategories`.
+func (c *Client) GetAlertSettingsByCategory(category string) ([]AlertSetting, error) {
+	// validate before the call.
+	if _, err := (AlertSettingsCategoryMap{}).Category(category); err != nil {
+		return nil, err
+	}
+
+	settings, err := c.GetAlertSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	return settings.Categories.Category(category)
+}
+
+// FindAlertSettingByDescription returns the alert setting of any category whose description is the "description",
+// matched case-insensitively, i.e. to enable an alert by its name.
+// It returns an error if there is no such alert setting or more than one.
+func (c *Client) FindAlertSettingByDescription(description string) (AlertSetting, error) {
+	if description == "" {
+		return AlertSetting{}, errRequired("description")
+	}
+
+	settings, err := c.GetAlertSettings()
+	if err != nil {
+		return AlertSetting{}, err
+	}
+
+	var found []AlertSetting
+	for _, category := range settings.Categories.allCategories() {
+		for _, setting := range category {
+			if strings.EqualFold(strings.TrimSpace(setting.Description), strings.TrimSpace(description)) {
+				found = append(found, setting)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return AlertSetting{}, fmt.Errorf("alert setting [%s] not found", description)
+	case 1:
+		return found[0], nil
+	default:
+		return AlertSetting{}, fmt.Errorf("[%d] alert settings match [%s]", len(found), description)
+	}
+}
+
 // EnableAlertSetting enables a specific alert setting based on its "id".
 func (c *Client) EnableAlertSetting(id int, enable bool) error {
 	return c.UpdateAlertSettings(AlertSettingsPayload{AlertID: strconv.Itoa(id), Enable: enable, Channels: []string{}})
@@ -295,6 +438,55 @@ func (c *Client) DeleteAlertSettingCondition(alertSettingID int, conditionUUID s
 	return resp.Body.Close()
 }
 
+// ConfigureAlert makes the alert setting of "id" have exactly the given "conditions" and enabled state,
+// conditions not already set are created and the ones not given are deleted, the rest are left untouched.
+// The conditions are applied before the setting is enabled, so it is never enabled with stale conditions.
+func (c *Client) ConfigureAlert(id int, enabled bool, conditions []string) error {
+	setting, err := c.GetAlertSetting(id)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(conditions))
+	for _, condition := range conditions {
+		wanted[strings.TrimSpace(condition)] = true
+	}
+
+	var (
+		existing = make(map[string]bool, len(setting.Conditions))
+		absent   []string
+	)
+	for conditionID, condition := range setting.Conditions {
+		condition = strings.TrimSpace(condition)
+		if wanted[condition] && !existing[condition] {
+			existing[condition] = true
+			continue
+		}
+		absent = append(absent, conditionID)
+	}
+	sort.Strings(absent)
+
+	for _, conditionID := range absent {
+		if err = c.DeleteAlertSettingCondition(id, conditionID); err != nil {
+			return err
+		}
+	}
+
+	for _, condition := range conditions {
+		condition = strings.TrimSpace(condition)
+		if existing[condition] {
+			continue
+		}
+
+		if err = c.CreateAlertSettingsCondition(strconv.Itoa(id), condition, []string{}); err != nil {
+			return err
+		}
+		existing[condition] = true
+	}
+
+	return c.EnableAlertSetting(id, enabled)
+}
+
 func flatten(prefix string, src map[string]interface{}, dest map[string]interface{}) {
 	if len(prefix) > 0 {
 		prefix += "."
@@ -4,17 +4,26 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
+	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
 
+	"github.com/google/uuid"
 	"github.com/kataras/golog"
 	"github.com/lensesio/lenses-go/v5/pkg"
 	"github.com/mitchellh/mapstructure"
@@ -46,6 +55,23 @@ type Client struct {
 
 	// the client is created on the `lenses#OpenConnection` function, it can be customized via options there.
 	client *http.Client
+	// ctx is attached to every request, see `SetContext`.
+	ctx context.Context
+	// activeHost is the index of the `ClientConfig#Hosts` the requests are sent to, see `Do`.
+	activeHost *int32
+	// schemaIDCache is nil unless enabled by the `UsingSchemaIDCache`.
+	schemaIDCache *schemaIDCache
+	// schemaCache is nil unless enabled by the `ClientConfig#SchemaCacheTTL`.
+	schemaCache *schemaTTLCache
+	// responseCache keeps the responses of the calls sent `WithCache`.
+	responseCache *responseCache
+}
+
+// SetContext sets a context which is attached to all the following requests of the client,
+// i.e a context with a deadline to bound the total runtime of a series of API calls.
+// A nil "ctx" removes any previously set context.
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctx = ctx
 }
 
 var noOpBuffer = new(bytes.Buffer)
@@ -79,12 +105,16 @@ const (
 	contentTypeHeaderKey = "Content-Type"
 	contentTypeJSON      = "application/json"
 
-	xKafkaLensesTokenHeaderKey = "X-Kafka-Lenses-Token"
+	xKafkaLensesTokenHeaderKey           = "X-Kafka-Lenses-Token"
+	xKafkaLensesImpersonateUserHeaderKey = "X-Kafka-Lenses-Impersonate-User"
+	xRequestIDHeaderKey                  = "X-Request-ID"
+	xHTTPMethodOverrideHeaderKey         = "X-HTTP-Method-Override"
 
 	acceptHeaderKey          = "Accept"
 	acceptEncodingHeaderKey  = "Accept-Encoding"
 	contentEncodingHeaderKey = "Content-Encoding"
 	gzipEncodingHeaderValue  = "gzip"
+	retryAfterHeaderKey      = "Retry-After"
 )
 
 // ErrCredentialsMissing fires on login, when credentials are missing or
@@ -95,16 +125,93 @@ var ErrCredentialsMissing = fmt.Errorf("credentials missing or invalid")
 // if the return value of the error is not nil then `Client#Do` fails with that error.
 type RequestOption func(r *http.Request) error
 
+// WithRequestID sets the "X-Request-ID" request header to "id" instead of a generated one,
+// i.e. to correlate a call with the logs of the caller.
+func WithRequestID(id string) RequestOption {
+	return func(r *http.Request) error {
+		r.Header.Set(xRequestIDHeaderKey, id)
+		return nil
+	}
+}
+
+// WithMethodOverride sends the request as a POST and the "actual" method, i.e. PUT or DELETE,
+// as the "X-HTTP-Method-Override" header, the method of the call is used if "actual" is empty.
+//
+// It is only needed when a proxy or a corporate gateway in front of the box, or of a proxied Connect cluster,
+// blocks any method other than GET and POST, the server behind it should honor the header.
+func WithMethodOverride(actual string) RequestOption {
+	return func(r *http.Request) error {
+		method := strings.ToUpper(actual)
+		if method == "" {
+			method = r.Method
+		}
+
+		switch method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodPost:
+		default:
+			return fmt.Errorf("client: method [%s] cannot be overridden, available methods are: [PUT, PATCH, DELETE, POST]", method)
+		}
+
+		r.Header.Set(xHTTPMethodOverrideHeaderKey, method)
+		r.Method = http.MethodPost
+		return nil
+	}
+}
+
+type cacheTTLContextKey struct{}
+
+// withBodyStream sends the "body" as it is read, instead of the bytes given to the `Client#Do`,
+// i.e. for uploads which should not be kept in memory or logged. Such a request is not retried on a 429.
+func withBodyStream(body io.Reader) RequestOption {
+	return func(r *http.Request) error {
+		rc, ok := body.(io.ReadCloser)
+		if !ok {
+			rc = ioutil.NopCloser(body)
+		}
+
+		r.Body = rc
+		r.GetBody = nil
+		r.ContentLength = -1
+		return nil
+	}
+}
+
+// WithCache keeps the response of a successful GET for "ttl", the same GET, by host, path, query, token and impersonated user,
+// is answered from the memory of the client until then, i.e. for the connector plugins or a schema by id
+// which do not change during a session. Other methods and the failed calls are never cached.
+//
+// It can be given to a `Client#Do` call or set as the `Client#PersistentRequestModifier`, see `Client#ClearCache` too.
+func WithCache(ttl time.Duration) RequestOption {
+	return func(r *http.Request) error {
+		if ttl > 0 {
+			*r = *r.WithContext(context.WithValue(r.Context(), cacheTTLContextKey{}, ttl))
+		}
+		return nil
+	}
+}
+
 // ResourceError is being fired from all API calls when an error code is received.
 type ResourceError struct {
 	StatusCode int    `json:"statusCode" header:"Status Code"`
 	Method     string `json:"method" header:"Method"`
 	URI        string `json:"uri" header:"Target"`
 	Body       string `json:"message" header:"Message"`
+	// RequestID is the "X-Request-ID" of the failed call, as reported by the box or as sent if the box did not report one.
+	// It can be used to find the call in the box logs.
+	RequestID string `json:"requestId,omitempty" header:"Request ID"`
+	// Details is the decoded body of a structured JSON error, the human message of it is at `Body`.
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Raw is the body of a structured JSON error as received, for debugging.
+	Raw string `json:"-"`
 }
 
 // String returns the detailed cause of the error.
 func (err ResourceError) String() string {
+	if err.RequestID != "" {
+		return fmt.Sprintf("client: [%s: %s] failed with status code [%d] (request id [%s]):\n[%s]",
+			err.Method, err.URI, err.StatusCode, err.RequestID, err.Body)
+	}
+
 	return fmt.Sprintf("client: [%s: %s] failed with status code [%d]:\n[%s]",
 		err.Method, err.URI, err.StatusCode, err.Body)
 }
@@ -145,6 +252,12 @@ func (err ResourceError) Code() int {
 	return err.StatusCode
 }
 
+// isNotFound reports whether the "err" is a `ResourceError` with 404 (Not Found) status code.
+func isNotFound(err error) bool {
+	resErr, ok := err.(ResourceError)
+	return ok && resErr.Code() == http.StatusNotFound
+}
+
 // NewResourceError is just a helper to create a new `ResourceError` to return from custom calls, it's "cli-compatible".
 func NewResourceError(statusCode int, uri, method, body string) ResourceError {
 	unescapedURI, _ := url.QueryUnescape(uri)
@@ -157,6 +270,35 @@ func NewResourceError(statusCode int, uri, method, body string) ResourceError {
 	}
 }
 
+// jsonErrorMessageKeys are the keys of a JSON error body which may hold its human message, in order of preference.
+var jsonErrorMessageKeys = []string{"message", "msg", "error", "detail", "description", "reason", "errors"}
+
+// jsonErrorMessage returns the human message of a structured JSON error body, i.e
+// {"error": {"message": "topic exists", "details": [...]}} or {"errors": [{"message": "a"}, {"message": "b"}]}.
+// It returns an empty string if no message was found.
+func jsonErrorMessage(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case map[string]interface{}:
+		for _, key := range jsonErrorMessageKeys {
+			if msg := jsonErrorMessage(value[key]); msg != "" {
+				return msg
+			}
+		}
+	case []interface{}:
+		var msgs []string
+		for _, item := range value {
+			if msg := jsonErrorMessage(item); msg != "" {
+				msgs = append(msgs, msg)
+			}
+		}
+		return strings.Join(msgs, ", ")
+	}
+
+	return ""
+}
+
 // jsonResourceError is defined for the groups/serviceaccounts/users
 type jsonResourceError struct {
 	Field     string `json:"field"`
@@ -175,7 +317,135 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 	if path[0] == '/' { // remove beginning slash, if any.
 		path = path[1:]
 	}
-	uri := c.Config.Host + "/" + path
+
+	// send the request and check the response for any connection & authorization errors here,
+	// on a connection error the next host, if any, is tried, see `ClientConfig#FallbackHosts`.
+	var (
+		uri  string
+		req  *http.Request
+		resp *http.Response
+		err  error
+	)
+
+	hosts := c.Config.Hosts()
+	active := c.activeHostIndex(len(hosts))
+	for i := range hosts {
+		idx := (active + i) % len(hosts)
+
+		uri = hosts[idx] + "/" + path
+		req, err = c.newRequest(method, uri, contentType, send, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if cached, ok := c.responseCache.get(req, path); ok {
+			return cached, nil
+		}
+
+		resp, err = c.send(req)
+		if err == nil {
+			c.setActiveHostIndex(idx)
+			break
+		}
+
+		if i == len(hosts)-1 || !isConnectionError(req, err) {
+			return nil, err
+		}
+
+		golog.Debugf("Client#Do.failover: [%s] is unreachable, trying [%s]: %v", hosts[idx], hosts[(idx+1)%len(hosts)], err)
+	}
+
+	if !isAuthorized(resp) {
+		resp.Body.Close() // close the body here so we don't have leaks.
+		return nil, ErrCredentialsMissing
+	}
+
+	if !isOK(resp) {
+		defer resp.Body.Close()
+		var (
+			errBody    string
+			errDetails map[string]interface{}
+			bodyBytes  []byte
+		)
+
+		if cType := resp.Header.Get(contentTypeHeaderKey); strings.Contains(cType, contentTypeJSON) {
+			// read it, it's an error in JSON format.
+			bodyBytes, _ = ioutil.ReadAll(resp.Body)
+			var jsonErr interface{}
+			if err = json.Unmarshal(bodyBytes, &jsonErr); err != nil {
+				// not a valid JSON after all, give the whole body to the error context below.
+				jsonErr = nil
+			}
+			errFieldsMap, ok := jsonErr.(map[string]interface{})
+			if ok {
+				errDetails = errFieldsMap
+
+				var jsonErrResp jsonResourceErrorV2
+				if err = mapstructure.Decode(errFieldsMap, &jsonErrResp); err == nil {
+					if jsonErrResp.ErrorType != "" {
+						errBody = fmt.Sprintf("%s ", jsonErrResp.ErrorType)
+					}
+					for i := range jsonErrResp.Fields {
+						for k, v := range jsonErrResp.Fields[i] {
+							errBody = fmt.Sprintf("%s%s:%s, ", errBody, k, v)
+						}
+					}
+					errBody = strings.TrimSpace(strings.TrimSuffix(errBody, ", "))
+				}
+
+				if errBody == "" {
+					// any other structured error, i.e {"error": {"message": "...", "details": [...]}}.
+					errBody = jsonErrorMessage(errFieldsMap)
+				}
+			} else if jsonErr != nil {
+				var jsonErrResp []jsonResourceError
+				err = mapstructure.Decode(jsonErr, &jsonErrResp)
+				for _, jsonError := range jsonErrResp {
+					if jsonError.ErrorType != "" {
+						errBody = fmt.Sprintf("%s", jsonError.ErrorType)
+					}
+					errBody = fmt.Sprintf("%s", errBody)
+				}
+			}
+		}
+
+		if errBody == "" {
+			if bodyBytes != nil {
+				errBody = string(bodyBytes)
+			} else {
+				// else give the whole body to the error context, i.e from "text/plain", "text/html" etc.
+				b, err := c.ReadResponseBody(resp)
+				if err != nil {
+					errBody = " unable to read body: " + err.Error()
+				} else {
+					errBody = string(b)
+				}
+			}
+		}
+
+		if errBody == "" {
+			errBody = fmt.Sprintf("Response returned status code %d", resp.StatusCode)
+		}
+
+		resErr := NewResourceError(resp.StatusCode, uri, method, errBody)
+		if errDetails != nil {
+			resErr.Details = errDetails
+			resErr.Raw = string(bodyBytes)
+			golog.Debugf("Client#Do.resp.Error:\n\turi: %s:%s\n\tbody: %s", method, uri, resErr.Raw)
+		}
+		resErr.RequestID = resp.Header.Get(xRequestIDHeaderKey)
+		if resErr.RequestID == "" {
+			resErr.RequestID = req.Header.Get(xRequestIDHeaderKey)
+		}
+
+		return nil, resErr
+	}
+
+	return c.responseCache.put(req, path, resp)
+}
+
+// newRequest creates a request of `Do`.
+func (c *Client) newRequest(method, uri, contentType string, send []byte, options []RequestOption) (*http.Request, error) {
 
 	golog.Debugf("Client#Do.req:\n\turi: %s:%s\n\tsend: %s", method, uri, string(send))
 
@@ -183,10 +453,14 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 	if err != nil {
 		return nil, err
 	}
+
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
 	// before sending requests here.
 
 	// Set explicit host and user-agent header
-	u, err := url.Parse(c.Config.Host)
+	u, err := url.Parse(uri)
 	hostHeader := u.Host
 	userAgentHeader := "lenses-cli/" + BuildVersion
 	req.Header.Set("Host", hostHeader)
@@ -195,6 +469,11 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 	// set the token header.
 	if c.Config.Token != "" {
 		req.Header.Set(xKafkaLensesTokenHeaderKey, c.Config.Token)
+
+		// act on behalf of another user, the box decides if that's allowed.
+		if c.Config.ImpersonateUser != "" {
+			req.Header.Set(xKafkaLensesImpersonateUserHeaderKey, c.Config.ImpersonateUser)
+		}
 	}
 
 	// set the content type if any.
@@ -205,6 +484,9 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 	// response accept gzipped content.
 	req.Header.Add(acceptEncodingHeaderKey, gzipEncodingHeaderValue)
 
+	// a unique id per call, can be overridden by the request options, see `WithRequestID`.
+	req.Header.Set(xRequestIDHeaderKey, uuid.NewString())
+
 	if c.PersistentRequestModifier != nil {
 		if err := c.PersistentRequestModifier(req); err != nil {
 			return nil, err
@@ -221,75 +503,272 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 	// --so bug reporters should be careful here to invalidate the token after that.
 	golog.Debugf("Client#Do.req.Headers: %#+v", req.Header)
 
-	// send the request and check the response for any connection & authorization errors here.
-	resp, err := c.client.Do(req)
+	return req, nil
+}
+
+// responseCache keeps the responses of the requests sent `WithCache`, see `responseCacheKey`.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]responseCacheEntry
+}
+
+type responseCacheEntry struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expiresAt  time.Time
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]responseCacheEntry)}
+}
+
+// responseCacheKey returns the key of the "req" response, the method, host, path and query
+// and the identity it is sent as, so a changed token or impersonated user is not served the response of another one.
+func responseCacheKey(req *http.Request, path string) string {
+	return strings.Join([]string{
+		req.Method,
+		req.URL.Host,
+		path,
+		req.Header.Get(xKafkaLensesTokenHeaderKey),
+		req.Header.Get(xKafkaLensesImpersonateUserHeaderKey),
+	}, " ")
+}
+
+// cacheTTL returns the time to keep the response of the "req" or false if it should not be cached.
+func cacheTTL(req *http.Request) (time.Duration, bool) {
+	if req.Method != http.MethodGet {
+		return 0, false
+	}
+
+	ttl, ok := req.Context().Value(cacheTTLContextKey{}).(time.Duration)
+	return ttl, ok && ttl > 0
+}
+
+func (cache *responseCache) get(req *http.Request, path string) (*http.Response, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	if _, ok := cacheTTL(req); !ok {
+		return nil, false
+	}
+
+	cache.mu.Lock()
+	entry, ok := cache.entries[responseCacheKey(req, path)]
+	cache.mu.Unlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	golog.Debugf("Client#Do.cache: %s:%s", req.Method, path)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.statusCode, http.StatusText(entry.statusCode)),
+		StatusCode:    entry.statusCode,
+		Header:        entry.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}, true
+}
+
+// put keeps the "resp" of the "req" if it was sent `WithCache` and succeeded,
+// its body is read and replaced so the caller can still read it.
+func (cache *responseCache) put(req *http.Request, path string, resp *http.Response) (*http.Response, error) {
+	if cache == nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, nil
+	}
+
+	ttl, ok := cacheTTL(req)
+	if !ok {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
 
-	if !isAuthorized(resp) {
-		resp.Body.Close() // close the body here so we don't have leaks.
-		return nil, ErrCredentialsMissing
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	// drop the expired ones so a long running client does not grow forever.
+	for k, entry := range cache.entries {
+		if now.After(entry.expiresAt) {
+			delete(cache.entries, k)
+		}
 	}
 
-	if !isOK(resp) {
-		defer resp.Body.Close()
-		var errBody string
+	cache.entries[responseCacheKey(req, path)] = responseCacheEntry{
+		statusCode: resp.StatusCode,
+		header:     resp.Header.Clone(),
+		body:       body,
+		expiresAt:  now.Add(ttl),
+	}
 
-		if cType := resp.Header.Get(contentTypeHeaderKey); strings.Contains(cType, contentTypeJSON) {
-			// read it, it's an error in JSON format.
-			bodyBytes, _ := ioutil.ReadAll(resp.Body)
-			resp.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
-			var jsonErr interface{}
-			if err = c.ReadJSON(resp, &jsonErr); err != nil {
-				return nil, err
-			}
-			errFieldsMap, ok := jsonErr.(map[string]interface{})
-			if ok {
-				var jsonErrResp jsonResourceErrorV2
-				err = mapstructure.Decode(errFieldsMap, &jsonErrResp)
-				if err != nil {
-					return nil, err
-				}
-				if jsonErrResp.ErrorType != "" {
-					errBody = fmt.Sprintf("%s ", jsonErrResp.ErrorType)
-				}
-				for i := range jsonErrResp.Fields {
-					for k, v := range jsonErrResp.Fields[i] {
-						errBody = fmt.Sprintf("%s%s:%s, ", errBody, k, v)
-					}
-				}
-				errBody = strings.TrimSuffix(errBody, ", ")
-			} else {
-				var jsonErrResp []jsonResourceError
-				err = mapstructure.Decode(jsonErr, &jsonErrResp)
-				for _, jsonError := range jsonErrResp {
-					if jsonError.ErrorType != "" {
-						errBody = fmt.Sprintf("%s", jsonError.ErrorType)
-					}
-					errBody = fmt.Sprintf("%s", errBody)
-				}
-			}
+	return resp, nil
+}
+
+func (cache *responseCache) clear() {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	cache.entries = make(map[string]responseCacheEntry)
+	cache.mu.Unlock()
+}
+
+// ClearCache drops all the responses kept by the calls sent `WithCache`,
+// see `InvalidateSchemaCache` for the schema caches.
+func (c *Client) ClearCache() {
+	c.responseCache.clear()
+}
+
+// activeHostIndex returns the index of the host which responded last, it is reset to the `ClientConfig#Host` if out of the "n" hosts.
+func (c *Client) activeHostIndex(n int) int {
+	if c.activeHost == nil {
+		return 0
+	}
+
+	if idx := int(atomic.LoadInt32(c.activeHost)); idx < n {
+		return idx
+	}
+
+	return 0
+}
+
+func (c *Client) setActiveHostIndex(idx int) {
+	if c.activeHost != nil {
+		atomic.StoreInt32(c.activeHost, int32(idx))
+	}
+}
+
+// isConnectionError reports whether the "err" of sending the "req" means that the host could not be reached,
+// i.e a refused connection or a timeout, a request canceled by its context is not.
+// Only a failed dial is safe to send again for any method, once the connection is made the box may have
+// received the request, so the rest of the errors, i.e. a reset or a read timeout, fail over idempotent requests only.
+func isConnectionError(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	if !isIdempotent(req.Method) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &opErr) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// isIdempotent reports whether a request of the "method" can be sent more than once with the same effect.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	defaultMaxRetries = 3
+	// maxRetryWait caps the wait between the retries, a far "Retry-After" fails the call instead of blocking it.
+	maxRetryWait = time.Minute
+)
+
+// retryWait waits for "d" or until the "ctx" is done, it is a variable so tests do not have to wait.
+var retryWait = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send sends the "req" and, while the box responds with 429 (Too Many Requests),
+// it waits as the "Retry-After" header says and sends it again, up to `ClientConfig#MaxRetries` times.
+// The last response is returned as it is.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	maxRetries := c.Config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.client.Do(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRetries {
+			return resp, err
 		}
 
-		if errBody == "" {
-			// else give the whole body to the error context, i.e from "text/plain", "text/html" etc.
-			b, err := c.ReadResponseBody(resp)
-			if err != nil {
-				errBody = " unable to read body: " + err.Error()
-			} else {
-				errBody = string(b)
+		// a streamed body cannot be sent again, see `withBodyStream`.
+		if req.GetBody == nil && req.Body != nil && req.Body != http.NoBody {
+			return resp, nil
+		}
+
+		wait, ok := parseRetryAfter(resp.Header.Get(retryAfterHeaderKey), time.Now())
+		if !ok {
+			wait = time.Second << attempt
+		}
+
+		if wait > maxRetryWait {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		golog.Debugf("Client#Do.retry: [%s] responded with 429, retry [%d/%d] in [%s]", req.URL, attempt+1, maxRetries, wait)
+
+		if err = retryWait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
 			}
 		}
+	}
+}
 
-		if errBody == "" {
-			errBody = fmt.Sprintf("Response returned status code %d", resp.StatusCode)
+// parseRetryAfter parses the "Retry-After" header value, either delay seconds or an HTTP date,
+// and returns the duration to wait from "now".
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
 		}
+		return time.Duration(seconds) * time.Second, true
+	}
 
-		return nil, NewResourceError(resp.StatusCode, uri, method, errBody)
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
 	}
 
-	return resp, nil
+	if wait := at.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
 }
 
 type gzipReadCloser struct {
@@ -402,38 +881,133 @@ func (c *Client) ReadResponseBody(resp *http.Response) ([]byte, error) {
 	if c.Config.Debug {
 		rawBodyString := string(b)
 
-		if strings.Contains(resp.Header.Get(contentTypeHeaderKey), "text/html") {
-			// If debug will print the full body through "rawBodyString", but the error here is the same content,
-			// so no need to duplicate it.
-			// The error should be minimal in this case in order to be resolved by callers: `lenses.ErrUnknownResponse`, same for !debug.
-			err = ErrUnknownResponse
+		if strings.Contains(resp.Header.Get(contentTypeHeaderKey), "text/html") {
+			// If debug will print the full body through "rawBodyString", but the error here is the same content,
+			// so no need to duplicate it.
+			// The error should be minimal in this case in order to be resolved by callers: `lenses.ErrUnknownResponse`, same for !debug.
+			err = ErrUnknownResponse
+		}
+
+		// print both body and error, because both of them may be formated by the `readResponseBody`'s caller.
+		golog.Debugf("Client#Do.resp:\n\tbody: %s\n\tstatus code: %d\n\terror: [%v]", rawBodyString, resp.StatusCode, err)
+	}
+
+	// return the body.
+	return b, err
+}
+
+// ReadJSON is one of the lower-level methods of the client to read the result of a `Client#Do`, it closes the body stream.
+//
+// See `ReadResponseBody` lower-level of method to read a response for more details.
+func (c *Client) ReadJSON(resp *http.Response, valuePtr interface{}) error {
+	b, err := c.ReadResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if c.Config.StrictJSON && len(bytes.TrimSpace(b)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.DisallowUnknownFields()
+		if err = decoder.Decode(valuePtr); err != nil && resp.Request != nil {
+			err = fmt.Errorf("client: strict json: %s %s: %w", resp.Request.Method, resp.Request.URL.Path, err)
+		}
+	} else {
+		err = json.Unmarshal(b, valuePtr)
+	}
+
+	if c.Config.Debug {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			golog.Errorf("Client#ReadJSON: syntax error at offset [%d]: [%s]", syntaxErr.Offset, syntaxErr.Error())
+		}
+	}
+	return err
+}
+
+// GetByURL fetches a URL given by the box, i.e. the `Quota#URL`, and decodes its JSON response to "valuePtr",
+// so a link of a response can be followed without rebuilding its path.
+//
+// The "rawURL" can be relative to the box or absolute, an absolute one should point to one of the `ClientConfig#Hosts`,
+// the credentials of the client are never sent to any other origin.
+func (c *Client) GetByURL(rawURL string, valuePtr interface{}) error {
+	if rawURL == "" {
+		return errRequired("url")
+	}
+
+	path, err := c.boxRelativePath(rawURL)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(http.MethodGet, path, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return c.ReadJSON(resp, valuePtr)
+}
+
+// boxRelativePath returns the path and query of "rawURL" relative to the box host,
+// it fails if "rawURL" is absolute and its scheme, host and port are not the ones of the box.
+func (c *Client) boxRelativePath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("client: invalid url [%s]: %w", rawURL, err)
+	}
+
+	path := u.EscapedPath()
+
+	if u.Scheme != "" || u.Host != "" {
+		matched := false
+		for _, host := range c.Config.Hosts() {
+			hostURL, err := url.Parse(host)
+			if err != nil || !sameOrigin(u, hostURL) {
+				continue
+			}
+
+			// the box may be served under a base path, i.e. http://domain.com/lenses.
+			if base := strings.TrimSuffix(hostURL.EscapedPath(), "/"); base != "" {
+				if path != base && !strings.HasPrefix(path, base+"/") {
+					continue
+				}
+				path = strings.TrimPrefix(path, base)
+			}
+
+			matched = true
+			break
 		}
 
-		// print both body and error, because both of them may be formated by the `readResponseBody`'s caller.
-		golog.Debugf("Client#Do.resp:\n\tbody: %s\n\tstatus code: %d\n\terror: [%v]", rawBodyString, resp.StatusCode, err)
+		if !matched {
+			return "", fmt.Errorf("client: url [%s] does not belong to the box [%s]", rawURL, c.Config.Host)
+		}
 	}
 
-	// return the body.
-	return b, err
-}
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return "", fmt.Errorf("client: url [%s] has no path", rawURL)
+	}
 
-// ReadJSON is one of the lower-level methods of the client to read the result of a `Client#Do`, it closes the body stream.
-//
-// See `ReadResponseBody` lower-level of method to read a response for more details.
-func (c *Client) ReadJSON(resp *http.Response, valuePtr interface{}) error {
-	b, err := c.ReadResponseBody(resp)
-	if err != nil {
-		return err
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
 	}
 
-	err = json.Unmarshal(b, valuePtr)
+	return path, nil
+}
 
-	if c.Config.Debug {
-		if syntaxErr, ok := err.(*json.SyntaxError); ok {
-			golog.Errorf("Client#ReadJSON: syntax error at offset [%d]: [%s]", syntaxErr.Offset, syntaxErr.Error())
+// sameOrigin reports whether "u" and "other" have the same scheme, host and port, the port defaults by the scheme.
+func sameOrigin(u, other *url.URL) bool {
+	port := func(u *url.URL) string {
+		if p := u.Port(); p != "" {
+			return p
 		}
+		if strings.EqualFold(u.Scheme, "https") {
+			return "443"
+		}
+		return "80"
 	}
-	return err
+
+	return strings.EqualFold(u.Scheme, other.Scheme) &&
+		strings.EqualFold(u.Hostname(), other.Hostname()) &&
+		port(u) == port(other)
 }
 
 // GetAccessToken returns the access token that
@@ -460,6 +1034,15 @@ func (c *Client) Logout() error {
 	return resp.Body.Close()
 }
 
+// Close closes the idle keep-alive connections of the underline HTTP client,
+// the Client can still be used after Close, new connections are opened on demand.
+// Close does not invalidate the token, call `Logout` before Close to revoke its access too.
+func (c *Client) Close() {
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+}
+
 // QueryFiltering used to add query params in an API request
 type QueryFiltering struct {
 	PageSize     int
@@ -694,6 +1277,47 @@ func (c *Client) GetExecutionMode() (ExecutionMode, error) {
 	return cfg.SQLExecutionMode, nil
 }
 
+// GetSchemaRegistryURLs returns the urls of the schema registry configured on the box.
+func (c *Client) GetSchemaRegistryURLs() ([]string, error) {
+	cfg, err := c.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return boxURLs(cfg.SchemaRegistryURLs), nil
+}
+
+// GetZookeeperHosts returns the zookeeper hosts configured on the box.
+func (c *Client) GetZookeeperHosts() ([]string, error) {
+	cfg, err := c.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return boxURLs(cfg.ZookeeperHosts), nil
+}
+
+// GetSecurityMode returns the security mode of the box, i.e. "BASIC" or "LDAP".
+func (c *Client) GetSecurityMode() (string, error) {
+	cfg, err := c.GetConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.SecurityMode, nil
+}
+
+func boxURLs(props []BoxURLConfigProperty) []string {
+	urls := make([]string, 0, len(props))
+	for _, prop := range props {
+		if prop.URL != "" {
+			urls = append(urls, prop.URL)
+		}
+	}
+
+	return urls
+}
+
 // ConnectCluster contains the connect cluster information that is returned by the `GetConnectClusters` call.
 type ConnectCluster struct {
 	Name     string `json:"name" header:"Name"`
@@ -1022,6 +1646,35 @@ func (c *Client) GetTopicsNames() ([]string, error) {
 	return topicNames, nil
 }
 
+// GetTopicsInNamespace returns the topics whose name matches the "namespace",
+// a data namespace wildcard as set on the groups' namespaces, i.e. "finance-*".
+//
+// Lenses already returns only the topics the caller's groups have access to,
+// this is a client-side filter for callers with a broad access, i.e. admins, to view a single tenant.
+func (c *Client) GetTopicsInNamespace(namespace string) ([]Topic, error) {
+	if namespace == "" {
+		return nil, errRequired("namespace")
+	}
+
+	if _, err := filepath.Match(namespace, ""); err != nil {
+		return nil, fmt.Errorf("invalid namespace [%s]: %v", namespace, err)
+	}
+
+	topics, err := c.GetTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	var inNamespace []Topic
+	for _, topic := range topics {
+		if ok, _ := filepath.Match(namespace, topic.TopicName); ok {
+			inNamespace = append(inNamespace, topic)
+		}
+	}
+
+	return inNamespace, nil
+}
+
 const topicsAvailableConfigKeysPath = "api/configs/default/topics/keys"
 
 // GetAvailableTopicConfigKeys retrieves a list of available configs for topics.
@@ -1039,11 +1692,63 @@ func (c *Client) GetAvailableTopicConfigKeys() ([]string, error) {
 	return keys, nil
 }
 
+const topicsConfigDefaultsPath = "api/configs/default/topics"
+
+// TopicConfigDefault describes a topic configuration key along with its default value and type,
+// see `GetTopicConfigDefaults`.
+type TopicConfigDefault struct {
+	Key     string `json:"name" yaml:"name" header:"Key"`
+	Default string `json:"defaultValue" yaml:"defaultValue" header:"Default"`
+	Type    string `json:"type" yaml:"type" header:"Type"`
+	Doc     string `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+}
+
+// IsDefault reports whether the "value" matches the default value of the configuration,
+// i.e. to skip setting a configuration which already has the desired value.
+func (d TopicConfigDefault) IsDefault(value string) bool {
+	return strings.TrimSpace(value) == d.Default
+}
+
+// GetTopicConfigDefaults retrieves the available configs for topics along with their default values, types and documentation,
+// keyed by the config key.
+func (c *Client) GetTopicConfigDefaults() (map[string]TopicConfigDefault, error) {
+	resp, err := c.Do(http.MethodGet, topicsConfigDefaultsPath, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		TopicConfigDefault
+		// default values can be strings, numbers, booleans or null.
+		Default json.RawMessage `json:"defaultValue"`
+	}
+	if err = c.ReadJSON(resp, &entries); err != nil {
+		return nil, err
+	}
+
+	defaults := make(map[string]TopicConfigDefault, len(entries))
+	for _, entry := range entries {
+		d := entry.TopicConfigDefault
+
+		var s string
+		if err := json.Unmarshal(entry.Default, &s); err == nil {
+			d.Default = s
+		} else if raw := string(entry.Default); raw != "null" {
+			d.Default = raw
+		}
+
+		defaults[d.Key] = d
+	}
+
+	return defaults, nil
+}
+
 type (
 	// TopicMetadata describes the data received from the `GetTopicsMetadata`
 	// and the payload to send on the `CreateTopicMetadata`.
 	TopicMetadata struct {
 		TopicName string `json:"topicName" yaml:"topicName" header:"Topic"`
+		// KeyType and ValueType are the `TopicDataFormat`s of the topic's key and value.
 		KeyType   string `json:"keyType,omitempty" yaml:"keyType" header:"Key /,NULL"`
 		ValueType string `json:"valueType,omitempty" yaml:"valueType" header:"Value Type,NULL"`
 
@@ -1079,6 +1784,72 @@ type (
 	*/
 )
 
+// TopicDataFormat is the format of a topic's key or value, see `TopicMetadata`.
+type TopicDataFormat string
+
+// The topic data formats supported by Lenses.
+const (
+	TopicDataFormatAvro     TopicDataFormat = "AVRO"
+	TopicDataFormatJSON     TopicDataFormat = "JSON"
+	TopicDataFormatProtobuf TopicDataFormat = "PROTOBUF"
+	TopicDataFormatString   TopicDataFormat = "STRING"
+	TopicDataFormatInt      TopicDataFormat = "INT"
+	TopicDataFormatLong     TopicDataFormat = "LONG"
+	TopicDataFormatDouble   TopicDataFormat = "DOUBLE"
+	TopicDataFormatBytes    TopicDataFormat = "BYTES"
+	TopicDataFormatXML      TopicDataFormat = "XML"
+	TopicDataFormatCSV      TopicDataFormat = "CSV"
+)
+
+// TopicDataFormats contains all the supported topic data formats.
+var TopicDataFormats = []TopicDataFormat{
+	TopicDataFormatAvro,
+	TopicDataFormatJSON,
+	TopicDataFormatProtobuf,
+	TopicDataFormatString,
+	TopicDataFormatInt,
+	TopicDataFormatLong,
+	TopicDataFormatDouble,
+	TopicDataFormatBytes,
+	TopicDataFormatXML,
+	TopicDataFormatCSV,
+}
+
+// ParseTopicDataFormat returns the `TopicDataFormat` of "s", matched case-insensitively.
+// It returns an error if "s" is not a supported format.
+func ParseTopicDataFormat(s string) (TopicDataFormat, error) {
+	for _, format := range TopicDataFormats {
+		if strings.EqualFold(s, string(format)) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported topic data format [%s], supported formats are: %v", s, TopicDataFormats)
+}
+
+// Validate normalizes the KeyType and the ValueType to their `TopicDataFormat`.
+// A format which is not one of the `TopicDataFormats` is kept as it is, with a warning,
+// so the box decides whether it is valid, e.g. a format of a newer Lenses version.
+func (meta *TopicMetadata) Validate() error {
+	meta.KeyType = normalizeTopicDataFormat("keyType", meta.KeyType)
+	meta.ValueType = normalizeTopicDataFormat("valueType", meta.ValueType)
+	return nil
+}
+
+func normalizeTopicDataFormat(field, s string) string {
+	if s == "" {
+		return s
+	}
+
+	format, err := ParseTopicDataFormat(s)
+	if err != nil {
+		golog.Warnf("%s: %v", field, err)
+		return s
+	}
+
+	return string(format)
+}
+
 const (
 	topicsMetadataPath = "api/metadata/topics"
 	topicMetadataPath  = topicsMetadataPath + "/%s"
@@ -1116,7 +1887,11 @@ func (c *Client) GetTopicMetadata(topicName string) (TopicMetadata, error) {
 }
 
 // CreateOrUpdateTopicMetadata adds or updates an existing topic metadata.
+// The KeyType and ValueType are normalized to their `TopicDataFormat`s, see `TopicMetadata#Validate`.
 func (c *Client) CreateOrUpdateTopicMetadata(metadata TopicMetadata) error {
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
 
 	payload, err := json.Marshal(metadata)
 	if err != nil {
@@ -1187,6 +1962,75 @@ func (c *Client) CreateTopic(topicName string, replication, partitions int, conf
 	return resp.Body.Close()
 }
 
+// CreateTopicWithAssignmentPayload contains the data that the `CreateTopicWithAssignment` sends to the backend,
+// the replication and partitions are derived from the "ReplicaAssignment" by the broker.
+type CreateTopicWithAssignmentPayload struct {
+	TopicName         string        `json:"topicName" yaml:"name"`
+	ReplicaAssignment map[int][]int `json:"replicaAssignment" yaml:"replicaAssignment"`
+	Configs           KV            `json:"configs" yaml:"configs"`
+}
+
+// CreateTopicWithAssignment creates a topic with an explicit placement of its replicas,
+// i.e for a rack-aware or a manual placement of the partitions.
+//
+// topicName, string, Required.
+// replicaAssignment, partition to the broker ids of its replicas, the first is the preferred leader, Required.
+// configs, topic key - value.
+//
+// Every partition, from 0 to the number of partitions, should have the same number of distinct replicas.
+func (c *Client) CreateTopicWithAssignment(topicName string, replicaAssignment map[int][]int, configs KV) error {
+	if topicName == "" {
+		return errRequired("topicName")
+	}
+
+	if len(replicaAssignment) == 0 {
+		return errRequired("replicaAssignment")
+	}
+
+	replication := len(replicaAssignment[0])
+	for partition := 0; partition < len(replicaAssignment); partition++ {
+		brokers, ok := replicaAssignment[partition]
+		if !ok {
+			return fmt.Errorf("replica assignment: partition [%d] is missing, partitions should be numbered from 0 to %d", partition, len(replicaAssignment)-1)
+		}
+
+		if len(brokers) == 0 {
+			return fmt.Errorf("replica assignment: partition [%d] has no replicas", partition)
+		}
+
+		if len(brokers) != replication {
+			return fmt.Errorf("replica assignment: partition [%d] has %d replicas, partition [0] has %d, all partitions should have the same replica count",
+				partition, len(brokers), replication)
+		}
+
+		seen := make(map[int]bool, len(brokers))
+		for _, broker := range brokers {
+			if seen[broker] {
+				return fmt.Errorf("replica assignment: partition [%d] has broker [%d] more than once", partition, broker)
+			}
+			seen[broker] = true
+		}
+	}
+
+	payload := CreateTopicWithAssignmentPayload{
+		TopicName:         topicName,
+		ReplicaAssignment: replicaAssignment,
+		Configs:           configs,
+	}
+
+	send, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(http.MethodPost, topicsPath, contentTypeJSON, send)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
 const (
 	topicPath        = topicsPath + "/%s"
 	topicRecordsPath = topicPath + "/%d/%d"
@@ -1234,6 +2078,181 @@ func (c *Client) DeleteTopicRecords(topicName string, fromPartition int, toOffse
 	return resp.Body.Close()
 }
 
+// TopicPartitionOffset describes an offset of a topic's partition, see `GetTopicOffsetsForTimestamp`.
+type TopicPartitionOffset struct {
+	Partition int   `json:"partition" yaml:"partition" header:"Partition"`
+	Offset    int64 `json:"offset" yaml:"offset" header:"Offset"`
+}
+
+const topicOffsetsPath = topicPath + "/offsets?timestamp=%d"
+
+// GetTopicOffsetsForTimestamp returns, per partition, the earliest offset whose timestamp is equal or greater than the "at" time,
+// the offset is -1 when a partition has no records at or after that time.
+func (c *Client) GetTopicOffsetsForTimestamp(topicName string, at time.Time) (offsets []TopicPartitionOffset, err error) {
+	if topicName == "" {
+		err = errRequired("topicName")
+		return
+	}
+
+	path := fmt.Sprintf(topicOffsetsPath, topicName, at.UnixNano()/int64(time.Millisecond))
+	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	if respErr != nil {
+		err = respErr
+		return
+	}
+
+	err = c.ReadJSON(resp, &offsets)
+	return
+}
+
+// TopicRecordsDeletion describes the result of a partition's records deletion, see `DeleteTopicRecordsBefore`.
+type TopicRecordsDeletion struct {
+	Partition int   `json:"partition" yaml:"partition" header:"Partition"`
+	ToOffset  int64 `json:"toOffset" yaml:"toOffset" header:"To Offset"`
+	Skipped   bool  `json:"skipped" yaml:"skipped" header:"Skipped"`
+}
+
+// DeleteTopicRecordsBefore deletes, on every partition of a topic, the records older than the "before" time.
+// The offset of each partition is resolved through the `GetTopicOffsetsForTimestamp`,
+// partitions without records before that time are skipped.
+//
+// It returns the per-partition results, see `DeleteTopicRecords` too.
+func (c *Client) DeleteTopicRecordsBefore(topicName string, before time.Time) ([]TopicRecordsDeletion, error) {
+	if topicName == "" {
+		return nil, errRequired("topicName")
+	}
+
+	topic, err := c.GetTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets, err := c.GetTopicOffsetsForTimestamp(topicName, before)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[int]int64, len(offsets))
+	for _, offset := range offsets {
+		resolved[offset.Partition] = offset.Offset
+	}
+
+	results := make([]TopicRecordsDeletion, 0, len(topic.MessagesPerPartition))
+	for _, partition := range topic.MessagesPerPartition {
+		toOffset, ok := resolved[partition.Partition]
+		if !ok || toOffset < 0 {
+			// no records at or after the given time, all of them are older.
+			toOffset = partition.End
+		}
+
+		result := TopicRecordsDeletion{Partition: partition.Partition, ToOffset: toOffset}
+		if toOffset <= partition.Begin {
+			result.Skipped = true
+			results = append(results, result)
+			continue
+		}
+
+		if err = c.DeleteTopicRecords(topicName, partition.Partition, toOffset); err != nil {
+			return results, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+const partitionReassignmentsPath = "api/v1/kafka/reassignments"
+
+// PartitionReassignment describes an in-progress reassignment of a topic partition's replicas,
+// see `GetPartitionReassignments`.
+type PartitionReassignment struct {
+	Topic           string `json:"topic" yaml:"topic" header:"Topic"`
+	Partition       int    `json:"partition" yaml:"partition" header:"Partition,text"`
+	CurrentReplicas []int  `json:"currentReplicas" yaml:"currentReplicas" header:"Current Replicas"`
+	TargetReplicas  []int  `json:"targetReplicas" yaml:"targetReplicas" header:"Target Replicas"`
+}
+
+// GetPartitionReassignments returns the partition reassignments which are currently in progress, sorted by topic and partition.
+// It returns an empty slice when there is no reassignment in progress.
+func (c *Client) GetPartitionReassignments() ([]PartitionReassignment, error) {
+	resp, err := c.Do(http.MethodGet, partitionReassignmentsPath, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.ReadResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	reassignments := make([]PartitionReassignment, 0)
+	if len(bytes.TrimSpace(b)) == 0 {
+		return reassignments, nil
+	}
+
+	if err = json.Unmarshal(b, &reassignments); err != nil {
+		return nil, err
+	}
+
+	if reassignments == nil {
+		// "null" body.
+		reassignments = make([]PartitionReassignment, 0)
+	}
+
+	sort.Slice(reassignments, func(i, j int) bool {
+		if reassignments[i].Topic == reassignments[j].Topic {
+			return reassignments[i].Partition < reassignments[j].Partition
+		}
+		return reassignments[i].Topic < reassignments[j].Topic
+	})
+
+	return reassignments, nil
+}
+
+const topicPartitionsPath = "api/v1/kafka/topics/%s/partitions"
+
+// ReplicaPlacement describes the placement of a topic partition's replicas on the brokers,
+// see `GetTopicReplicaPlacement`.
+type ReplicaPlacement struct {
+	Partition       int   `json:"partition" yaml:"partition" header:"Partition,text"`
+	Leader          int   `json:"leader" yaml:"leader" header:"Leader,text"` // -1 when the partition has no leader.
+	Replicas        []int `json:"replicas" yaml:"replicas" header:"Replicas"`
+	InSyncReplicas  []int `json:"isr" yaml:"isr" header:"ISR"`
+	OfflineReplicas []int `json:"offlineReplicas" yaml:"offlineReplicas" header:"Offline"`
+}
+
+// UnderReplicated reports whether some of the partition's replicas are not in sync.
+func (p ReplicaPlacement) UnderReplicated() bool {
+	return len(p.InSyncReplicas) < len(p.Replicas)
+}
+
+// GetTopicReplicaPlacement returns the leader, the replicas, the in-sync and the offline replicas
+// of each partition of the "topicName" topic, keyed by partition.
+func (c *Client) GetTopicReplicaPlacement(topicName string) (map[int]ReplicaPlacement, error) {
+	if topicName == "" {
+		return nil, errRequired("topicName")
+	}
+
+	path := fmt.Sprintf(topicPartitionsPath, url.PathEscape(topicName))
+	resp, err := c.Do(http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var partitions []ReplicaPlacement
+	if err = c.ReadJSON(resp, &partitions); err != nil {
+		return nil, err
+	}
+
+	placement := make(map[int]ReplicaPlacement, len(partitions))
+	for _, partition := range partitions {
+		placement[partition.Partition] = partition
+	}
+
+	return placement, nil
+}
+
 const updateTopicConfigPath = "api/configs/topics/%s"
 
 // KeyVal contains the data configs to send for a topic update.
@@ -1291,18 +2310,78 @@ func (c *Client) UpdateTopicPartitions(topicName string, partitions int) error {
 		Partitions int `json:"partitions"`
 	}
 
-	path := fmt.Sprintf("api/v1/kafka/topics/%s/partitions", topicName)
-	payload, err := json.Marshal(PartitionUpdatePayload{Partitions: partitions})
-	if err != nil {
-		return err
+	path := fmt.Sprintf("api/v1/kafka/topics/%s/partitions", topicName)
+	payload, err := json.Marshal(PartitionUpdatePayload{Partitions: partitions})
+	if err != nil {
+		return err
+	}
+	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// ReplicaThrottle is a replica of a topic partition on a broker,
+// the leader and follower replication of the throttled replicas is limited by the brokers' throttled rate.
+// See `SetReplicaThrottle`.
+type ReplicaThrottle struct {
+	Partition int `json:"partition" yaml:"partition"`
+	Broker    int `json:"broker" yaml:"broker"`
+}
+
+// String returns the replica in the form of [PartitionId]:[BrokerId].
+func (t ReplicaThrottle) String() string {
+	return fmt.Sprintf("%d:%d", t.Partition, t.Broker)
+}
+
+const (
+	leaderThrottledReplicasConfigKey   = "leader.replication.throttled.replicas"
+	followerThrottledReplicasConfigKey = "follower.replication.throttled.replicas"
+)
+
+// SetReplicaThrottle throttles the leader and follower replication of the "partitionBrokerPairs" replicas of a topic,
+// i.e. the replicas that move during a partition reassignment. It replaces any previously throttled replicas of the topic.
+func (c *Client) SetReplicaThrottle(topicName string, partitionBrokerPairs []ReplicaThrottle) error {
+	if len(partitionBrokerPairs) == 0 {
+		return errRequired("partitionBrokerPairs")
 	}
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, payload)
-	if err != nil {
-		return err
+
+	seen := make(map[ReplicaThrottle]struct{}, len(partitionBrokerPairs))
+	replicas := make([]string, 0, len(partitionBrokerPairs))
+	for _, pair := range partitionBrokerPairs {
+		if pair.Partition < 0 || pair.Broker < 0 {
+			return fmt.Errorf("invalid replica throttle [%s], partition and broker must not be negative", pair)
+		}
+
+		if _, ok := seen[pair]; ok {
+			continue
+		}
+		seen[pair] = struct{}{}
+		replicas = append(replicas, pair.String())
 	}
-	resp.Body.Close()
 
-	return nil
+	return c.updateReplicaThrottle(topicName, strings.Join(replicas, ","))
+}
+
+// ThrottleAll throttles the leader and follower replication of all the replicas of a topic, using the '*' wildcard.
+func (c *Client) ThrottleAll(topicName string) error {
+	return c.updateReplicaThrottle(topicName, "*")
+}
+
+// ClearReplicaThrottle removes the leader and follower replication throttling of a topic's replicas,
+// i.e. after a partition reassignment is completed.
+func (c *Client) ClearReplicaThrottle(topicName string) error {
+	return c.updateReplicaThrottle(topicName, "")
+}
+
+func (c *Client) updateReplicaThrottle(topicName, replicas string) error {
+	return c.UpdateTopicConfig(topicName, []KV{{
+		leaderThrottledReplicasConfigKey:   replicas,
+		followerThrottledReplicasConfigKey: replicas,
+	}})
 }
 
 type topicsResponse struct {
@@ -1330,6 +2409,159 @@ type Topic struct {
 	IsMarkedForDeletion  bool               `json:"isMarkedForDeletion" header:"Marked Del"`
 }
 
+// TopicConfigServerManagedKeys are the topic config keys which are set or derived by the broker itself,
+// i.e the `message.format.version` follows the inter broker protocol version.
+// They are not counted as a change when comparing topic configs, see `TopicConfig.ComparableKeys` and `TopicConfigDiff`.
+var TopicConfigServerManagedKeys = map[string]bool{
+	"message.format.version":                  true,
+	"leader.replication.throttled.replicas":   true,
+	"follower.replication.throttled.replicas": true,
+}
+
+// ComparableKeys returns the user-settable configs of the `KV`,
+// the keys of `TopicConfigServerManagedKeys` are excluded.
+func (c TopicConfig) ComparableKeys() KV {
+	comparable := make(KV, len(c.KV))
+	for k, v := range c.KV {
+		if TopicConfigServerManagedKeys[k] {
+			continue
+		}
+		comparable[k] = v
+	}
+
+	return comparable
+}
+
+// ConfigKV returns the topic's `Configs` as a single map of the config name and its original value.
+func (topic Topic) ConfigKV() KV {
+	kv := make(KV, len(topic.Configs))
+	for _, conf := range topic.Configs {
+		kv[fmt.Sprintf("%v", conf["name"])] = conf["originalValue"]
+	}
+
+	return kv
+}
+
+// TopicConfigDiff returns the "desired" configs whose value differs from or is missing in the "current" configs,
+// the server managed keys are ignored on both sides.
+func TopicConfigDiff(current, desired KV) KV {
+	current = TopicConfig{KV: current}.ComparableKeys()
+
+	diff := make(KV)
+	for k, v := range (TopicConfig{KV: desired}).ComparableKeys() {
+		if existing, ok := current[k]; !ok || fmt.Sprintf("%v", existing) != fmt.Sprintf("%v", v) {
+			diff[k] = v
+		}
+	}
+
+	return diff
+}
+
+// GetTopicConfigForComparison returns the user-settable configs of a topic,
+// ready to be compared with the desired configs, see `TopicConfigDiff`.
+func (c *Client) GetTopicConfigForComparison(topicName string) (KV, error) {
+	topic, err := c.GetTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	return TopicConfig{KV: topic.ConfigKV()}.ComparableKeys(), nil
+}
+
+// TopicConfigSource is where the value of a topic config comes from, see `TopicConfigEntry`.
+type TopicConfigSource string
+
+// The available topic config sources.
+const (
+	// TopicConfigSourceTopic is a value overridden on the topic itself.
+	TopicConfigSourceTopic TopicConfigSource = "TOPIC"
+	// TopicConfigSourceDynamic is a value set dynamically on the brokers, for one or all of them.
+	TopicConfigSourceDynamic TopicConfigSource = "DYNAMIC"
+	// TopicConfigSourceStatic is a value of the brokers' server.properties.
+	TopicConfigSourceStatic TopicConfigSource = "STATIC"
+	// TopicConfigSourceDefault is the Kafka default value.
+	TopicConfigSourceDefault TopicConfigSource = "DEFAULT"
+)
+
+// topicConfigSources maps the Kafka describe configs sources to the `TopicConfigSource`.
+var topicConfigSources = map[string]TopicConfigSource{
+	"DYNAMIC_TOPIC_CONFIG":          TopicConfigSourceTopic,
+	"DYNAMIC_BROKER_CONFIG":         TopicConfigSourceDynamic,
+	"DYNAMIC_DEFAULT_BROKER_CONFIG": TopicConfigSourceDynamic,
+	"STATIC_BROKER_CONFIG":          TopicConfigSourceStatic,
+	"DEFAULT_CONFIG":                TopicConfigSourceDefault,
+}
+
+// TopicConfigEntry is the value of a topic config along with its source, see `GetTopicConfigWithSource`.
+type TopicConfigEntry struct {
+	Value  string            `json:"value" yaml:"value" header:"Value"`
+	Source TopicConfigSource `json:"source" yaml:"source" header:"Source"`
+}
+
+const topicDescribeConfigsPath = "api/v1/kafka/topics/%s/configs"
+
+// GetTopicConfigWithSource returns the configs of a topic, keyed by the config name, along with where each value comes from,
+// i.e. to find the configs which are explicitly overridden on the topic.
+// The sources are read from the describe configs of the box, if it does not expose them
+// the topic's configs can only tell apart the `TopicConfigSourceTopic` overrides from the `TopicConfigSourceDefault` values.
+func (c *Client) GetTopicConfigWithSource(topicName string) (map[string]TopicConfigEntry, error) {
+	if topicName == "" {
+		return nil, errRequired("topicName")
+	}
+
+	resp, err := c.Do(http.MethodGet, fmt.Sprintf(topicDescribeConfigsPath, url.PathEscape(topicName)), "", nil)
+	if err == nil {
+		var described []struct {
+			Name   string  `json:"name"`
+			Value  *string `json:"value"`
+			Source string  `json:"source"`
+		}
+		if err = c.ReadJSON(resp, &described); err != nil {
+			return nil, err
+		}
+
+		entries := make(map[string]TopicConfigEntry, len(described))
+		for _, conf := range described {
+			source, ok := topicConfigSources[strings.ToUpper(conf.Source)]
+			if !ok {
+				source = TopicConfigSource(strings.ToUpper(conf.Source))
+			}
+
+			entry := TopicConfigEntry{Source: source}
+			if conf.Value != nil {
+				entry.Value = *conf.Value
+			}
+			entries[conf.Name] = entry
+		}
+
+		return entries, nil
+	}
+
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	topic, err := c.GetTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]TopicConfigEntry, len(topic.Configs))
+	for _, conf := range topic.Configs {
+		entry := TopicConfigEntry{Source: TopicConfigSourceTopic}
+		if isDefault, _ := conf["isDefault"].(bool); isDefault {
+			entry.Source = TopicConfigSourceDefault
+		}
+		if value, ok := conf["originalValue"]; ok && value != nil {
+			entry.Value = fmt.Sprintf("%v", value)
+		}
+
+		entries[fmt.Sprintf("%v", conf["name"])] = entry
+	}
+
+	return entries, nil
+}
+
 // GetTopicAsRequest takes a topic returned from Lenses and transforms to a request
 func (topic *Topic) GetTopicAsRequest(config KV) CreateTopicPayload {
 	return CreateTopicPayload{
@@ -1343,20 +2575,20 @@ func (topic *Topic) GetTopicAsRequest(config KV) CreateTopicPayload {
 
 // ConsumersGroup describes the data that the `Topic`'s  `ConsumersGroup` field contains.
 type ConsumersGroup struct {
-	ID          string              `json:"id"`
+	ID          string              `json:"id" header:"ID"`
 	Coordinator ConsumerCoordinator `json:"coordinator"`
 	// On consumers not active/committing offsets - we don't get any of the following info
-	Active               bool               `json:"active"`
-	State                ConsumerGroupState `json:"state"`
+	Active               bool               `json:"active" header:"Active"`
+	State                ConsumerGroupState `json:"state" header:"State"`
 	Consumers            []string           `json:"consumers"`
-	ConsumersCount       int                `json:"consumersCount,omitempty"`
-	TopicPartitionsCount int                `json:"topicPartitionsCount,omitempty"`
-	MinLag               int64              `json:"minLag,omitempty"`
-	MaxLag               int64              `json:"maxLag,omitempty"`
+	ConsumersCount       int                `json:"consumersCount,omitempty" header:"Consumers"`
+	TopicPartitionsCount int                `json:"topicPartitionsCount,omitempty" header:"Partitions"`
+	MinLag               int64              `json:"minLag,omitempty" header:"Min Lag"`
+	MaxLag               int64              `json:"maxLag,omitempty" header:"Max Lag"`
 }
 
 // ConsumerGroupState describes the valid values of a `ConsumerGroupState`:
-// `StateUnknown`,`StateStable`,`StateRebalancing`,`StateDead`,`StateNoActiveMembers`,`StateExistsNot`,`StateCoordinatorNotFound`.
+// `StateUnknown`,`StateStable`,`StateRebalancing`,`StateEmpty`,`StateDead`,`StateNoActiveMembers`,`StateExistsNot`,`StateCoordinatorNotFound`.
 type ConsumerGroupState string
 
 const (
@@ -1366,6 +2598,8 @@ const (
 	StateStable ConsumerGroupState = "Stable"
 	// StateRebalancing is a valid `ConsumerGroupState` value of "Rebalancing".
 	StateRebalancing ConsumerGroupState = "Rebalancing"
+	// StateEmpty is a valid `ConsumerGroupState` value of "Empty".
+	StateEmpty ConsumerGroupState = "Empty"
 	// StateDead is a valid `ConsumerGroupState` value of "Dead".
 	StateDead ConsumerGroupState = "Dead"
 	// StateNoActiveMembers is a valid `ConsumerGroupState` value of "NoActiveMembers".
@@ -1425,6 +2659,61 @@ func (c *Client) GetTopic(topicName string) (topic Topic, err error) {
 	return
 }
 
+const topicMetricsHistoryPath = "api/v1/kafka/topics/%s/metrics/history"
+
+// ErrTopicMetricsHistoryNotSupported is returned by the `GetTopicMetricsHistory` when the box does not keep
+// the metrics history of the topics. Check for it with `errors.Is(err, ErrTopicMetricsHistoryNotSupported)`.
+var ErrTopicMetricsHistoryNotSupported = fmt.Errorf("topic metrics history is not supported")
+
+// TopicMetricPoint is the throughput of a topic in a time bucket, see `GetTopicMetricsHistory`.
+type TopicMetricPoint struct {
+	// Timestamp is the start of the bucket, in milliseconds.
+	Timestamp      int64   `json:"timestamp" yaml:"timestamp" header:"Time,timestamp(ms|02 Jan 2006 15:04)"`
+	MessagesPerSec float64 `json:"messagesPerSec" yaml:"messagesPerSec" header:"msg/sec"`
+	BytesPerSec    float64 `json:"bytesPerSec" yaml:"bytesPerSec" header:"bytes/sec"`
+}
+
+// GetTopicMetricsHistory returns the messages and bytes per second of the "topicName" from "from" to "to",
+// in buckets of "step", the box decides the step if it is zero, otherwise it must be at least a second.
+// It returns `ErrTopicMetricsHistoryNotSupported` if the box does not keep the metrics history.
+func (c *Client) GetTopicMetricsHistory(topicName string, from, to time.Time, step time.Duration) ([]TopicMetricPoint, error) {
+	if topicName == "" {
+		return nil, errRequired("topicName")
+	}
+
+	if !to.After(from) {
+		return nil, fmt.Errorf("client: the end [%s] of the topic metrics history must be after its start [%s]",
+			to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	if step != 0 && step < time.Second {
+		return nil, fmt.Errorf("client: the step [%s] of the topic metrics history must be at least a second", step)
+	}
+
+	params := url.Values{}
+	params.Set("from", strconv.FormatInt(from.UnixNano()/int64(time.Millisecond), 10))
+	params.Set("to", strconv.FormatInt(to.UnixNano()/int64(time.Millisecond), 10))
+	if step > 0 {
+		params.Set("step", strconv.FormatInt(int64(step/time.Second), 10))
+	}
+
+	path := fmt.Sprintf(topicMetricsHistoryPath, url.PathEscape(topicName)) + "?" + params.Encode()
+	resp, err := c.Do(http.MethodGet, path, "", nil)
+	if err != nil {
+		// a 404 of an existing topic means no history endpoint.
+		if isNotFound(err) {
+			if _, topicErr := c.GetTopic(topicName); topicErr == nil {
+				return nil, fmt.Errorf("topic [%s]: %w", topicName, ErrTopicMetricsHistoryNotSupported)
+			}
+		}
+		return nil, err
+	}
+
+	var points []TopicMetricPoint
+	err = c.ReadJSON(resp, &points)
+	return points, err
+}
+
 // Processor API
 
 const processorsPath = "api/v1/streams"
@@ -1634,6 +2923,61 @@ func (c *Client) GetProcessors() (ProcessorsResult, error) {
 	return res, nil
 }
 
+// The deployment states of a processor, see `GetProcessorsByState`.
+const (
+	ProcessorStatePending    = "PENDING"
+	ProcessorStateRunning    = "RUNNING"
+	ProcessorStateStopped    = "STOPPED"
+	ProcessorStateFailed     = "FAILED"
+	ProcessorStateNotRunning = "NOT_RUNNING"
+	ProcessorStateUnknown    = "UNKNOWN"
+)
+
+// ProcessorStates are the known deployment states of a processor.
+var ProcessorStates = []string{
+	ProcessorStatePending,
+	ProcessorStateRunning,
+	ProcessorStateStopped,
+	ProcessorStateFailed,
+	ProcessorStateNotRunning,
+	ProcessorStateUnknown,
+}
+
+// validateProcessorState returns the "state" in upper case or an error if it is not one of the `ProcessorStates`.
+func validateProcessorState(state string) (string, error) {
+	upper := strings.ToUpper(strings.TrimSpace(state))
+	for _, valid := range ProcessorStates {
+		if upper == valid {
+			return upper, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown processor state [%s], available states are: [%s]", state, strings.Join(ProcessorStates, ", "))
+}
+
+// GetProcessorsByState returns the processors which are in the deployment "state", one of the `ProcessorStates`,
+// i.e FAILED to find the processors that failed to deploy.
+func (c *Client) GetProcessorsByState(state string) ([]ProcessorStream, error) {
+	state, err := validateProcessorState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.GetProcessors()
+	if err != nil {
+		return nil, err
+	}
+
+	var processors []ProcessorStream
+	for _, processor := range res.Streams {
+		if strings.EqualFold(processor.DeploymentState, state) {
+			processors = append(processors, processor)
+		}
+	}
+
+	return processors, nil
+}
+
 // GetDeploymentTargets returns a list of all deployment target clusters
 func (c *Client) GetDeploymentTargets() (DeploymentTargets, error) {
 	var res DeploymentTargets
@@ -1667,12 +3011,137 @@ func (c *Client) GetProcessor(processorID string) (ProcessorStream, error) {
 	return res, nil
 }
 
+// ProcessorRunnerMetrics are the runtime metrics of a single runner of a processor, see `ProcessorMetrics`.
+type ProcessorRunnerMetrics struct {
+	RunnerID             string  `json:"runnerId" yaml:"runnerId" header:"Runner"`
+	MessagesInPerSecond  float64 `json:"messagesInPerSecond" yaml:"messagesInPerSecond" header:"In msg/sec"`
+	MessagesOutPerSecond float64 `json:"messagesOutPerSecond" yaml:"messagesOutPerSecond" header:"Out msg/sec"`
+	ConsumerLag          int64   `json:"lag" yaml:"lag" header:"Lag"`
+}
+
+// ProcessorMetrics are the runtime metrics of a processor, see `GetProcessorMetrics`.
+// Uptime is in milliseconds.
+type ProcessorMetrics struct {
+	ProcessorID          string                   `json:"processorId" yaml:"processorId" header:"ID,text"`
+	MessagesInPerSecond  float64                  `json:"messagesInPerSecond" yaml:"messagesInPerSecond" header:"In msg/sec"`
+	MessagesOutPerSecond float64                  `json:"messagesOutPerSecond" yaml:"messagesOutPerSecond" header:"Out msg/sec"`
+	ConsumerLag          int64                    `json:"lag" yaml:"lag" header:"Lag"`
+	Uptime               int64                    `json:"uptime" yaml:"uptime" header:"Up time,unixduration"`
+	Runners              []ProcessorRunnerMetrics `json:"runners,omitempty" yaml:"runners,omitempty" header:"Runners,count"`
+}
+
+// GetProcessorMetrics returns the throughput and the consumer lag of a processor, in total and per runner.
+// When the totals are not reported they are summed up from the runners.
+// See `LookupProcessorIdentifier`.
+func (c *Client) GetProcessorMetrics(processorID string) (ProcessorMetrics, error) {
+	var metrics ProcessorMetrics
+
+	if processorID == "" {
+		return metrics, errRequired("processorID")
+	}
+
+	path := fmt.Sprintf(processorPath+"/metrics", processorID)
+	resp, err := c.Do(http.MethodGet, path, "", nil)
+	if err != nil {
+		return metrics, err
+	}
+
+	if err = c.ReadJSON(resp, &metrics); err != nil {
+		return metrics, err
+	}
+
+	if metrics.ProcessorID == "" {
+		metrics.ProcessorID = processorID
+	}
+
+	if metrics.MessagesInPerSecond == 0 && metrics.MessagesOutPerSecond == 0 && metrics.ConsumerLag == 0 {
+		for _, runner := range metrics.Runners {
+			metrics.MessagesInPerSecond += runner.MessagesInPerSecond
+			metrics.MessagesOutPerSecond += runner.MessagesOutPerSecond
+			metrics.ConsumerLag += runner.ConsumerLag
+		}
+	}
+
+	return metrics, nil
+}
+
+// ProcessorHealth is a rollup of the runners of a processor,
+// see `GetProcessorHealth`.
+type ProcessorHealth struct {
+	ProcessorID     string   `json:"processorId" yaml:"processorId" header:"ID,text"`
+	Name            string   `json:"name" yaml:"name" header:"Name"`
+	DeploymentState string   `json:"deploymentState" yaml:"deploymentState" header:"State"`
+	DesiredRunners  int      `json:"desiredRunners" yaml:"desiredRunners" header:"Desired"`
+	RunningRunners  int      `json:"runningRunners" yaml:"runningRunners" header:"Running"`
+	Errors          []string `json:"errors,omitempty" yaml:"errors,omitempty" header:"Errors,count"`
+	Healthy         bool     `json:"healthy" yaml:"healthy" header:"Healthy"`
+}
+
+// processorRunnerRunningState is the runner status reported for a runner that is up.
+const processorRunnerRunningState = "RUNNING"
+
+// NewProcessorHealth summarizes the runners of a processor, a processor is healthy
+// when all of its desired runners are running and neither the deployment nor any runner reports an error.
+func NewProcessorHealth(processor ProcessorStream) ProcessorHealth {
+	health := ProcessorHealth{
+		ProcessorID:     processor.ID,
+		Name:            processor.Name,
+		DeploymentState: processor.RunnerState.DeploymentStatus,
+		DesiredRunners:  processor.Runners,
+	}
+
+	if processor.RunnerState.DeploymentError != "" {
+		health.Errors = append(health.Errors, processor.RunnerState.DeploymentError)
+	}
+
+	runnerIDs := make([]string, 0, len(processor.RunnerState.RunnerStataus))
+	for id := range processor.RunnerState.RunnerStataus {
+		runnerIDs = append(runnerIDs, id)
+	}
+	sort.Strings(runnerIDs)
+
+	for _, id := range runnerIDs {
+		runner := processor.RunnerState.RunnerStataus[id]
+		if strings.EqualFold(runner.State, processorRunnerRunningState) {
+			health.RunningRunners++
+		}
+
+		if runner.ErrorMessage != "" {
+			health.Errors = append(health.Errors, fmt.Sprintf("%s: %s", id, runner.ErrorMessage))
+		}
+	}
+
+	health.Healthy = len(health.Errors) == 0 && health.RunningRunners >= health.DesiredRunners
+	return health
+}
+
+// GetProcessorHealth returns the runners rollup and an overall healthy indicator of a processor.
+// See `GetProcessor` and `NewProcessorHealth`.
+func (c *Client) GetProcessorHealth(processorID string) (ProcessorHealth, error) {
+	if processorID == "" {
+		return ProcessorHealth{}, errRequired("processorID")
+	}
+
+	processor, err := c.GetProcessor(processorID)
+	if err != nil {
+		return ProcessorHealth{}, err
+	}
+
+	return NewProcessorHealth(processor), nil
+}
+
 // LookupProcessorIdentifier is not a direct API call, although it fires requests to get the result.
 // It's a helper which can be used as an input argument of the `DeleteProcessor` and `StopProcessor` and `ResumeProcessor` and `UpdateProcessorRunners` functions.
 //
 // Fill the id or name in any case.
 // Fill the clusterName and namespace when in KUBERNETES execution mode.
 func (c *Client) LookupProcessorIdentifier(id, name, clusterName, namespace string) (string, error) {
+	return c.lookupProcessorIdentifier(id, name, clusterName, namespace, false)
+}
+
+// lookupProcessorIdentifier is the `LookupProcessorIdentifier`, when "mustExist" is true
+// it fails if no processor matches the name instead of falling back to the name itself.
+func (c *Client) lookupProcessorIdentifier(id, name, clusterName, namespace string, mustExist bool) (string, error) {
 	if name == "" && id == "" {
 		return "", fmt.Errorf("LookupProcessorIdentifier: name or id are missing")
 	}
@@ -1702,13 +3171,19 @@ func (c *Client) LookupProcessorIdentifier(id, name, clusterName, namespace stri
 				return "", err
 			}
 
+			found := false
 			for _, processor := range result.Streams {
 				if processor.Name == name && processor.ClusterName == clusterName {
 					identifier = processor.ID
+					found = true
 					break
 				}
 			}
 
+			if mustExist && !found {
+				return "", fmt.Errorf("processor [%s] not found in cluster [%s]", name, clusterName)
+			}
+
 		} else {
 			return "", fmt.Errorf("LookupProcessorIdentifier: name or id arguments are missing")
 		}
@@ -1727,12 +3202,18 @@ func (c *Client) LookupProcessorIdentifier(id, name, clusterName, namespace stri
 				return "", err
 			}
 
+			found := false
 			for _, processor := range result.Streams {
 				if processor.Name == name && processor.ClusterName == clusterName && processor.Namespace == namespace {
 					identifier = processor.ID
+					found = true
 					break
 				}
 			}
+
+			if mustExist && !found {
+				return "", fmt.Errorf("processor [%s] not found in cluster [%s] and namespace [%s]", name, clusterName, namespace)
+			}
 		}
 	}
 
@@ -1805,16 +3286,176 @@ func (c *Client) DeleteProcessor(processorNameOrID string) error {
 	return resp.Body.Close()
 }
 
+// lookupProcessorByName resolves the identifier of a processor by its name,
+// see `LookupProcessorIdentifier`. Unlike that, it fails if no processor matches the name.
+func (c *Client) lookupProcessorByName(name, clusterName, namespace string) (string, error) {
+	if name == "" {
+		return "", errRequired("name")
+	}
+
+	return c.lookupProcessorIdentifier("", name, clusterName, namespace, true)
+}
+
+// StopProcessorByName stops a running processor by its name,
+// the clusterName and namespace are required depending on the lenses execution mode.
+// See `LookupProcessorIdentifier` and `StopProcessor`.
+func (c *Client) StopProcessorByName(name, clusterName, namespace string) error {
+	identifier, err := c.lookupProcessorByName(name, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.StopProcessor(identifier)
+}
+
+// ResumeProcessorByName resumes a processor by its name,
+// the clusterName and namespace are required depending on the lenses execution mode.
+// See `LookupProcessorIdentifier` and `ResumeProcessor`.
+func (c *Client) ResumeProcessorByName(name, clusterName, namespace string) error {
+	identifier, err := c.lookupProcessorByName(name, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.ResumeProcessor(identifier)
+}
+
+// UpdateProcessorRunnersByName scales a processor, found by its name, to "numberOfRunners",
+// the clusterName and namespace are required depending on the lenses execution mode.
+// See `LookupProcessorIdentifier` and `UpdateProcessorRunners`.
+func (c *Client) UpdateProcessorRunnersByName(name, clusterName, namespace string, numberOfRunners int) error {
+	identifier, err := c.lookupProcessorByName(name, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.UpdateProcessorRunners(identifier, numberOfRunners)
+}
+
+// GetProcessorsInNamespace returns the processors deployed to the "namespace" of the "clusterName",
+// the namespace is empty for the processors of a CONNECT mode cluster.
+func (c *Client) GetProcessorsInNamespace(clusterName, namespace string) ([]ProcessorStream, error) {
+	if clusterName == "" {
+		return nil, errRequired("clusterName")
+	}
+
+	result, err := c.GetProcessors()
+	if err != nil {
+		return nil, err
+	}
+
+	var processors []ProcessorStream
+	for _, processor := range result.Streams {
+		if processor.ClusterName == clusterName && processor.Namespace == namespace {
+			processors = append(processors, processor)
+		}
+	}
+
+	return processors, nil
+}
+
+// ProcessorScaleResult is the outcome of scaling a processor, see `ScaleProcessorsInNamespace`.
+type ProcessorScaleResult struct {
+	ProcessorID string `json:"processorId" yaml:"processorId" header:"ID,text"`
+	Name        string `json:"name" yaml:"name" header:"Name"`
+	Runners     int    `json:"runners" yaml:"runners" header:"Runners"`
+	Error       string `json:"error,omitempty" yaml:"error,omitempty" header:"Error"`
+}
+
+// ScaleProcessorsInNamespace scales all the processors of the "namespace" of the "clusterName" to "runners",
+// see `GetProcessorsInNamespace`. All the processors are attempted even if some fail,
+// it returns the result of each processor and an error which lists the failed ones.
+// The "runners" must be at least 1, a processor is stopped instead of scaled to zero, see `StopProcessor`.
+func (c *Client) ScaleProcessorsInNamespace(clusterName, namespace string, runners int) ([]ProcessorScaleResult, error) {
+	if runners < 1 {
+		return nil, fmt.Errorf("invalid runners [%d], a processor needs at least one runner", runners)
+	}
+
+	processors, err := c.GetProcessorsInNamespace(clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ProcessorScaleResult, len(processors))
+	var failed []string
+
+	for i, processor := range processors {
+		results[i] = ProcessorScaleResult{ProcessorID: processor.ID, Name: processor.Name, Runners: runners}
+
+		if err := c.UpdateProcessorRunners(processor.ID, runners); err != nil {
+			results[i].Error = err.Error()
+			failed = append(failed, processor.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("failed to scale processors [%s]", strings.Join(failed, ", "))
+	}
+
+	return results, nil
+}
+
+// DeleteProcessorByName removes a processor by its name,
+// the clusterName and namespace are required depending on the lenses execution mode.
+// See `LookupProcessorIdentifier` and `DeleteProcessor`.
+func (c *Client) DeleteProcessorByName(name, clusterName, namespace string) error {
+	identifier, err := c.lookupProcessorByName(name, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteProcessor(identifier)
+}
+
 //
 // Connector API
 // https://docs.lenses.io/dev/lenses-apis/rest-api/index.html#connector-api
 //
 
-// ConnectorConfig the configuration parameters
-// for the connector.
-//
-// For both send and receive:
-type ConnectorConfig map[string]interface{}
+// ConnectorConfig the configuration parameters
+// for the connector.
+//
+// For both send and receive:
+type ConnectorConfig map[string]interface{}
+
+// MaskedConfigValue is the value that replaces the secrets of a masked configuration, see `MaskConnectorConfig`.
+const MaskedConfigValue = "******"
+
+// DefaultSecretConfigPatterns are the patterns of the configuration keys which usually hold secrets,
+// see `MaskConnectorConfig`. Note that "*.key" does not match the "key.converter" class.
+var DefaultSecretConfigPatterns = []string{"*password*", "*secret*", "*.key", "*token*", "*.credentials", "*jaas*"}
+
+// MaskConnectorConfig returns a copy of the "cfg" where the values of the keys that match any of the "patterns"
+// are replaced with the `MaskedConfigValue`. The patterns are shell file name patterns, i.e. "*password*",
+// matched case-insensitively against the whole key, if "patterns" is empty then the `DefaultSecretConfigPatterns` are used.
+func MaskConnectorConfig(cfg ConnectorConfig, patterns []string) ConnectorConfig {
+	if len(patterns) == 0 {
+		patterns = DefaultSecretConfigPatterns
+	}
+
+	masked := make(ConnectorConfig, len(cfg))
+	for key, value := range cfg {
+		masked[key] = value
+
+		if matchesSecretPattern(key, patterns) {
+			masked[key] = MaskedConfigValue
+		}
+	}
+
+	return masked
+}
+
+// matchesSecretPattern reports whether the "key" matches, case-insensitively, any of the "patterns".
+func matchesSecretPattern(key string, patterns []string) bool {
+	lowerKey := strings.ToLower(key)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(strings.ToLower(pattern), lowerKey); ok {
+			return true
+		}
+	}
+
+	return false
+}
 
 // ConnectorTaskReadOnly is the type that returned
 // as "tasks" from the connector, it's for read-only access,
@@ -1989,6 +3630,33 @@ func (c *Client) UpdateConnector(clusterName, name string, config ConnectorConfi
 	return
 }
 
+// PatchConnectorConfig changes only the given configuration keys of an existing connector,
+// the rest of its current configuration is kept as it is.
+// A key of "changes" with a nil value is removed from the configuration.
+//
+// It fetches the current configuration, merges the "changes" and sets the result via `UpdateConnector`.
+func (c *Client) PatchConnectorConfig(clusterName, name string, changes ConnectorConfig) (Connector, error) {
+	current, err := c.GetConnector(clusterName, name)
+	if err != nil {
+		return Connector{}, err
+	}
+
+	config := make(ConnectorConfig, len(current.Config)+len(changes))
+	for key, value := range current.Config {
+		config[key] = value
+	}
+
+	for key, value := range changes {
+		if value == nil {
+			delete(config, key)
+			continue
+		}
+		config[key] = value
+	}
+
+	return c.UpdateConnector(clusterName, name, config)
+}
+
 const connectorPath = connectorsPath + "/%s"
 
 // GetConnector returns the information about the connector.
@@ -2084,6 +3752,44 @@ type (
 	}
 )
 
+// FailedTasks returns only the tasks of the connector that are in a FAILED state.
+func (cs ConnectorStatus) FailedTasks() []ConnectorStatusTask {
+	var failed []ConnectorStatusTask
+	for _, task := range cs.Tasks {
+		if strings.EqualFold(task.State, string(FAILED)) {
+			failed = append(failed, task)
+		}
+	}
+
+	return failed
+}
+
+// ShortError returns the top exception of the task's Java stack trace in a single line,
+// without the exception's package, i.e "RecordTooLargeException: The message is 2000000 bytes".
+// It returns an empty string if the task has no trace, the full trace is kept at `Trace`.
+func (t ConnectorStatusTask) ShortError() string {
+	for _, line := range strings.Split(t.Trace, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		exception, message := line, ""
+		if idx := strings.Index(line, ": "); idx > 0 {
+			exception, message = line[:idx], line[idx:]
+		}
+
+		// shorten only a qualified class name, i.e not a plain message with a dot.
+		if !strings.ContainsAny(exception, " \t") {
+			exception = exception[strings.LastIndexByte(exception, '.')+1:]
+		}
+
+		return exception + message
+	}
+
+	return ""
+}
+
 // GetConnectorStatus returns the current status of the connector, including whether it is running,
 // failed or paused, which worker it is assigned to, error information if it has failed,
 // and the state of all its tasks.
@@ -2111,6 +3817,228 @@ func (c *Client) GetConnectorStatus(clusterName, name string) (cs ConnectorStatu
 	return
 }
 
+// expandedConnector is an entry of the connectors list requested with the `expand` parameter,
+// see `getConnectorsExpanded`.
+type expandedConnector struct {
+	Info   Connector       `json:"info"`
+	Status ConnectorStatus `json:"status"`
+}
+
+// getConnectorsExpanded lists the connectors of the "clusterName" along with the "expand" fields, i.e. "status".
+// Older boxes ignore the `expand` parameter and reply with the connectors names only,
+// in that case the "names" are returned instead of the "expanded" connectors.
+func (c *Client) getConnectorsExpanded(clusterName string, expand ...string) (expanded map[string]expandedConnector, names []string, err error) {
+	if clusterName == "" {
+		err = errRequired("clusterName")
+		return
+	}
+
+	// # List active connectors along with their info and/or status
+	// GET /api/proxy-connect/(string: clusterName)/connectors?expand=info&expand=status
+	path := fmt.Sprintf(connectorsPath, clusterName) + "?" + url.Values{"expand": expand}.Encode()
+	resp, respErr := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	if respErr != nil {
+		err = respErr
+		return
+	}
+
+	var raw json.RawMessage
+	if err = c.ReadJSON(resp, &raw); err != nil {
+		return
+	}
+
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(raw, &names)
+		return
+	}
+
+	err = json.Unmarshal(raw, &expanded)
+	return
+}
+
+// connectorRequestsLimit is the maximum number of concurrent per-connector requests
+// made when the box does not support the expanded connectors list.
+const connectorRequestsLimit = 8
+
+// forEachConcurrently calls "fn" for every index up to "total" with at most "limit" calls running at the same time.
+// It returns the error of the lowest failed index.
+func forEachConcurrently(total, limit int, fn func(i int) error) error {
+	var (
+		errs = make([]error, total)
+		sem  = make(chan struct{}, limit)
+		wg   sync.WaitGroup
+	)
+
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetConnectorStatuses returns the status of all the connectors of the "clusterName", keyed by the connector name.
+// It fetches them in a single request when the box supports the expanded connectors list,
+// otherwise it falls back to a `GetConnectorStatus` call per connector, a few at a time.
+func (c *Client) GetConnectorStatuses(clusterName string) (map[string]ConnectorStatus, error) {
+	expanded, names, err := c.getConnectorsExpanded(clusterName, "status")
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]ConnectorStatus, len(expanded)+len(names))
+	if expanded != nil {
+		for name, connector := range expanded {
+			status := connector.Status
+			if status.Name == "" {
+				status.Name = name
+			}
+			statuses[name] = status
+		}
+
+		return statuses, nil
+	}
+
+	results := make([]ConnectorStatus, len(names))
+	err = forEachConcurrently(len(names), connectorRequestsLimit, func(i int) (err error) {
+		results[i], err = c.GetConnectorStatus(clusterName, names[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range names {
+		statuses[name] = results[i]
+	}
+
+	return statuses, nil
+}
+
+// ConnectorDetail is the combined view of a connector's configuration, tasks and their states,
+// see `GetConnectorDetail`.
+type ConnectorDetail struct {
+	ClusterName string                `json:"clusterName" yaml:"clusterName" header:"Cluster"`
+	Name        string                `json:"name" yaml:"name" header:"Name"`
+	State       string                `json:"state" yaml:"state" header:"State"`
+	WorkerID    string                `json:"workerId" yaml:"workerId" header:"Worker"`
+	Config      ConnectorConfig       `json:"config,omitempty" yaml:"config" header:"Configs,count"`
+	Tasks       []ConnectorStatusTask `json:"tasks,omitempty" yaml:"tasks" header:"Tasks,count"`
+}
+
+// GetConnectorDetail returns the connector's configuration and tasks along with the connector and per-task state and trace.
+// It fetches the `GetConnector` and `GetConnectorStatus` concurrently and merges their results.
+func (c *Client) GetConnectorDetail(clusterName, name string) (ConnectorDetail, error) {
+	var (
+		wg                  sync.WaitGroup
+		connector           Connector
+		status              ConnectorStatus
+		connectorErr, stErr error
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		connector, connectorErr = c.GetConnector(clusterName, name)
+	}()
+	go func() {
+		defer wg.Done()
+		status, stErr = c.GetConnectorStatus(clusterName, name)
+	}()
+	wg.Wait()
+
+	if connectorErr != nil {
+		return ConnectorDetail{}, connectorErr
+	}
+
+	if stErr != nil {
+		return ConnectorDetail{}, stErr
+	}
+
+	return newConnectorDetail(connector, status), nil
+}
+
+// GetConnectorsExpanded returns the configuration, tasks and states of all the connectors of the "clusterName", sorted by name.
+// It fetches them in a single request when the box supports the `expand=info&expand=status` connectors list (Kafka Connect 2.3+),
+// otherwise it falls back to a `GetConnectorDetail` call per connector, a few at a time.
+func (c *Client) GetConnectorsExpanded(clusterName string) ([]ConnectorDetail, error) {
+	expanded, names, err := c.getConnectorsExpanded(clusterName, "info", "status")
+	if err != nil {
+		return nil, err
+	}
+
+	var details []ConnectorDetail
+	if expanded != nil {
+		details = make([]ConnectorDetail, 0, len(expanded))
+		for name, connector := range expanded {
+			connector.Info.ClusterName = clusterName
+			if connector.Info.Name == "" {
+				connector.Info.Name = name
+			}
+			details = append(details, newConnectorDetail(connector.Info, connector.Status))
+		}
+	} else {
+		details = make([]ConnectorDetail, len(names))
+		err = forEachConcurrently(len(names), connectorRequestsLimit, func(i int) (err error) {
+			details[i], err = c.GetConnectorDetail(clusterName, names[i])
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].Name < details[j].Name
+	})
+
+	return details, nil
+}
+
+// newConnectorDetail merges the connector's tasks with their states, tasks are sorted by their ID.
+func newConnectorDetail(connector Connector, status ConnectorStatus) ConnectorDetail {
+	detail := ConnectorDetail{
+		ClusterName: connector.ClusterName,
+		Name:        connector.Name,
+		State:       status.Connector.State,
+		WorkerID:    status.Connector.WorkerID,
+		Config:      connector.Config,
+	}
+
+	tasks := make(map[int]ConnectorStatusTask, len(connector.Tasks))
+	for _, task := range connector.Tasks {
+		tasks[task.Task] = ConnectorStatusTask{ID: task.Task}
+	}
+
+	for _, task := range status.Tasks {
+		tasks[task.ID] = task
+	}
+
+	for _, task := range tasks {
+		detail.Tasks = append(detail.Tasks, task)
+	}
+
+	sort.Slice(detail.Tasks, func(i, j int) bool {
+		return detail.Tasks[i].ID < detail.Tasks[j].ID
+	})
+
+	return detail
+}
+
 // PauseConnector pauses the connector and its tasks, which stops message processing until the connector is resumed.
 // This call asynchronous and the tasks will not transition to PAUSED state at the same time.
 func (c *Client) PauseConnector(clusterName, name string) error {
@@ -2157,7 +4085,17 @@ func (c *Client) ResumeConnector(clusterName, name string) error {
 
 // RestartConnector restarts the connector and its tasks.
 // It returns a 409 (Conflict) status code error if rebalance is in process.
+//
+// See `RestartConnectorWithOptions` too.
 func (c *Client) RestartConnector(clusterName, name string) error {
+	return c.RestartConnectorWithOptions(clusterName, name, false, false)
+}
+
+// RestartConnectorWithOptions restarts the connector and, if "includeTasks" is true, its tasks too.
+// If "onlyFailed" is true then only the connector and the task instances that are in a FAILED state are restarted.
+// Both options are supported on newer Kafka Connect versions (KIP-745), older ones ignore them.
+// It returns a 409 (Conflict) status code error if rebalance is in process.
+func (c *Client) RestartConnectorWithOptions(clusterName, name string, includeTasks, onlyFailed bool) error {
 	if clusterName == "" {
 		return errRequired("clusterName")
 	}
@@ -2167,8 +4105,20 @@ func (c *Client) RestartConnector(clusterName, name string) error {
 	}
 
 	// # Restart a connector
-	// POST /api/proxy-connect/(string: clusterName)/connectors/(string: name)/restart
+	// POST /api/proxy-connect/(string: clusterName)/connectors/(string: name)/restart?includeTasks=(bool)&onlyFailed=(bool)
 	path := fmt.Sprintf(connectorPath+"/restart", clusterName, name)
+
+	query := url.Values{}
+	if includeTasks {
+		query.Set("includeTasks", "true")
+	}
+	if onlyFailed {
+		query.Set("onlyFailed", "true")
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
 	resp, err := c.Do(http.MethodPost, path, "", nil)
 	if err != nil {
 		return err
@@ -2177,6 +4127,123 @@ func (c *Client) RestartConnector(clusterName, name string) error {
 	return resp.Body.Close()
 }
 
+// RestartResult is the outcome of restarting a connector, see `RestartConnectors`.
+type RestartResult struct {
+	Name      string `json:"name" yaml:"name" header:"Name"`
+	Restarted bool   `json:"restarted" yaml:"restarted" header:"Restarted"`
+	// Skipped is set when only the failed connectors are restarted and the connector has not failed.
+	Skipped bool   `json:"skipped,omitempty" yaml:"skipped,omitempty" header:"Skipped"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty" header:"Error"`
+}
+
+// RestartConnectors restarts the "names" connectors of the "clusterName", and their tasks, one after the other
+// waiting for "pauseBetween" between two restarts, so a connect cluster is not overwhelmed by restarting all at once.
+// All the connectors of the cluster are restarted if "names" is empty.
+// If "onlyFailed" is true then only the connectors which have failed, or have a failed task, are restarted
+// and only their failed instances, see `RestartConnectorWithOptions`.
+// A connector without a status is not restarted but reported as failed.
+//
+// All the connectors are attempted even if some fail,
+// it returns the result of each connector and an error which lists the failed ones.
+func (c *Client) RestartConnectors(clusterName string, names []string, pauseBetween time.Duration, onlyFailed bool) ([]RestartResult, error) {
+	if clusterName == "" {
+		return nil, errRequired("clusterName")
+	}
+
+	if len(names) == 0 {
+		var err error
+		if names, err = c.GetConnectors(clusterName); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses map[string]ConnectorStatus
+	if onlyFailed {
+		var err error
+		if statuses, err = c.GetConnectorStatuses(clusterName); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	results := make([]RestartResult, len(names))
+	var (
+		failed    []string
+		restarted bool
+	)
+
+	for i, name := range names {
+		results[i].Name = name
+
+		if onlyFailed {
+			// a connector without a status, i.e. a mistyped name, cannot be known to have failed.
+			status, ok := statuses[name]
+			if !ok {
+				results[i].Error = fmt.Sprintf("connector [%s] has no status in cluster [%s]", name, clusterName)
+				failed = append(failed, name)
+				continue
+			}
+
+			if !strings.EqualFold(status.Connector.State, string(FAILED)) && len(status.FailedTasks()) == 0 {
+				results[i].Skipped = true
+				continue
+			}
+		}
+
+		if restarted && pauseBetween > 0 {
+			if err := retryWait(ctx, pauseBetween); err != nil {
+				return results, err
+			}
+		}
+		restarted = true
+
+		if err := c.RestartConnectorWithOptions(clusterName, name, true, onlyFailed); err != nil {
+			results[i].Error = err.Error()
+			failed = append(failed, name)
+			continue
+		}
+
+		results[i].Restarted = true
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("failed to restart connectors [%s]", strings.Join(failed, ", "))
+	}
+
+	return results, nil
+}
+
+// ResetConnectorActiveTopics resets the set of topic names that the connector has been using since its creation
+// or since the last time its set of active topics was reset.
+// Kafka Connect versions before 2.5 (KIP-558) do not support it, a 404 (Not Found) is reported with a clear message.
+func (c *Client) ResetConnectorActiveTopics(clusterName, name string) error {
+	if clusterName == "" {
+		return errRequired("clusterName")
+	}
+
+	if name == "" {
+		return errRequired("name")
+	}
+
+	// # Reset the active topics of a connector
+	// PUT /api/proxy-connect/(string: clusterName)/connectors/(string: name)/topics/reset
+	path := fmt.Sprintf(connectorPath+"/topics/reset", clusterName, name)
+	resp, err := c.Do(http.MethodPut, path, "", nil)
+	if err != nil {
+		if resErr, ok := err.(ResourceError); ok && resErr.Code() == http.StatusNotFound {
+			return NewResourceError(resErr.StatusCode, resErr.URI, resErr.Method,
+				fmt.Sprintf("connector [%s] not found or the connect cluster [%s] does not support resetting the active topics", name, clusterName))
+		}
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
 // DeleteConnector deletes a connector, halting all tasks and deleting its configuration.
 // It return a 409 (Conflict) status code error if rebalance is in process.
 func (c *Client) DeleteConnector(clusterName, name string) error {
@@ -2303,8 +4370,146 @@ func (c *Client) GetConnectorPlugins(clusterName string) (cp []ConnectorPlugin,
 		return
 	}
 
-	err = c.ReadJSON(resp, &cp)
-	return
+	err = c.ReadJSON(resp, &cp)
+	return
+}
+
+// ConnectorConfigDef describes a config key of a connector plugin, see `GetConnectorPluginConfigDef`.
+type ConnectorConfigDef struct {
+	Name     string `json:"name" yaml:"name" header:"Name"`
+	Type     string `json:"type" yaml:"type" header:"Type"`
+	Required bool   `json:"required" yaml:"required" header:"Required"`
+	// DefaultValue is nil if the key has no default value.
+	DefaultValue  *string `json:"default_value" yaml:"defaultValue" header:"Default"`
+	Importance    string  `json:"importance" yaml:"importance" header:"Importance"`
+	Group         string  `json:"group" yaml:"group" header:"Group"`
+	Documentation string  `json:"documentation" yaml:"documentation"`
+}
+
+// GetConnectorPluginConfigDef returns the definitions of the config keys of a connector plugin of the "clusterName",
+// the "pluginClass" is the plugin's class name, i.e "io.confluent.connect.jdbc.JdbcSourceConnector", or its simple name.
+//
+// The definitions are taken from the Kafka Connect validation of an empty config, so the call creates nothing.
+func (c *Client) GetConnectorPluginConfigDef(clusterName, pluginClass string) ([]ConnectorConfigDef, error) {
+	if clusterName == "" {
+		return nil, errRequired("clusterName")
+	}
+
+	if pluginClass == "" {
+		return nil, errRequired("pluginClass")
+	}
+
+	send, err := json.Marshal(ConnectorConfig{"connector.class": pluginClass})
+	if err != nil {
+		return nil, err
+	}
+
+	// # Validate a config of a connector plugin
+	// PUT /api/proxy-connect/(string: clusterName)/connector-plugins/(string: pluginClass)/config/validate
+	path := fmt.Sprintf(pluginsPath+"/%s/config/validate", clusterName, url.PathEscape(pluginClass))
+	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Configs []struct {
+			Definition ConnectorConfigDef `json:"definition"`
+		} `json:"configs"`
+	}
+	if err = c.ReadJSON(resp, &res); err != nil {
+		return nil, err
+	}
+
+	defs := make([]ConnectorConfigDef, len(res.Configs))
+	for i, config := range res.Configs {
+		defs[i] = config.Definition
+	}
+
+	return defs, nil
+}
+
+// connectorTemplateImportance is the importance of the optional config keys which are part of a template,
+// see `GetConnectorConfigTemplate`.
+const connectorTemplateImportance = "HIGH"
+
+// NewConnectorConfigTemplate returns a skeleton config of the "pluginClass" out of its "defs",
+// the required keys are empty and the optional keys of a high importance are set to their default value.
+func NewConnectorConfigTemplate(pluginClass string, defs []ConnectorConfigDef) ConnectorConfig {
+	template := ConnectorConfig{"connector.class": pluginClass}
+
+	for _, def := range defs {
+		if _, ok := template[def.Name]; ok {
+			continue
+		}
+
+		switch {
+		case def.Required && def.DefaultValue == nil:
+			template[def.Name] = ""
+		case def.DefaultValue != nil && (def.Required || strings.EqualFold(def.Importance, connectorTemplateImportance)):
+			template[def.Name] = *def.DefaultValue
+		}
+	}
+
+	return template
+}
+
+// GetConnectorConfigTemplate returns a skeleton config of a connector plugin of the "clusterName", ready to be edited
+// and passed to the `CreateConnector`, see `NewConnectorConfigTemplate` and `GetConnectorPluginConfigDef`.
+func (c *Client) GetConnectorConfigTemplate(clusterName, pluginClass string) (ConnectorConfig, error) {
+	defs, err := c.GetConnectorPluginConfigDef(clusterName, pluginClass)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConnectorConfigTemplate(pluginClass, defs), nil
+}
+
+const pluginInstallPath = pluginsPath + "/install"
+
+// ErrPluginInstallNotSupported is returned by the `InstallConnectorPlugin` when the box, or the Kafka Connect cluster,
+// does not support uploading connector plugins. Check for it with `errors.Is(err, ErrPluginInstallNotSupported)`.
+var ErrPluginInstallNotSupported = fmt.Errorf("connector plugin install is not supported")
+
+// InstallConnectorPlugin uploads a connector plugin archive, i.e. a jar or a zip file, named "filename"
+// to the Kafka Connect cluster of the "clusterName".
+// It returns `ErrPluginInstallNotSupported` if the box does not proxy a plugin install endpoint for the cluster.
+func (c *Client) InstallConnectorPlugin(clusterName string, archive io.Reader, filename string) error {
+	if clusterName == "" {
+		return errRequired("clusterName")
+	}
+
+	if filename == "" {
+		return errRequired("filename")
+	}
+
+	// the archive is streamed to the request, not kept in memory.
+	body, pipeWriter := io.Pipe()
+	// unblocks the writer if the request is not sent or fails before reading the whole body.
+	defer body.Close()
+
+	writer := multipart.NewWriter(pipeWriter)
+	go func() {
+		part, err := writer.CreateFormFile("file", filepath.Base(filename))
+		if err == nil {
+			_, err = io.Copy(part, archive)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	path := fmt.Sprintf(pluginInstallPath, clusterName)
+	resp, err := c.Do(http.MethodPost, path, writer.FormDataContentType(), nil, withBodyStream(body))
+	if err != nil {
+		if resErr, ok := err.(ResourceError); ok && (resErr.Code() == http.StatusNotFound || resErr.Code() == http.StatusMethodNotAllowed) {
+			return fmt.Errorf("cluster [%s]: %w", clusterName, ErrPluginInstallNotSupported)
+		}
+		return err
+	}
+
+	return resp.Body.Close()
 }
 
 // Schema Registry
@@ -2319,6 +4524,32 @@ func JSONAvroSchema(avroSchema string) (json.RawMessage, error) {
 	return raw, err
 }
 
+// NormalizeAvroSchema returns the canonical form of the "avroSchema" json,
+// its object keys are sorted and the insignificant whitespace is removed,
+// so two schemas which differ only in their formatting have the same normalized form.
+// The order of the array elements, i.e. the record fields, is significant and it is kept.
+func NormalizeAvroSchema(avroSchema string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(avroSchema))
+	decoder.UseNumber()
+
+	var schema interface{}
+	if err := decoder.Decode(&schema); err != nil {
+		return "", fmt.Errorf("invalid avro schema: %v", err)
+	}
+
+	if decoder.More() {
+		return "", fmt.Errorf("invalid avro schema: unexpected data after the schema")
+	}
+
+	// maps are encoded with sorted keys.
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
 // ACL API
 // "ACL" stands for "Access Control Lists".
 //
@@ -2498,6 +4729,22 @@ func (acl *ACL) Validate() error {
 
 const aclPath = "api/acl"
 
+// ErrNoAuthorizer is returned by the ACL calls when there is no authorizer configured on the brokers,
+// i.e. the Kafka ACLs are not enabled. Check for it with `errors.Is(err, ErrNoAuthorizer)`.
+var ErrNoAuthorizer = fmt.Errorf("no authorizer is configured on the broker")
+
+// aclError converts the 400 (Bad Request) errors of the ACL API which report a missing authorizer to `ErrNoAuthorizer`,
+// unlike with other calls the ACL API returns a plain text with no authorize-type error message
+// instead of 403, so make that check only on the acl API. The rest of the 400 errors, i.e. an invalid ACL, are kept.
+func aclError(err error) error {
+	if resErr, ok := err.(ResourceError); ok && resErr.Code() == http.StatusBadRequest &&
+		strings.Contains(strings.ToLower(resErr.Body), "authorizer") {
+		return fmt.Errorf("%w: %s", ErrNoAuthorizer, resErr.Body)
+	}
+
+	return err
+}
+
 // CreateOrUpdateACL sets an Apache Kafka Access Control List.
 // Use the defined types when needed, example:
 // `client.CreateOrUpdateACL(lenses.ACL{lenses.ACLResourceTopic, "transactions", "principalType:principalName", lenses.ACLPermissionAllow, "*", lenses.OpRead})`
@@ -2515,30 +4762,80 @@ func (c *Client) CreateOrUpdateACL(acl ACL) error {
 
 	resp, err := c.Do(http.MethodPut, aclPath, contentTypeJSON, send)
 	if err != nil {
-		return err
-	}
-
-	// unlike with other calls this one returns a plain text with no authorize-type error message
-	// instead of 403, so make that check only on the acl API:
-	if resp.StatusCode == http.StatusBadRequest {
-		return fmt.Errorf("no authorizer is configured on the broker")
+		return aclError(err)
 	}
 
 	// note: the status code errors are checked in the `do` on every request.
 	return resp.Body.Close()
 }
 
+// ACLBatchFailure describes an ACL of a batch that failed to be applied, see `ACLBatchError`.
+type ACLBatchFailure struct {
+	// Index is the position of the ACL in the batch.
+	Index int
+	ACL   ACL
+	Err   error
+}
+
+// ACLBatchError is the error returned by `CreateOrUpdateACLs`,
+// it reports all the ACLs of the batch that failed to be applied.
+type ACLBatchError struct {
+	Total    int
+	Failures []ACLBatchFailure
+}
+
+// Error implements the error interface.
+func (e *ACLBatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to apply [%d/%d] ACLs", len(e.Failures), e.Total)
+
+	for _, failure := range e.Failures {
+		fmt.Fprintf(&b, "\n[%d] %s %s %s on %s [%s]: %v", failure.Index, failure.ACL.Principal, failure.ACL.PermissionType,
+			failure.ACL.Operation, failure.ACL.ResourceType, failure.ACL.ResourceName, failure.Err)
+	}
+
+	return b.String()
+}
+
+// CreateOrUpdateACLs sets a batch of Apache Kafka Access Control Lists.
+// All the ACLs are validated before any of them is sent, if any is invalid then none is applied.
+//
+// Lenses does not expose a bulk ACL endpoint, so each ACL is still applied by its own request,
+// however all the ACLs are attempted and the failures are reported together by an `*ACLBatchError`.
+func (c *Client) CreateOrUpdateACLs(acls []ACL) error {
+	validated := make([]ACL, len(acls))
+	for i, acl := range acls {
+		if err := acl.Validate(); err != nil {
+			return fmt.Errorf("invalid acl [%d]: %v", i, err)
+		}
+		validated[i] = acl
+	}
+
+	batchErr := &ACLBatchError{Total: len(validated)}
+	for i, acl := range validated {
+		if err := c.CreateOrUpdateACL(acl); err != nil {
+			batchErr.Failures = append(batchErr.Failures, ACLBatchFailure{Index: i, ACL: acl, Err: err})
+		}
+	}
+
+	if len(batchErr.Failures) > 0 {
+		return batchErr
+	}
+
+	return nil
+}
+
 // GetACLs returns all the available Apache Kafka Access Control Lists.
 func (c *Client) GetACLs() ([]ACL, error) {
 	resp, err := c.Do(http.MethodGet, aclPath, "", nil)
 	if err != nil {
-		return nil, err
+		return nil, aclError(err)
 	}
 
-	// unlike with other calls this one returns a plain text with no authorize-type error message
-	// instead of 403, so make that check only on the acl API:
+	// 400 is not an error for GET requests, see `isOK`.
 	if resp.StatusCode == http.StatusBadRequest {
-		return nil, fmt.Errorf("no authorizer is configured on the broker")
+		resp.Body.Close()
+		return nil, ErrNoAuthorizer
 	}
 
 	var acls []ACL
@@ -2558,17 +4855,109 @@ func (c *Client) DeleteACL(acl ACL) error {
 	}
 
 	resp, err := c.Do(http.MethodDelete, aclPath, contentTypeJSON, send)
+	if err != nil {
+		return aclError(err)
+	}
+
+	return resp.Body.Close()
+}
+
+// aclDefaultPatternType is the pattern type Kafka gives to the ACLs created without one.
+const aclDefaultPatternType = "LITERAL"
+
+// normalizeACL returns the "acl" validated and with its defaults set,
+// so ACLs which differ only in their letter case or an empty host or pattern type are equal.
+func normalizeACL(acl ACL) (ACL, error) {
+	err := acl.Validate()
+
+	if acl.Host == "" {
+		acl.Host = "*"
+	}
+
+	if acl.PatternType == "" {
+		acl.PatternType = aclDefaultPatternType
+	}
+
+	return acl, err
+}
+
+// ACLsDiff compares the "desired" ACLs against the ACLs returned by `GetACLs`,
+// it returns the desired ACLs which are missing and the existing ACLs which are not desired.
+// All the desired ACLs are validated before any request is sent.
+func (c *Client) ACLsDiff(desired []ACL) (toAdd, toRemove []ACL, err error) {
+	wanted := make(map[ACL]bool, len(desired))
+	for i, acl := range desired {
+		acl, err = normalizeACL(acl)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid acl [%d]: %v", i, err)
+		}
+
+		if !wanted[acl] {
+			wanted[acl] = true
+			toAdd = append(toAdd, acl)
+		}
+	}
+
+	current, err := c.GetACLs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing := make(map[ACL]bool, len(current))
+	for _, acl := range current {
+		// the existing ones may be of resource types unknown to the client, they can still be compared.
+		acl, _ = normalizeACL(acl)
+		if existing[acl] {
+			continue
+		}
+		existing[acl] = true
+
+		if !wanted[acl] {
+			toRemove = append(toRemove, acl)
+		}
+	}
+
+	missing := toAdd[:0]
+	for _, acl := range toAdd {
+		if !existing[acl] {
+			missing = append(missing, acl)
+		}
+	}
+
+	return missing, toRemove, nil
+}
+
+// ApplyACLs makes the "desired" ACLs the ACLs of the cluster, the missing ones are created
+// and, if "prune" is true, the existing ones which are not desired are deleted, see `ACLsDiff`.
+// All the ACLs are attempted and the failures are reported together by an `*ACLBatchError`.
+func (c *Client) ApplyACLs(desired []ACL, prune bool) error {
+	toAdd, toRemove, err := c.ACLsDiff(desired)
 	if err != nil {
 		return err
 	}
 
-	// unlike with other calls this one returns a plain text with no authorize-type error message
-	// instead of 403, so make that check only on the acl API:
-	if resp.StatusCode == http.StatusBadRequest {
-		return fmt.Errorf("no authorizer is configured on the broker")
+	if !prune {
+		toRemove = nil
 	}
 
-	return resp.Body.Close()
+	batchErr := &ACLBatchError{Total: len(toAdd) + len(toRemove)}
+	for i, acl := range toAdd {
+		if err := c.CreateOrUpdateACL(acl); err != nil {
+			batchErr.Failures = append(batchErr.Failures, ACLBatchFailure{Index: i, ACL: acl, Err: err})
+		}
+	}
+
+	for i, acl := range toRemove {
+		if err := c.DeleteACL(acl); err != nil {
+			batchErr.Failures = append(batchErr.Failures, ACLBatchFailure{Index: len(toAdd) + i, ACL: acl, Err: err})
+		}
+	}
+
+	if len(batchErr.Failures) > 0 {
+		return batchErr
+	}
+
+	return nil
 }
 
 //
@@ -2986,6 +5375,38 @@ type (
 	}
 )
 
+// The valid alert settings categories, see `GetAlertSettingsByCategory`.
+const (
+	AlertSettingsCategoryInfrastructure = "infrastructure"
+	AlertSettingsCategoryConsumers      = "consumers"
+	AlertSettingsCategoryProducers      = "producers"
+	AlertSettingsCategoryApps           = "apps"
+)
+
+// AlertSettingsCategories are the valid alert settings categories.
+var AlertSettingsCategories = []string{
+	AlertSettingsCategoryInfrastructure,
+	AlertSettingsCategoryConsumers,
+	AlertSettingsCategoryProducers,
+	AlertSettingsCategoryApps,
+}
+
+// Category returns the alert settings of the "category", one of the `AlertSettingsCategories`, matched case-insensitively.
+func (categoryMap AlertSettingsCategoryMap) Category(category string) ([]AlertSetting, error) {
+	switch strings.ToLower(category) {
+	case AlertSettingsCategoryInfrastructure:
+		return categoryMap.Infrastructure, nil
+	case AlertSettingsCategoryConsumers:
+		return categoryMap.Consumers, nil
+	case AlertSettingsCategoryProducers:
+		return categoryMap.Producers, nil
+	case AlertSettingsCategoryApps:
+		return categoryMap.Apps, nil
+	default:
+		return nil, fmt.Errorf("unknown alert settings category [%s], available categories are: [%s]", category, strings.Join(AlertSettingsCategories, ", "))
+	}
+}
+
 func (categoryMap AlertSettingsCategoryMap) allCategories() [][]AlertSetting {
 	return [][]AlertSetting{
 		categoryMap.Apps,
@@ -3038,6 +5459,14 @@ func (c *Client) GetProcessorsLogs(clusterName, ns, podName string, follow bool,
 		return fmt.Errorf("unable to retrieve logs, execution mode is not KUBERNETES")
 	}
 
+	return c.readProcessorsLogs(clusterName, ns, podName, follow, lines, func(_ time.Time, level, log string) error {
+		return handler(level, log)
+	})
+}
+
+// readProcessorsLogs reads the logs of the "podName", the "handler" receives the time of each log line as well,
+// it is zero for the lines which are not logged in JSON. The "options" are passed to the logs request.
+func (c *Client) readProcessorsLogs(clusterName, ns, podName string, follow bool, lines int, handler func(ts time.Time, level string, log string) error, options ...RequestOption) error {
 	path := fmt.Sprintf(processorsLogsPathSSE, clusterName, ns, podName)
 	if follow {
 		if lines <= 0 {
@@ -3047,10 +5476,12 @@ func (c *Client) GetProcessorsLogs(clusterName, ns, podName string, follow bool,
 		path += "?follow=true&lines=" + fmt.Sprintf("%d", lines)
 	}
 
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil, func(r *http.Request) error {
+	options = append([]RequestOption{func(r *http.Request) error {
 		r.Header.Add(acceptHeaderKey, "application/json, text/event-stream")
 		return nil
-	})
+	}}, options...)
+
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil, options...)
 	if err != nil {
 		return err
 	}
@@ -3098,21 +5529,156 @@ func (c *Client) GetProcessorsLogs(clusterName, ns, podName string, follow bool,
 				}
 
 				// colorized by the caller.
-				if err = handler(logEntry.Level, fmt.Sprintf("%s %s", logEntry.Timestamp, logEntry.Message)); err != nil {
+				if err = handler(t, logEntry.Level, fmt.Sprintf("%s %s", logEntry.Timestamp, logEntry.Message)); err != nil {
 					return err
 				}
 
 			} else {
 				// for any case.
-				handler("info", string(message))
+				handler(time.Time{}, "info", string(message))
 			}
 
 			continue
 		}
 
 		// it contains the log level itself.
-		handler("", string(message))
+		handler(time.Time{}, "", string(message))
+	}
+}
+
+// GetProcessorPods returns the names of the kubernetes pods which run the "processorName" of the "clusterName" and "namespace",
+// these are the ids of its runners, see `ProcessorAppState`.
+func (c *Client) GetProcessorPods(clusterName, namespace, processorName string) ([]string, error) {
+	if processorName == "" {
+		return nil, errRequired("processorName")
+	}
+
+	result, err := c.GetProcessors()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, processor := range result.Streams {
+		if processor.Name != processorName || processor.ClusterName != clusterName || processor.Namespace != namespace {
+			continue
+		}
+
+		pods := make([]string, 0, len(processor.RunnerState.RunnerStataus))
+		for id, runner := range processor.RunnerState.RunnerStataus {
+			if runner.ID != "" {
+				id = runner.ID
+			}
+			pods = append(pods, id)
+		}
+		sort.Strings(pods)
+
+		return pods, nil
+	}
+
+	return nil, fmt.Errorf("processor [%s] not found in cluster [%s] and namespace [%s]", processorName, clusterName, namespace)
+}
+
+// processorPodLog is a log line of a pod, see `GetProcessorLogsAll`.
+type processorPodLog struct {
+	pod, level, log string
+	ts              time.Time
+}
+
+// GetProcessorLogsAll retrieves the logs of all the pods of a LSQL processor if in kubernetes mode,
+// the "handler" receives each log line along with the pod which logged it.
+//
+// Without "follow" the logs of all the pods are fetched first and given in the order of their time,
+// the lines which are not logged in JSON keep their place after the previous line of their pod.
+// With "follow" the lines are given as they arrive, one at a time, until the first pod's stream ends or fails,
+// or the "handler" fails, then the streams of the rest of the pods are canceled as well.
+func (c *Client) GetProcessorLogsAll(clusterName, namespace, processorName string, follow bool, lines int, handler func(pod, level, log string) error) error {
+	if handler == nil {
+		return errRequired("handler")
+	}
+
+	if mode, _ := c.GetExecutionMode(); mode != ExecutionModeKubernetes {
+		return fmt.Errorf("unable to retrieve logs, execution mode is not KUBERNETES")
+	}
+
+	pods, err := c.GetProcessorPods(clusterName, namespace, processorName)
+	if err != nil {
+		return err
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("processor [%s] has no pods", processorName)
+	}
+
+	if follow {
+		ctx := c.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			mu     sync.Mutex
+			once   sync.Once
+			failed error
+		)
+
+		forEachConcurrently(len(pods), len(pods), func(i int) error {
+			err := c.readProcessorsLogs(clusterName, namespace, pods[i], true, lines, func(_ time.Time, level, log string) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				return handler(pods[i], level, log)
+			}, requestContext(ctx))
+
+			// the first stream which ends stops the rest, their cancellation errors are not reported.
+			once.Do(func() {
+				failed = err
+				cancel()
+			})
+			return nil
+		})
+
+		return failed
+	}
+
+	logs := make([][]processorPodLog, len(pods))
+	err = forEachConcurrently(len(pods), len(pods), func(i int) error {
+		var last time.Time
+		return c.readProcessorsLogs(clusterName, namespace, pods[i], false, lines, func(ts time.Time, level, log string) error {
+			if ts.IsZero() {
+				ts = last
+			}
+			last = ts
+
+			logs[i] = append(logs[i], processorPodLog{pod: pods[i], level: level, log: log, ts: ts})
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	var all []processorPodLog
+	for _, podLogs := range logs {
+		all = append(all, podLogs...)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].ts.Before(all[j].ts)
+	})
+
+	for _, entry := range all {
+		if err = handler(entry.pod, entry.level, entry.log); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 //
@@ -3133,6 +5699,8 @@ const (
 
 // GetDynamicClusterConfigs returns the dynamic updated configurations for a kafka cluster.
 // Retrieves only the ones added/updated dynamically.
+//
+// Only the `BrokerConfig` fields are filled, use the `GetDynamicClusterConfigsMap` to retrieve all the keys.
 func (c *Client) GetDynamicClusterConfigs() (configs BrokerConfig, err error) {
 	resp, respErr := c.Do(http.MethodGet, brokersConfigsPath, "", nil)
 	if respErr != nil {
@@ -3140,26 +5708,156 @@ func (c *Client) GetDynamicClusterConfigs() (configs BrokerConfig, err error) {
 		return
 	}
 
-	err = c.ReadJSON(resp, &configs)
-	return
+	err = c.ReadJSON(resp, &configs)
+	return
+}
+
+// GetDynamicBrokerConfigs returns the dynamic updated configurations for a kafka broker.
+// Retrieves only the ones added/updated dynamically.
+//
+// Only the `BrokerConfig` fields are filled, use the `GetDynamicBrokerConfigsMap` to retrieve all the keys.
+func (c *Client) GetDynamicBrokerConfigs(brokerID int) (config BrokerConfig, err error) {
+	path := fmt.Sprintf(brokerConfigsPath, brokerID)
+	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	if respErr != nil {
+		err = respErr
+		return
+	}
+
+	err = c.ReadJSON(resp, &config)
+	return
+}
+
+// readConfigsMap reads a configs response as key-value pairs,
+// non-string values, i.e numbers, are kept as their json text, so big numbers are not rounded.
+func (c *Client) readConfigsMap(resp *http.Response) (map[string]string, error) {
+	var raw map[string]json.RawMessage
+	if err := c.ReadJSON(resp, &raw); err != nil {
+		return nil, err
+	}
+
+	configs := make(map[string]string, len(raw))
+	for k, v := range raw {
+		v = bytes.TrimSpace(v)
+		switch {
+		case len(v) > 0 && v[0] == '"':
+			var value string
+			if err := json.Unmarshal(v, &value); err != nil {
+				return nil, err
+			}
+			configs[k] = value
+		case string(v) == "null":
+			configs[k] = ""
+		default:
+			// i.e 9223372036854775807 instead of 9.223372036854776e+18.
+			configs[k] = string(v)
+		}
+	}
+
+	return configs, nil
+}
+
+// GetDynamicClusterConfigsMap returns all the dynamically updated configurations for a kafka cluster,
+// unlike the `GetDynamicClusterConfigs` it does not drop keys that are not part of the `BrokerConfig`.
+func (c *Client) GetDynamicClusterConfigsMap() (map[string]string, error) {
+	resp, err := c.Do(http.MethodGet, brokersConfigsPath, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.readConfigsMap(resp)
+}
+
+// GetDynamicBrokerConfigsMap returns all the dynamically updated configurations for a kafka broker,
+// unlike the `GetDynamicBrokerConfigs` it does not drop keys that are not part of the `BrokerConfig`.
+func (c *Client) GetDynamicBrokerConfigsMap(brokerID int) (map[string]string, error) {
+	path := fmt.Sprintf(brokerConfigsPath, brokerID)
+	resp, err := c.Do(http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.readConfigsMap(resp)
+}
+
+const brokersAvailableConfigKeysPath = "api/configs/default/brokers/keys"
+
+// GetAvailableBrokerConfigKeys retrieves a list of the broker configs that can be updated dynamically.
+func (c *Client) GetAvailableBrokerConfigKeys() ([]string, error) {
+	resp, err := c.Do(http.MethodGet, brokersAvailableConfigKeysPath, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	if err = c.ReadJSON(resp, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// ToMap converts the broker config to the key-value form that the `UpdateDynamicBrokerConfigsMap`
+// and `UpdateDynamicClusterConfigsMap` accept, fields with zero values are not included.
+func (config BrokerConfig) ToMap() map[string]string {
+	m := make(map[string]string)
+
+	if config.LogCleanerThreads > 0 {
+		m["log.cleaner.threads"] = strconv.Itoa(config.LogCleanerThreads)
+	}
+
+	if config.CompressionType != "" {
+		m["compression.type"] = string(config.CompressionType)
+	}
+
+	if config.AdvertisedPort > 0 {
+		m["advertised.port"] = strconv.Itoa(config.AdvertisedPort)
+	}
+
+	return m
+}
+
+// validateBrokerConfigKeys makes sure that all the "configs" keys can be updated dynamically,
+// the validation is skipped when the box does not serve the available keys.
+func (c *Client) validateBrokerConfigKeys(configs map[string]string) error {
+	if len(configs) == 0 {
+		return errRequired("configs")
+	}
+
+	keys, err := c.GetAvailableBrokerConfigKeys()
+	if err != nil {
+		if isNotFound(err) {
+			golog.Debugf("Client#validateBrokerConfigKeys: the available broker config keys are not supported, skip validation: %v", err)
+			return nil
+		}
+		return err
+	}
+
+	for key := range configs {
+		found := false
+		for _, k := range keys {
+			if k == key {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("client: [%s] is not a dynamically updatable broker config", key)
+		}
+	}
+
+	return nil
 }
 
-// GetDynamicBrokerConfigs returns the dynamic updated configurations for a kafka broker.
-// Retrieves only the ones added/updated dynamically.
-func (c *Client) GetDynamicBrokerConfigs(brokerID int) (config BrokerConfig, err error) {
-	path := fmt.Sprintf(brokerConfigsPath, brokerID)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
-	if respErr != nil {
-		err = respErr
-		return
+// UpdateDynamicClusterConfigsMap adds or updates any dynamically updatable cluster configuration,
+// i.e "num.replica.fetchers" or "message.max.bytes".
+// The keys are validated against the `GetAvailableBrokerConfigKeys` before sent, if the box serves them.
+func (c *Client) UpdateDynamicClusterConfigsMap(toAddOrUpdate map[string]string) error {
+	if err := c.validateBrokerConfigKeys(toAddOrUpdate); err != nil {
+		return err
 	}
 
-	err = c.ReadJSON(resp, &config)
-	return
-}
-
-// UpdateDynamicClusterConfigs adds or updates cluster configuration dynamically.
-func (c *Client) UpdateDynamicClusterConfigs(toAddOrUpdate BrokerConfig) error {
 	send, err := json.Marshal(toAddOrUpdate)
 	if err != nil {
 		return err
@@ -3173,8 +5871,13 @@ func (c *Client) UpdateDynamicClusterConfigs(toAddOrUpdate BrokerConfig) error {
 	return resp.Body.Close()
 }
 
-// UpdateDynamicBrokerConfigs adds or updates broker configuration dynamically.
-func (c *Client) UpdateDynamicBrokerConfigs(brokerID int, toAddOrUpdate BrokerConfig) error {
+// UpdateDynamicBrokerConfigsMap adds or updates any dynamically updatable broker configuration.
+// The keys are validated against the `GetAvailableBrokerConfigKeys` before sent, if the box serves them.
+func (c *Client) UpdateDynamicBrokerConfigsMap(brokerID int, toAddOrUpdate map[string]string) error {
+	if err := c.validateBrokerConfigKeys(toAddOrUpdate); err != nil {
+		return err
+	}
+
 	send, err := json.Marshal(toAddOrUpdate)
 	if err != nil {
 		return err
@@ -3189,6 +5892,18 @@ func (c *Client) UpdateDynamicBrokerConfigs(brokerID int, toAddOrUpdate BrokerCo
 	return resp.Body.Close()
 }
 
+// UpdateDynamicClusterConfigs adds or updates cluster configuration dynamically.
+// It's a convenience wrapper of the `UpdateDynamicClusterConfigsMap`.
+func (c *Client) UpdateDynamicClusterConfigs(toAddOrUpdate BrokerConfig) error {
+	return c.UpdateDynamicClusterConfigsMap(toAddOrUpdate.ToMap())
+}
+
+// UpdateDynamicBrokerConfigs adds or updates broker configuration dynamically.
+// It's a convenience wrapper of the `UpdateDynamicBrokerConfigsMap`.
+func (c *Client) UpdateDynamicBrokerConfigs(brokerID int, toAddOrUpdate BrokerConfig) error {
+	return c.UpdateDynamicBrokerConfigsMap(brokerID, toAddOrUpdate.ToMap())
+}
+
 // DeleteDynamicClusterConfigs deletes cluster configuration(s) dynamically.
 // It reverts the configuration to its default value.
 func (c *Client) DeleteDynamicClusterConfigs(configKeysToBeReset ...string) error {
@@ -3243,6 +5958,18 @@ const (
 	AuditEntryConnector    AuditEntryType = "CONNECTOR"
 )
 
+// AuditEntryTypes are the available audit entry types.
+var AuditEntryTypes = []AuditEntryType{
+	AuditEntryTopic,
+	AuditEntryTopicData,
+	AuditEntryQuotas,
+	AuditEntryBrokerConfig,
+	AuditEntryACL,
+	AuditEntrySchema,
+	AuditEntryProcessor,
+	AuditEntryConnector,
+}
+
 // AuditEntryChange the go type describer for the audit entry changes, see the `AuditEntry` structure for more.
 type AuditEntryChange string
 
@@ -3289,6 +6016,75 @@ func (c *Client) GetAuditEntries() (entries []AuditEntry, err error) {
 	return auditsV3.Values, err
 }
 
+const auditEntriesPath = "api/audit"
+
+// AuditEntryQuery filters the audit entries of `GetAuditEntriesFiltered`, zero fields are ignored.
+type AuditEntryQuery struct {
+	// From and To are the unix milliseconds range of the entries.
+	From int64
+	To   int64
+	// Type is the type of the audited resource.
+	Type AuditEntryType
+	// User is the user who made the change.
+	User string
+	// PageSize is the number of entries retrieved per request, defaults to 1000.
+	PageSize int
+}
+
+func (q AuditEntryQuery) matches(entry AuditEntry) bool {
+	if q.From > 0 && entry.Timestamp < q.From {
+		return false
+	}
+
+	if q.To > 0 && entry.Timestamp > q.To {
+		return false
+	}
+
+	if q.Type != "" && q.Type != entry.Type {
+		return false
+	}
+
+	return q.User == "" || q.User == entry.User
+}
+
+// IterateAuditEntries returns an iterator over the audit entries matching the "query", page by page.
+func (c *Client) IterateAuditEntries(query AuditEntryQuery) *Iterator[AuditEntry] {
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = 1000
+	}
+
+	params := url.Values{}
+	if query.From > 0 {
+		params.Set("from", strconv.FormatInt(query.From, 10))
+	}
+	if query.To > 0 {
+		params.Set("to", strconv.FormatInt(query.To, 10))
+	}
+
+	fetch := pageFetcher[AuditEntry](c, auditEntriesPath, params, pageSize)
+	return NewIterator(func(ctx context.Context, page int) ([]AuditEntry, bool, error) {
+		values, more, err := fetch(ctx, page)
+		if err != nil {
+			return nil, false, err
+		}
+
+		entries := make([]AuditEntry, 0, len(values))
+		for _, entry := range values {
+			if query.matches(entry) {
+				entries = append(entries, entry)
+			}
+		}
+
+		return entries, more, nil
+	})
+}
+
+// GetAuditEntriesFiltered returns all the audit entries matching the "query", see `IterateAuditEntries`.
+func (c *Client) GetAuditEntriesFiltered(query AuditEntryQuery) ([]AuditEntry, error) {
+	return c.IterateAuditEntries(query).Collect(c.ctx)
+}
+
 // DeleteAuditEntries deletes audit logs.
 //
 // Deletes all the audit logs older than timestamp.
@@ -3308,13 +6104,99 @@ type AuditEntryHandler func(AuditEntry) error
 
 const auditPathSSE = "api/sse/audit"
 
+// AuditLiveOptions filters the live audit notifications of the `GetAuditEntriesLiveFiltered`, empty fields are ignored.
+type AuditLiveOptions struct {
+	// Types are the types of the audited resources to receive, i.e TOPIC and ACL.
+	Types []AuditEntryType
+	// Users are the users whose changes to receive.
+	Users []string
+}
+
+// validate returns an error if any of the `Types` is not one of the `AuditEntryTypes`, the types are upper cased.
+func (opts *AuditLiveOptions) validate() error {
+	for i, typ := range opts.Types {
+		upper := AuditEntryType(strings.ToUpper(string(typ)))
+		valid := false
+		for _, known := range AuditEntryTypes {
+			if upper == known {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			known := make([]string, len(AuditEntryTypes))
+			for j, t := range AuditEntryTypes {
+				known[j] = string(t)
+			}
+			return fmt.Errorf("unknown audit entry type [%s], available types are: [%s]", typ, strings.Join(known, ", "))
+		}
+
+		opts.Types[i] = upper
+	}
+
+	return nil
+}
+
+func (opts AuditLiveOptions) matches(entry AuditEntry) bool {
+	if len(opts.Types) > 0 {
+		matched := false
+		for _, typ := range opts.Types {
+			if typ == entry.Type {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(opts.Users) == 0 {
+		return true
+	}
+
+	for _, user := range opts.Users {
+		if user == entry.User {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetAuditEntriesLive returns the live audit notifications, see `GetAuditEntries` too.
 func (c *Client) GetAuditEntriesLive(handler AuditEntryHandler) error {
+	return c.GetAuditEntriesLiveFiltered(AuditLiveOptions{}, handler)
+}
+
+// GetAuditEntriesLiveFiltered returns the live audit notifications which match the "opts",
+// i.e only the TOPIC and ACL changes for a SIEM forwarder.
+// The filters are sent to the box, so the rest are not even streamed, and they are applied
+// before the "handler" is called too, in case the box does not support them.
+func (c *Client) GetAuditEntriesLiveFiltered(opts AuditLiveOptions, handler AuditEntryHandler) error {
 	if handler == nil {
 		return errRequired("handler")
 	}
 
-	resp, err := c.Do(http.MethodGet, auditPathSSE, contentTypeJSON, nil, func(r *http.Request) error {
+	opts.Types = append([]AuditEntryType(nil), opts.Types...)
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	path := auditPathSSE
+	params := url.Values{}
+	for _, typ := range opts.Types {
+		params.Add("type", string(typ))
+	}
+	for _, user := range opts.Users {
+		params.Add("user", user)
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil, func(r *http.Request) error {
 		r.Header.Add(acceptHeaderKey, "application/json, text/event-stream")
 		return nil
 	})
@@ -3363,6 +6245,10 @@ func (c *Client) GetAuditEntriesLive(handler AuditEntryHandler) error {
 			return err
 		}
 
+		if !opts.matches(entry) {
+			continue
+		}
+
 		if err = handler(entry); err != nil {
 			return err // stop on first error by the caller.
 		}
@@ -3496,6 +6382,36 @@ func (c *Client) GetSupportedConnectors() ([]ConnectorInfoUI, error) {
 	return connectorsInfo, err
 }
 
+const (
+	// ConnectorTypeSource is the `ConnectorInfoUI.Type` of the source connectors.
+	ConnectorTypeSource = "source"
+	// ConnectorTypeSink is the `ConnectorInfoUI.Type` of the sink connectors.
+	ConnectorTypeSink = "sink"
+)
+
+// GetSupportedConnectorsByType returns the supported Kafka Connectors of the "typeFilter" type,
+// which can be `ConnectorTypeSource`, `ConnectorTypeSink` or empty for all of them, see `GetSupportedConnectors`.
+func (c *Client) GetSupportedConnectorsByType(typeFilter string) ([]ConnectorInfoUI, error) {
+	typeFilter = strings.ToLower(typeFilter)
+	if typeFilter != "" && typeFilter != ConnectorTypeSource && typeFilter != ConnectorTypeSink {
+		return nil, fmt.Errorf("invalid connector type [%s], available types are: [%s, %s]", typeFilter, ConnectorTypeSource, ConnectorTypeSink)
+	}
+
+	connectorsInfo, err := c.GetSupportedConnectors()
+	if err != nil || typeFilter == "" {
+		return connectorsInfo, err
+	}
+
+	filtered := make([]ConnectorInfoUI, 0, len(connectorsInfo))
+	for _, info := range connectorsInfo {
+		if strings.EqualFold(info.Type, typeFilter) {
+			filtered = append(filtered, info)
+		}
+	}
+
+	return filtered, nil
+}
+
 const (
 	topicExtractPath = "/api/topology/"
 )
@@ -3519,6 +6435,229 @@ func (c *Client) GetTopicExtract(id string) ([]TopicExtract, error) {
 	return topics, err
 }
 
+const topologyPath = "/api/topology"
+
+// TopologyNodeType is the kind of a topology node, i.e. a topic or a processor.
+type TopologyNodeType string
+
+// The available topology node types.
+const (
+	TopologyNodeTopic     TopologyNodeType = "TOPIC"
+	TopologyNodeProcessor TopologyNodeType = "PROCESSOR"
+	TopologyNodeConnector TopologyNodeType = "CONNECTOR"
+	TopologyNodeApp       TopologyNodeType = "APP"
+)
+
+// TopologyNode is a topic, processor, connector or app of the topology graph.
+type TopologyNode struct {
+	ID          string           `json:"id" yaml:"id" header:"ID"`
+	Name        string           `json:"name" yaml:"name" header:"Name"`
+	Type        TopologyNodeType `json:"type" yaml:"type" header:"Type"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty" header:"Description"`
+}
+
+// TopologyEdge connects two nodes of the topology graph by their ids, data flows from the Source to the Target.
+type TopologyEdge struct {
+	Source string `json:"source" yaml:"source" header:"Source"`
+	Target string `json:"target" yaml:"target" header:"Target"`
+}
+
+// Topology is the whole graph of the topics, processors, connectors and apps and their connections,
+// see `GetTopology`.
+type Topology struct {
+	Nodes []TopologyNode `json:"nodes" yaml:"nodes"`
+	Edges []TopologyEdge `json:"edges" yaml:"edges"`
+}
+
+// Node returns the node with the given "id" and true, or false if the topology does not contain it.
+func (t Topology) Node(id string) (TopologyNode, bool) {
+	for _, node := range t.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+
+	return TopologyNode{}, false
+}
+
+// label returns the name of the node, or its id if it has no name.
+func (n TopologyNode) label() string {
+	if n.Name == "" {
+		return n.ID
+	}
+
+	return n.Name
+}
+
+// dotShapes are the Graphviz node shapes per topology node type.
+var dotShapes = map[TopologyNodeType]string{
+	TopologyNodeTopic:     "box",
+	TopologyNodeProcessor: "ellipse",
+	TopologyNodeConnector: "hexagon",
+	TopologyNodeApp:       "component",
+}
+
+// ToDOT returns the topology as a Graphviz directed graph, data flows from left to right.
+func (t Topology) ToDOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph topology {\n")
+	b.WriteString("  rankdir=LR;\n")
+
+	for _, node := range t.Nodes {
+		shape, ok := dotShapes[node.Type]
+		if !ok {
+			shape = "plaintext"
+		}
+
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(node.ID), dotQuote(node.label()), shape)
+	}
+
+	for _, edge := range t.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(edge.Source), dotQuote(edge.Target))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote returns "s" as a double-quoted DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mermaidShapes are the opening and closing brackets of the Mermaid node shapes per topology node type.
+var mermaidShapes = map[TopologyNodeType][2]string{
+	TopologyNodeTopic:     {"[", "]"},
+	TopologyNodeProcessor: {"([", "])"},
+	TopologyNodeConnector: {"{{", "}}"},
+	TopologyNodeApp:       {"[[", "]]"},
+}
+
+// ToMermaid returns the topology as a Mermaid flowchart, data flows from left to right.
+// The node ids are replaced by n0, n1 and so on as Mermaid does not accept any character in ids.
+func (t Topology) ToMermaid() string {
+	var b strings.Builder
+
+	b.WriteString("flowchart LR\n")
+
+	ids := make(map[string]string, len(t.Nodes))
+	writeNode := func(id string, node TopologyNode) string {
+		mermaidID := fmt.Sprintf("n%d", len(ids))
+		ids[id] = mermaidID
+
+		shape, ok := mermaidShapes[node.Type]
+		if !ok {
+			shape = mermaidShapes[TopologyNodeTopic]
+		}
+
+		fmt.Fprintf(&b, "  %s%s%s%s\n", mermaidID, shape[0], mermaidQuote(node.label()), shape[1])
+		return mermaidID
+	}
+
+	for _, node := range t.Nodes {
+		if _, ok := ids[node.ID]; !ok {
+			writeNode(node.ID, node)
+		}
+	}
+
+	for _, edge := range t.Edges {
+		source, ok := ids[edge.Source]
+		if !ok {
+			// the edges may point to nodes which are not part of the graph.
+			source = writeNode(edge.Source, TopologyNode{ID: edge.Source})
+		}
+
+		target, ok := ids[edge.Target]
+		if !ok {
+			target = writeNode(edge.Target, TopologyNode{ID: edge.Target})
+		}
+
+		fmt.Fprintf(&b, "  %s --> %s\n", source, target)
+	}
+
+	return b.String()
+}
+
+// mermaidQuote returns "s" as a double-quoted Mermaid label.
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// GetTopicFormatChangeImpact returns the processors, connectors and apps which read from the "topicName" topic
+// and so are affected if its value format changes to the "newValueType", one of the `TopicDataFormats`.
+// The impacts are empty if the topic's value is already of the "newValueType".
+func (c *Client) GetTopicFormatChangeImpact(topicName string, newValueType string) (Impacts, error) {
+	var impacts Impacts
+
+	if topicName == "" {
+		return impacts, errRequired("topicName")
+	}
+
+	if newValueType == "" {
+		return impacts, errRequired("newValueType")
+	}
+
+	topic, err := c.GetTopic(topicName)
+	if err != nil {
+		return impacts, err
+	}
+
+	if strings.EqualFold(topic.ValueType, newValueType) {
+		return impacts, nil
+	}
+
+	topology, err := c.GetTopology()
+	if err != nil {
+		return impacts, err
+	}
+
+	topicIDs := make(map[string]bool)
+	for _, node := range topology.Nodes {
+		if node.Type == TopologyNodeTopic && node.Name == topicName {
+			topicIDs[node.ID] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, edge := range topology.Edges {
+		if !topicIDs[edge.Source] || seen[edge.Target] {
+			continue
+		}
+		seen[edge.Target] = true
+
+		node, ok := topology.Node(edge.Target)
+		if !ok {
+			continue
+		}
+
+		detail := ImpactsDetails{ID: node.ID, Name: node.Name, Type: string(node.Type)}
+		switch node.Type {
+		case TopologyNodeProcessor:
+			impacts.Processors = append(impacts.Processors, detail)
+		case TopologyNodeConnector:
+			impacts.Connectors = append(impacts.Connectors, detail)
+		case TopologyNodeApp:
+			impacts.Apps = append(impacts.Apps, detail)
+		}
+	}
+
+	return impacts, nil
+}
+
+// GetTopology returns the full topology graph, see `GetTopicExtract` for the parents and descendants of a single node.
+func (c *Client) GetTopology() (Topology, error) {
+	var topology Topology
+
+	resp, err := c.Do(http.MethodGet, topologyPath, "", nil)
+	if err != nil {
+		return topology, err
+	}
+
+	err = c.ReadJSON(resp, &topology)
+	return topology, err
+}
+
 const (
 	sqlValidationPath = "/api/v1/sql/presentation"
 )
@@ -3573,6 +6712,123 @@ func (c *Client) ValidateSQL(sql string, caret int) (SQLValidationResponse, erro
 	return response, err
 }
 
+// ValidateProcessorSQL validates, but does not deploy, the SQL of a processor.
+// The result is not valid if the validation reported any error, its Line and Column (1-based)
+// point to the start of the first error and the Message describes it.
+func (c *Client) ValidateProcessorSQL(sql string) (LSQLValidation, error) {
+	v := LSQLValidation{IsValid: true}
+
+	if strings.TrimSpace(sql) == "" {
+		return v, errSQLEmpty
+	}
+
+	response, err := c.ValidateSQL(sql, 0)
+	if err != nil {
+		return v, err
+	}
+
+	for _, lint := range response.Lints {
+		if strings.EqualFold(lint.Type, "error") {
+			v.IsValid = false
+			v.Line, v.Column = sqlLineColumn(sql, lint.Start)
+			v.Message = lint.Text
+			break
+		}
+	}
+
+	return v, nil
+}
+
+// ProcessorSQLIssue is an error or a warning that the SQL validation reported for a processor, see `CheckProcessorsSQL`.
+type ProcessorSQLIssue struct {
+	ProcessorID string `json:"processorId" yaml:"processorId" header:"ID,text"`
+	Name        string `json:"name" yaml:"name" header:"Name"`
+	Severity    string `json:"severity" yaml:"severity" header:"Severity"`
+	Line        int    `json:"line" yaml:"line" header:"Line"`
+	Column      int    `json:"column" yaml:"column" header:"Column"`
+	Message     string `json:"message" yaml:"message" header:"Message"`
+}
+
+const processorRequestsLimit = 8
+
+// CheckProcessorsSQL validates the SQL of every processor, i.e. before upgrading the streaming engine,
+// and returns the errors and warnings reported for them, sorted by the processor name.
+// Processors without issues are not part of the result.
+func (c *Client) CheckProcessorsSQL() ([]ProcessorSQLIssue, error) {
+	processors, err := c.GetProcessors()
+	if err != nil {
+		return nil, err
+	}
+
+	streams := processors.Streams
+	issues := make([][]ProcessorSQLIssue, len(streams))
+
+	err = forEachConcurrently(len(streams), processorRequestsLimit, func(i int) error {
+		processor := streams[i]
+		if strings.TrimSpace(processor.SQL) == "" {
+			return nil
+		}
+
+		response, err := c.ValidateSQL(processor.SQL, 0)
+		if err != nil {
+			return fmt.Errorf("validate SQL of processor [%s]: %w", processor.Name, err)
+		}
+
+		for _, lint := range response.Lints {
+			severity := strings.ToLower(lint.Type)
+			if severity != "error" && severity != "warning" {
+				continue
+			}
+
+			line, column := sqlLineColumn(processor.SQL, lint.Start)
+			issues[i] = append(issues[i], ProcessorSQLIssue{
+				ProcessorID: processor.ID,
+				Name:        processor.Name,
+				Severity:    severity,
+				Line:        line,
+				Column:      column,
+				Message:     lint.Text,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ProcessorSQLIssue
+	for _, processorIssues := range issues {
+		result = append(result, processorIssues...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// sqlLineColumn returns the 1-based line and column of the "offset" (in characters) of the "sql".
+func sqlLineColumn(sql string, offset int) (line, column int) {
+	line, column = 1, 1
+	for i, r := range []rune(sql) {
+		if i >= offset {
+			break
+		}
+
+		if r == '\n' {
+			line++
+			column = 1
+			continue
+		}
+
+		column++
+	}
+
+	return
+}
+
 const (
 	policyPath = "/api/protection/policy"
 )
@@ -1,8 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestAPIConfig(t *testing.T) {
@@ -48,3 +59,2343 @@ func TestAPIConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestBrokerConfigToMap(t *testing.T) {
+	cfg := BrokerConfig{LogCleanerThreads: 2, CompressionType: Snappy}
+
+	got := cfg.ToMap()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 keys but got %d: %v", len(got), got)
+	}
+
+	if got["log.cleaner.threads"] != "2" {
+		t.Errorf("got `%v`, want `%v`", got["log.cleaner.threads"], "2")
+	}
+
+	if got["compression.type"] != "snappy" {
+		t.Errorf("got `%v`, want `%v`", got["compression.type"], "snappy")
+	}
+
+	if _, ok := got["advertised.port"]; ok {
+		t.Error("zero value `advertised.port` should not be included")
+	}
+}
+
+func TestNewProcessorHealth(t *testing.T) {
+	processor := ProcessorStream{
+		ID:      "lsql_1",
+		Runners: 2,
+		RunnerState: ProcessorAppState{
+			DeploymentStatus: "RUNNING",
+			RunnerStataus: map[string]ProcessorRunnerState{
+				"r1": {ID: "r1", State: "RUNNING"},
+				"r2": {ID: "r2", State: "FAILED", ErrorMessage: "boom"},
+			},
+		},
+	}
+
+	health := NewProcessorHealth(processor)
+	if health.RunningRunners != 1 {
+		t.Errorf("got `%v`, want `%v`", health.RunningRunners, 1)
+	}
+
+	if health.Healthy {
+		t.Error("processor with a failed runner should not be healthy")
+	}
+
+	if len(health.Errors) != 1 || health.Errors[0] != "r2: boom" {
+		t.Errorf("got `%v`, want `%v`", health.Errors, []string{"r2: boom"})
+	}
+
+	processor.RunnerState.RunnerStataus["r2"] = ProcessorRunnerState{ID: "r2", State: "RUNNING"}
+	if health = NewProcessorHealth(processor); !health.Healthy {
+		t.Errorf("processor with all runners running should be healthy: %v", health)
+	}
+}
+
+func TestNewConnectorDetail(t *testing.T) {
+	connector := Connector{
+		ClusterName: "dev",
+		Name:        "sink",
+		Tasks:       []ConnectorTaskReadOnly{{Connector: "sink", Task: 1}, {Connector: "sink", Task: 0}},
+	}
+	status := ConnectorStatus{
+		Name:      "sink",
+		Connector: ConnectorStatusConnectorField{State: "RUNNING", WorkerID: "worker:8083"},
+		Tasks:     []ConnectorStatusTask{{ID: 1, State: "FAILED", Trace: "boom"}},
+	}
+
+	detail := newConnectorDetail(connector, status)
+	if detail.State != "RUNNING" || detail.WorkerID != "worker:8083" {
+		t.Errorf("got `%v`, want connector state and worker from the status", detail)
+	}
+
+	if len(detail.Tasks) != 2 || detail.Tasks[0].ID != 0 || detail.Tasks[1].State != "FAILED" {
+		t.Errorf("got `%v`, want two tasks sorted by id with their states", detail.Tasks)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+// handlerTransport serves the requests by the "handler" instead of a box.
+func handlerTransport(handler http.HandlerFunc) roundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		handler(rec, r)
+
+		resp := rec.Result()
+		resp.Request = r
+		return resp, nil
+	}
+}
+
+// newTestClient returns a client of a token-authenticated box whose requests are served by the "handler".
+func newTestClient(t *testing.T, handler http.HandlerFunc, options ...ConnectionOption) *Client {
+	t.Helper()
+	return newTestClientWithConfig(t, ClientConfig{Host: "http://domain.com", Token: "secret"}, handler, options...)
+}
+
+// newTestClientWithConfig is like `newTestClient` but for a custom configuration.
+func newTestClientWithConfig(t *testing.T, cfg ClientConfig, handler http.HandlerFunc, options ...ConnectionOption) *Client {
+	t.Helper()
+
+	client, err := OpenConnection(cfg, append([]ConnectionOption{UsingTransport(handlerTransport(handler))}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestUsingTransport(t *testing.T) {
+	var called bool
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(strings.NewReader(`["a","b"]`)),
+			Request:    r,
+		}, nil
+	})
+
+	client, err := OpenConnection(ClientConfig{Host: "http://domain.com", Token: "secret"}, UsingTransport(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := client.GetAvailableTopicConfigKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Error("expected the custom transport to be used")
+	}
+
+	if len(keys) != 2 {
+		t.Errorf("got `%v`, want `%v`", keys, []string{"a", "b"})
+	}
+}
+
+func TestGroupConsumerGroupMembers(t *testing.T) {
+	partitions := []ConsumerGroupPartition{
+		{Consumer: Consumer{Topic: "a", ConsumerID: "c1", ClientID: "app"}, Partition: 0},
+		{Consumer: Consumer{Topic: "a", ConsumerID: "c2", ClientID: "app"}, Partition: 1},
+		{Consumer: Consumer{Topic: "b", ConsumerID: "c1", ClientID: "app"}, Partition: 0},
+		{Consumer: Consumer{Topic: "b"}, Partition: 1},
+	}
+
+	members := groupConsumerGroupMembers(partitions)
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members but got %d", len(members))
+	}
+
+	if members[0].ConsumerID != "c1" || len(members[0].Partitions) != 2 {
+		t.Errorf("got `%v`, want member `c1` with 2 partitions", members[0])
+	}
+
+	if members[1].ConsumerID != "c2" || len(members[1].Partitions) != 1 {
+		t.Errorf("got `%v`, want member `c2` with 1 partition", members[1])
+	}
+}
+
+func TestMaskConnectorConfig(t *testing.T) {
+	cfg := ConnectorConfig{
+		"connector.class":                 "io.lenses.S3SinkConnector",
+		"key.converter":                   "org.apache.kafka.connect.storage.StringConverter",
+		"connection.password":             "pass",
+		"aws.secret.access.key":           "secret",
+		"connect.s3.aws.access.key":       "access",
+		"gcp.credentials":                 "{}",
+		"sasl.jaas.config":                "jaas",
+		"connect.elastic.use.http.token":  "token",
+		"connect.elastic.write.timeout":   "300",
+		"connect.s3.aws.auth.mode":        "Credentials",
+		"connect.s3.aws.region":           "eu-west-1",
+		"connect.s3.kcql":                 "INSERT INTO bucket SELECT * FROM topic",
+		"connect.s3.aws.client.CLIENT_ID": "client",
+	}
+
+	masked := MaskConnectorConfig(cfg, nil)
+
+	for _, key := range []string{"connection.password", "aws.secret.access.key", "connect.s3.aws.access.key", "gcp.credentials", "sasl.jaas.config", "connect.elastic.use.http.token"} {
+		if masked[key] != MaskedConfigValue {
+			t.Errorf("got `%v`, want `%s` to be masked", masked[key], key)
+		}
+	}
+
+	for _, key := range []string{"connector.class", "key.converter", "connect.s3.aws.auth.mode", "connect.s3.kcql"} {
+		if masked[key] != cfg[key] {
+			t.Errorf("got `%v`, want `%s` to be kept as `%v`", masked[key], key, cfg[key])
+		}
+	}
+
+	if cfg["connection.password"] != "pass" {
+		t.Error("the given config should not be modified")
+	}
+
+	masked = MaskConnectorConfig(cfg, []string{"*.JAAS.config"})
+	if masked["sasl.jaas.config"] != MaskedConfigValue || masked["connection.password"] != "pass" {
+		t.Errorf("got `%v`, want only the custom pattern to be masked", masked)
+	}
+}
+
+func TestParseSubjectVersions(t *testing.T) {
+	versions, err := parseSubjectVersions([]byte(`[{"version":1,"id":10,"registeredAt":1600000000000,"registeredBy":"admin"},{"version":2,"id":12}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(versions) != 2 || versions[0].ID != 10 || versions[0].RegisteredBy != "admin" {
+		t.Errorf("got `%v`, want two versions with their metadata", versions)
+	}
+
+	if _, ok := versions[0].RegistrationTime(); !ok {
+		t.Error("expected the registration time of the first version")
+	}
+
+	if _, ok := versions[1].RegistrationTime(); ok {
+		t.Error("expected no registration time when the registry omits it")
+	}
+
+	versions, err = parseSubjectVersions([]byte(`[1,2,3]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(versions) != 3 || versions[2].Version != 3 || versions[2].ID != 0 {
+		t.Errorf("got `%v`, want the version numbers only", versions)
+	}
+
+	if _, err = parseSubjectVersions([]byte(`{"version":1}`)); err == nil {
+		t.Error("expected an error for an unexpected response")
+	}
+}
+
+func TestGetSubjectVersionsDetailedResolvesIDs(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sr/default/subject/payments/versions":
+			w.Write([]byte(`[1,2]`))
+		case "/api/v1/sr/default/subject/payments/versions/1":
+			w.Write([]byte(`{"subject":"payments","version":1,"id":10,"schema":"\"string\""}`))
+		case "/api/v1/sr/default/subject/payments/versions/2":
+			w.Write([]byte(`{"subject":"payments","version":2,"id":12,"schema":"\"string\""}`))
+		default:
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	versions, err := client.GetSubjectVersionsDetailed("payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []SchemaVersionInfo{{Version: 1, ID: 10}, {Version: 2, ID: 12}}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("got `%v`, want `%v`", versions, expected)
+	}
+}
+
+func TestRemoveSchemaVersionSafe(t *testing.T) {
+	var (
+		referencedBy = http.StatusOK
+		removed      bool
+	)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			removed = true
+			return
+		}
+
+		w.WriteHeader(referencedBy)
+		if referencedBy == http.StatusOK {
+			w.Write([]byte(`[42]`))
+		}
+	})
+
+	err := client.RemoveSchemaVersionSafe("orders-value", "2")
+	if refErr, ok := err.(*SchemaVersionReferencedError); !ok || refErr.ReferencedBy[0] != 42 || removed {
+		t.Fatalf("got `%v`, want a referenced error and no removal", err)
+	}
+
+	// a failed lookup is not skipped.
+	referencedBy = http.StatusInternalServerError
+	if err = client.RemoveSchemaVersionSafe("orders-value", "2"); err == nil || removed {
+		t.Fatalf("got `%v`, want the lookup error and no removal", err)
+	}
+
+	// registries without references support do not serve the lookup.
+	referencedBy = http.StatusNotFound
+	if err = client.RemoveSchemaVersionSafe("orders-value", "2"); err != nil || !removed {
+		t.Fatalf("got `%v`, want the version removed", err)
+	}
+}
+
+func TestCreateOrUpdateACLs(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var acl ACL
+		json.NewDecoder(r.Body).Decode(&acl)
+
+		status := http.StatusOK
+		if acl.Principal == "User:bad" {
+			status = http.StatusInternalServerError
+		}
+
+		w.WriteHeader(status)
+	})
+
+	acls := []ACL{
+		{PermissionType: ACLPermissionAllow, Principal: "User:good", Operation: ACLOperationRead, ResourceType: ACLResourceTopic, ResourceName: "a", Host: "*"},
+		{PermissionType: ACLPermissionAllow, Principal: "User:bad", Operation: ACLOperationRead, ResourceType: ACLResourceTopic, ResourceName: "b", Host: "*"},
+		{PermissionType: ACLPermissionAllow, Principal: "User:good", Operation: ACLOperationWrite, ResourceType: ACLResourceTopic, ResourceName: "c", Host: "*"},
+	}
+
+	err := client.CreateOrUpdateACLs(acls)
+	batchErr, ok := err.(*ACLBatchError)
+	if !ok {
+		t.Fatalf("got `%v`, want an ACLBatchError", err)
+	}
+
+	if requests != 3 || batchErr.Total != 3 || len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Errorf("got `%v` after `%d` requests, want all ACLs to be attempted and only the second to fail", batchErr, requests)
+	}
+
+	requests = 0
+	invalid := append(acls, ACL{PermissionType: ACLPermissionAllow, Principal: "User:good", Operation: ACLOperationWrite, ResourceType: ACLResourceCluster, Host: "*"})
+	if err = client.CreateOrUpdateACLs(invalid); err == nil || requests != 0 {
+		t.Errorf("got `%v` after `%d` requests, want a validation error before any request", err, requests)
+	}
+}
+
+func TestTopicMetadataValidate(t *testing.T) {
+	meta := TopicMetadata{TopicName: "a", KeyType: "string", ValueType: "Avro"}
+	if err := meta.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if meta.KeyType != "STRING" || meta.ValueType != "AVRO" {
+		t.Errorf("got `%v`, want the formats to be normalized", meta)
+	}
+
+	meta = TopicMetadata{TopicName: "a", KeyType: "double", ValueType: "csv"}
+	if err := meta.Validate(); err != nil || meta.KeyType != "DOUBLE" || meta.ValueType != "CSV" {
+		t.Errorf("got `%v`, `%v`, want the formats to be normalized", meta, err)
+	}
+
+	// unknown formats are left to the box.
+	meta = TopicMetadata{TopicName: "a", ValueType: "TWAVRO"}
+	if err := meta.Validate(); err != nil || meta.ValueType != "TWAVRO" {
+		t.Errorf("got `%v`, `%v`, want the unknown format kept as it is", meta, err)
+	}
+
+	meta = TopicMetadata{TopicName: "a"}
+	if err := meta.Validate(); err != nil {
+		t.Errorf("empty formats should be valid: %v", err)
+	}
+}
+
+func TestGetTopicsInNamespace(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"topicName":"finance-payments"},{"topicName":"sales-orders"},{"topicName":"finance-invoices"}]`))
+	})
+
+	topics, err := client.GetTopicsInNamespace("finance-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(topics) != 2 || topics[0].TopicName != "finance-payments" || topics[1].TopicName != "finance-invoices" {
+		t.Errorf("got `%v`, want only the finance topics", topics)
+	}
+
+	if _, err = client.GetTopicsInNamespace("finance-["); err == nil {
+		t.Error("expected an error for an invalid namespace")
+	}
+}
+
+func TestBoxConfigAccessors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+				"lenses.security.mode": "BASIC",
+				"lenses.schema.registry.urls": [{"url": "http://sr-1:8081"}, {"url": "http://sr-2:8081", "jmx": "sr-2:9582"}],
+				"lenses.zookeeper.hosts": [{"url": "zk-1:2181"}, {"url": ""}]
+			}`))
+	})
+
+	srURLs, err := client.GetSchemaRegistryURLs()
+	if err != nil || len(srURLs) != 2 || srURLs[1] != "http://sr-2:8081" {
+		t.Errorf("got `%v`, `%v`, want the schema registry urls", srURLs, err)
+	}
+
+	zkHosts, err := client.GetZookeeperHosts()
+	if err != nil || len(zkHosts) != 1 || zkHosts[0] != "zk-1:2181" {
+		t.Errorf("got `%v`, `%v`, want the non-empty zookeeper hosts", zkHosts, err)
+	}
+
+	if mode, err := client.GetSecurityMode(); err != nil || mode != "BASIC" {
+		t.Errorf("got `%v`, `%v`, want `BASIC`", mode, err)
+	}
+}
+
+func TestCreateGenericConnection(t *testing.T) {
+	var created bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+		switch {
+		case r.URL.Path == "/api/v1/connection/connection-templates":
+			body = `[{"name":"Elasticsearch","configuration":[
+				{"key":"nodes","required":true},
+				{"key":"user","required":false}
+			]}]`
+		case r.Method == http.MethodPost:
+			var payload ConnectionRequest
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload.TemplateName != "Elasticsearch" {
+				t.Errorf("got template `%s`, want the canonical template name", payload.TemplateName)
+			}
+			created = true
+		case r.Method == http.MethodGet:
+			body = `{"name":"es","templateName":"Elasticsearch","configuration":[{"key":"nodes","value":["http://es:9200"]}]}`
+		}
+
+		w.Write([]byte(body))
+	})
+
+	_, err := client.CreateGenericConnection(ConnectionRequest{
+		Name:          "es",
+		TemplateName:  "elasticsearch",
+		Configuration: []ConnectionConfig{{Key: "user", Value: "admin"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "[nodes]") || created {
+		t.Fatalf("got `%v`, want a missing required configuration error before any create request", err)
+	}
+
+	conn, err := client.CreateGenericConnection(ConnectionRequest{
+		Name:          "es",
+		TemplateName:  "ELASTICSEARCH",
+		Configuration: []ConnectionConfig{{Key: "nodes", Value: []string{"http://es:9200"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !created || conn.Name != "es" || conn.TemplateName != "Elasticsearch" {
+		t.Errorf("got `%v`, want the created connection", conn)
+	}
+}
+
+func TestGetConnectorStatuses(t *testing.T) {
+	var requests int32
+	newClient := func(expandSupported bool) *Client {
+		return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+
+			body := ""
+			switch {
+			case r.URL.Path == "/api/proxy-connect/dev/connectors" && expandSupported:
+				body = `{
+					"sink": {"status": {"name": "sink", "connector": {"state": "RUNNING", "worker_id": "w1"}}},
+					"source": {"status": {"name": "source", "connector": {"state": "FAILED", "worker_id": "w2"}}}
+				}`
+			case r.URL.Path == "/api/proxy-connect/dev/connectors":
+				body = `["sink", "source"]`
+			default:
+				name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/proxy-connect/dev/connectors/"), "/status")
+				body = fmt.Sprintf(`{"name": %q, "connector": {"state": "PAUSED", "worker_id": "w1"}}`, name)
+			}
+
+			w.Write([]byte(body))
+		})
+	}
+
+	statuses, err := newClient(true).GetConnectorStatuses("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 1 || len(statuses) != 2 || statuses["source"].Connector.State != "FAILED" {
+		t.Errorf("got `%v` in `%d` requests, want both statuses in a single request", statuses, requests)
+	}
+
+	requests = 0
+	statuses, err = newClient(false).GetConnectorStatuses("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 3 || len(statuses) != 2 || statuses["sink"].Name != "sink" || statuses["source"].Connector.State != "PAUSED" {
+		t.Errorf("got `%v` in `%d` requests, want a status request per connector", statuses, requests)
+	}
+}
+
+func TestRestartConnectorsOnlyFailed(t *testing.T) {
+	var restarts []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			restarts = append(restarts, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Write([]byte(`{
+			"sink": {"status": {"name": "sink", "connector": {"state": "RUNNING", "worker_id": "w1"}}},
+			"source": {"status": {"name": "source", "connector": {"state": "FAILED", "worker_id": "w2"}}}
+		}`))
+	})
+
+	results, err := client.RestartConnectors("dev", []string{"sink", "source", "sorce"}, 0, true)
+	if err == nil || !strings.Contains(err.Error(), "sorce") {
+		t.Errorf("got `%v`, want an error for the connector without a status", err)
+	}
+
+	if len(restarts) != 1 || restarts[0] != "/api/proxy-connect/dev/connectors/source/restart" {
+		t.Errorf("got restarts `%v`, want only the failed connector restarted", restarts)
+	}
+
+	if !results[0].Skipped || !results[1].Restarted || results[2].Restarted || results[2].Error == "" {
+		t.Errorf("got results `%v`", results)
+	}
+}
+
+func TestGetConnectorsExpanded(t *testing.T) {
+	newClient := func(expandSupported bool) *Client {
+		return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			body := ""
+			switch path := strings.TrimPrefix(r.URL.Path, "/api/proxy-connect/dev/connectors"); {
+			case path == "" && expandSupported:
+				if expand := r.URL.Query()["expand"]; len(expand) != 2 {
+					t.Errorf("got expand `%v`, want both info and status", expand)
+				}
+				body = `{
+					"source": {
+						"info": {"name": "source", "config": {"tasks.max": "1"}, "tasks": [{"connector": "source", "task": 0}]},
+						"status": {"name": "source", "connector": {"state": "RUNNING", "worker_id": "w1"}, "tasks": [{"id": 0, "state": "FAILED", "worker_id": "w1"}]}
+					},
+					"sink": {
+						"info": {"name": "sink", "config": {}, "tasks": []},
+						"status": {"name": "sink", "connector": {"state": "PAUSED", "worker_id": "w2"}}
+					}
+				}`
+			case path == "":
+				body = `["source", "sink"]`
+			case strings.HasSuffix(path, "/status"):
+				body = `{"connector": {"state": "RUNNING", "worker_id": "w1"}, "tasks": [{"id": 0, "state": "FAILED", "worker_id": "w1"}]}`
+			default:
+				body = fmt.Sprintf(`{"name": %q, "config": {}, "tasks": [{"task": 0}]}`, strings.TrimPrefix(path, "/"))
+			}
+
+			w.Write([]byte(body))
+		})
+	}
+
+	for _, expandSupported := range []bool{true, false} {
+		details, err := newClient(expandSupported).GetConnectorsExpanded("dev")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(details) != 2 || details[0].Name != "sink" || details[1].Name != "source" {
+			t.Fatalf("got `%v`, want both connectors sorted by name", details)
+		}
+
+		source := details[1]
+		if source.ClusterName != "dev" || source.State != "RUNNING" || len(source.Tasks) != 1 || source.Tasks[0].State != "FAILED" {
+			t.Errorf("got `%v`, want the source connector with its task states (expand supported: %v)", source, expandSupported)
+		}
+	}
+}
+
+func TestGetPartitionReassignments(t *testing.T) {
+	body := ""
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/kafka/reassignments" {
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+
+		w.Write([]byte(body))
+	})
+
+	for _, body = range []string{"", "null", "[]"} {
+		reassignments, err := client.GetPartitionReassignments()
+		if err != nil || reassignments == nil || len(reassignments) != 0 {
+			t.Errorf("got `%v`, `%v` for body `%s`, want an empty slice", reassignments, err, body)
+		}
+	}
+
+	body = `[
+		{"topic": "payments", "partition": 1, "currentReplicas": [1, 2], "targetReplicas": [2, 3]},
+		{"topic": "orders", "partition": 0, "currentReplicas": [1], "targetReplicas": [3]}
+	]`
+	reassignments, err := client.GetPartitionReassignments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reassignments) != 2 || reassignments[0].Topic != "orders" || reassignments[1].TargetReplicas[1] != 3 {
+		t.Errorf("got `%v`, want the reassignments sorted by topic", reassignments)
+	}
+}
+
+func TestSetReplicaThrottle(t *testing.T) {
+	var configs map[string]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/configs/topics/orders" {
+			t.Errorf("unexpected request [%s %s]", r.Method, r.URL.Path)
+		}
+
+		var payload UpdateConfigs
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+
+		configs = make(map[string]string)
+		for _, kv := range payload.Configs {
+			configs[kv.Key] = kv.Value
+		}
+	})
+
+	if err := client.SetReplicaThrottle("orders", []ReplicaThrottle{{0, 1}, {1, 2}, {0, 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if configs["leader.replication.throttled.replicas"] != "0:1,1:2" || configs["follower.replication.throttled.replicas"] != "0:1,1:2" {
+		t.Errorf("got `%v`, want both throttled replicas configs set to `0:1,1:2`", configs)
+	}
+
+	if err := client.ThrottleAll("orders"); err != nil || configs["leader.replication.throttled.replicas"] != "*" {
+		t.Errorf("got `%v`, `%v`, want the wildcard", configs, err)
+	}
+
+	if err := client.ClearReplicaThrottle("orders"); err != nil || configs["follower.replication.throttled.replicas"] != "" {
+		t.Errorf("got `%v`, `%v`, want the throttled replicas cleared", configs, err)
+	}
+
+	configs = nil
+	if err := client.SetReplicaThrottle("orders", []ReplicaThrottle{{Partition: -1, Broker: 1}}); err == nil || configs != nil {
+		t.Errorf("got `%v`, want an invalid replica error before any request", err)
+	}
+}
+
+func TestImpersonateUserHeader(t *testing.T) {
+	var impersonated string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		impersonated = r.Header.Get("X-Kafka-Lenses-Impersonate-User")
+
+		w.Write([]byte(`[]`))
+	}
+
+	client := newTestClientWithConfig(t, ClientConfig{Host: "http://domain.com", Token: "secret", ImpersonateUser: "alice"}, handler)
+	if _, err := client.GetServiceAccounts(); err != nil {
+		t.Fatal(err)
+	}
+
+	if impersonated != "alice" {
+		t.Errorf("got impersonated user `%s`, want `alice`", impersonated)
+	}
+
+	client = newTestClient(t, handler)
+	if _, err := client.GetServiceAccounts(); err != nil || impersonated != "" {
+		t.Errorf("got impersonated user `%s`, `%v`, want no impersonation header", impersonated, err)
+	}
+}
+
+func TestGetTopicConfigDefaults(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/configs/default/topics" {
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+
+		w.Write([]byte(`[
+				{"name": "cleanup.policy", "defaultValue": "delete", "type": "LIST", "documentation": "The retention policy"},
+				{"name": "retention.ms", "defaultValue": 604800000, "type": "LONG"},
+				{"name": "message.timestamp.difference.max.ms", "defaultValue": null, "type": "LONG"}
+			]`))
+	})
+
+	defaults, err := client.GetTopicConfigDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(defaults) != 3 {
+		t.Fatalf("got `%v`, want 3 defaults", defaults)
+	}
+
+	if d := defaults["cleanup.policy"]; d.Default != "delete" || d.Type != "LIST" || d.Doc != "The retention policy" || !d.IsDefault("delete") {
+		t.Errorf("got `%v`, want the cleanup.policy default", d)
+	}
+
+	if d := defaults["retention.ms"]; d.Default != "604800000" || d.IsDefault("1000") {
+		t.Errorf("got `%v`, want the retention.ms default as string", d)
+	}
+
+	if d := defaults["message.timestamp.difference.max.ms"]; d.Default != "" {
+		t.Errorf("got `%v`, want an empty default", d)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var sent []string
+	replyID := ""
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("X-Request-ID"))
+
+		if replyID != "" {
+			w.Header().Set("X-Request-ID", replyID)
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	})
+
+	_, err := client.Do(http.MethodGet, "api/topics", "", nil)
+	_, err2 := client.Do(http.MethodGet, "api/topics", "", nil)
+	if len(sent) != 2 || sent[0] == "" || sent[0] == sent[1] {
+		t.Fatalf("got request ids `%v`, want a unique id per call", sent)
+	}
+
+	if resErr, ok := err.(ResourceError); !ok || resErr.RequestID != sent[0] || !strings.Contains(resErr.String(), sent[0]) {
+		t.Errorf("got `%v`, want the sent request id `%s`", err, sent[0])
+	}
+
+	if resErr, ok := err2.(ResourceError); !ok || resErr.RequestID != sent[1] {
+		t.Errorf("got `%v`, want the sent request id `%s`", err2, sent[1])
+	}
+
+	replyID = "box-side-id"
+	_, err = client.Do(http.MethodGet, "api/topics", "", nil, WithRequestID("my-id"))
+	if sent[2] != "my-id" {
+		t.Errorf("got request id `%s`, want the supplied `my-id`", sent[2])
+	}
+
+	if resErr, ok := err.(ResourceError); !ok || resErr.RequestID != "box-side-id" {
+		t.Errorf("got `%v`, want the request id of the response", err)
+	}
+}
+
+func TestGetConsumerGroupsForTopic(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/topics/orders" {
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+
+		w.Write([]byte(`{"topicName": "orders", "consumers": [{"id": "billing", "state": "Stable", "active": true, "consumersCount": 2}]}`))
+	})
+
+	if _, err := client.GetConsumerGroupsForTopic(""); err == nil {
+		t.Error("expected an error for an empty topic name")
+	}
+
+	groups, err := client.GetConsumerGroupsForTopic("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].ID != "billing" || groups[0].ConsumersCount != 2 {
+		t.Errorf("got `%v`, want the `billing` group of the topic", groups)
+	}
+}
+
+func TestGetSupportedConnectorsByType(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+				{"class": "FileStreamSource", "name": "File Source", "type": "Source"},
+				{"class": "FileStreamSink", "name": "File Sink", "type": "Sink"}
+			]`))
+	})
+
+	all, err := client.GetSupportedConnectorsByType("")
+	if err != nil || len(all) != 2 {
+		t.Errorf("got `%v`, `%v`, want all the connectors", all, err)
+	}
+
+	sources, err := client.GetSupportedConnectorsByType(ConnectorTypeSource)
+	if err != nil || len(sources) != 1 || sources[0].Class != "FileStreamSource" {
+		t.Errorf("got `%v`, `%v`, want only the source connectors", sources, err)
+	}
+
+	sinks, err := client.GetSupportedConnectorsByType("SINK")
+	if err != nil || len(sinks) != 1 || sinks[0].Class != "FileStreamSink" {
+		t.Errorf("got `%v`, `%v`, want only the sink connectors", sinks, err)
+	}
+
+	if _, err = client.GetSupportedConnectorsByType("transform"); err == nil {
+		t.Error("expected an error for an invalid connector type")
+	}
+}
+
+func TestNormalizeAvroSchema(t *testing.T) {
+	a := `{"type": "record", "name": "Order",
+		"fields": [{"name": "id", "type": "long"}, {"name": "amount", "type": "double", "default": 0.10}]}`
+	b := `{"name":"Order","fields":[{"type":"long","name":"id"},{"default":0.10,"type":"double","name":"amount"}],"type":"record"}`
+
+	normalizedA, err := NormalizeAvroSchema(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalizedB, err := NormalizeAvroSchema(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if normalizedA != normalizedB {
+		t.Errorf("got `%s` and `%s`, want the same normalized schema", normalizedA, normalizedB)
+	}
+
+	expected := `{"fields":[{"name":"id","type":"long"},{"default":0.10,"name":"amount","type":"double"}],"name":"Order","type":"record"}`
+	if normalizedA != expected {
+		t.Errorf("got `%s`, want `%s`", normalizedA, expected)
+	}
+
+	// the order of the fields is significant.
+	reordered, _ := NormalizeAvroSchema(`{"type":"record","name":"Order","fields":[{"name":"amount","type":"double","default":0.10},{"name":"id","type":"long"}]}`)
+	if reordered == normalizedA {
+		t.Error("expected schemas with a different field order to differ")
+	}
+
+	for _, invalid := range []string{"", "{", `{"type":"string"} {}`} {
+		if _, err = NormalizeAvroSchema(invalid); err == nil {
+			t.Errorf("expected an error for the invalid schema `%s`", invalid)
+		}
+	}
+}
+
+func TestLookupSchema(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		status, body := http.StatusOK, `{"name": "orders-value", "format": "AVRO", "schema": "{\"type\": \"record\", \"name\": \"Order\", \"fields\": []}"}`
+		if r.URL.Path != "/api/v1/datasets/schema-registry/orders-value" {
+			status, body = http.StatusNotFound, `{"message": "not found"}`
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+
+	tests := []struct {
+		name     string
+		request  WriteSchemaReq
+		expected bool
+	}{
+		{"orders-value", WriteSchemaReq{Format: "AVRO", Schema: `{"fields":[],"name":"Order","type":"record"}`}, true},
+		{"orders-value", WriteSchemaReq{Format: "AVRO", Schema: `{"fields":[],"name":"Payment","type":"record"}`}, false},
+		{"orders-value", WriteSchemaReq{Format: "JSON", Schema: `{"fields":[],"name":"Order","type":"record"}`}, false},
+		{"payments-value", WriteSchemaReq{Format: "AVRO", Schema: `{"fields":[],"name":"Order","type":"record"}`}, false},
+	}
+
+	for i, tt := range tests {
+		same, err := client.LookupSchema(tt.name, tt.request)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+		if same != tt.expected {
+			t.Errorf("[%d] got `%v`, want `%v`", i, same, tt.expected)
+		}
+	}
+}
+
+func TestRegisterSchemas(t *testing.T) {
+	var registered []string
+	ids := map[string]string{"common": "1", "address": "2", "customer": "3"}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+
+		switch {
+		case r.Method == http.MethodPut:
+			subject := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/sr/default/subject/"), "/current-version")
+			registered = append(registered, subject)
+		case r.Method == http.MethodGet:
+			subject := strings.TrimPrefix(r.URL.Path, "/api/v1/datasets/schema-registry/")
+			body = fmt.Sprintf(`{"name": "%s", "schemaId": "%s"}`, subject, ids[subject])
+		}
+
+		w.Write([]byte(body))
+	})
+
+	schema := func(subject string, refs ...string) SchemaAsRequest {
+		s := SchemaAsRequest{Subject: subject, WriteSchemaReq: WriteSchemaReq{Format: "PROTOBUF", Schema: "syntax = \"proto3\";"}}
+		for _, ref := range refs {
+			s.References = append(s.References, SchemaReference{SchemaName: ref + ".proto", SubjectName: ref, Version: 1})
+		}
+		return s
+	}
+
+	// "external" is not part of the set, it is expected to be registered already.
+	got, err := client.RegisterSchemas([]SchemaAsRequest{
+		schema("customer", "address", "common"),
+		schema("address", "common", "external"),
+		schema("common"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if order := strings.Join(registered, ","); order != "common,address,customer" {
+		t.Errorf("got order `%s`, want referenced subjects first", order)
+	}
+
+	if len(got) != 3 || got["common"] != 1 || got["address"] != 2 || got["customer"] != 3 {
+		t.Errorf("got ids `%v`", got)
+	}
+
+	_, err = client.RegisterSchemas([]SchemaAsRequest{schema("common"), schema("a", "b"), schema("b", "a")})
+	if err == nil || !strings.Contains(err.Error(), "[a, b]") {
+		t.Errorf("got `%v`, want an error reporting the cyclic subjects", err)
+	}
+
+	if _, err = client.RegisterSchemas([]SchemaAsRequest{schema("common"), schema("common")}); err == nil {
+		t.Error("expected an error for a duplicated subject")
+	}
+}
+
+func TestOpenConnectionVerifyOnConnect(t *testing.T) {
+	var status int
+	var expiry int64
+	rt := handlerTransport(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/license" {
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"clientId": "id", "expiry": %d}`, expiry)
+	})
+
+	open := func(verify bool) error {
+		_, err := OpenConnection(ClientConfig{Host: "http://domain.com", Token: "secret", VerifyOnConnect: verify}, UsingTransport(rt))
+		return err
+	}
+
+	status, expiry = http.StatusServiceUnavailable, 0
+	if err := open(false); err != nil {
+		t.Errorf("got `%v`, want no call without verification", err)
+	}
+	if err := open(true); err == nil || !strings.Contains(err.Error(), "not reachable") {
+		t.Errorf("got `%v`, want a not reachable error", err)
+	}
+
+	status, expiry = http.StatusOK, time.Now().Add(-time.Hour).Unix()*1000
+	if err := open(true); err == nil || !strings.Contains(err.Error(), "has expired") {
+		t.Errorf("got `%v`, want a license expired error", err)
+	}
+
+	expiry = time.Now().Add(24*time.Hour).Unix() * 1000
+	if err := open(true); err != nil {
+		t.Errorf("got `%v`, want a verified connection", err)
+	}
+}
+
+func TestGetClusterMetrics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/kafka/cluster/metrics" {
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+
+		w.Write([]byte(`{"brokerCount": 3, "topicCount": 12, "partitionCount": 96,
+				"underReplicatedPartitions": 2, "offlinePartitions": 0, "messagesPerSecond": 1520.5}`))
+	})
+
+	metrics, err := client.GetClusterMetrics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ClusterMetrics{BrokerCount: 3, TopicCount: 12, PartitionCount: 96, UnderReplicatedPartitions: 2, MessagesPerSecond: 1520.5}
+	if metrics != expected {
+		t.Errorf("got `%#v`, want `%#v`", metrics, expected)
+	}
+
+	if metrics.Healthy() {
+		t.Error("expected a cluster with under-replicated partitions to not be healthy")
+	}
+}
+
+func TestRetryOnTooManyRequests(t *testing.T) {
+	var waits []time.Duration
+	defer func(wait func(context.Context, time.Duration) error) { retryWait = wait }(retryWait)
+	retryWait = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	var calls int
+	limited := 2
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"name":"orders"}` {
+			t.Errorf("got body `%s` on call [%d], want the same body on every retry", body, calls)
+		}
+
+		if calls <= limited {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+
+	if _, err := client.Do(http.MethodPost, "api/topics", contentTypeJSON, []byte(`{"name":"orders"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 3 || len(waits) != 2 || waits[0] != 2*time.Second {
+		t.Errorf("got [%d] calls and waits `%v`, want 3 calls after waiting 2s twice", calls, waits)
+	}
+
+	// more than the retries, the 429 is returned.
+	calls, limited, waits = 0, 10, nil
+	_, err := client.Do(http.MethodPost, "api/topics", contentTypeJSON, []byte(`{"name":"orders"}`))
+	if resErr, ok := err.(ResourceError); !ok || resErr.Code() != http.StatusTooManyRequests {
+		t.Errorf("got `%v`, want a 429 error", err)
+	}
+	if calls != defaultMaxRetries+1 {
+		t.Errorf("got [%d] calls, want [%d]", calls, defaultMaxRetries+1)
+	}
+
+	// disabled.
+	calls = 0
+	client.Config.MaxRetries = -1
+	client.Do(http.MethodPost, "api/topics", contentTypeJSON, []byte(`{"name":"orders"}`))
+	if calls != 1 {
+		t.Errorf("got [%d] calls, want no retries", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"Tue, 01 Jun 2021 09:00:30 GMT", 30 * time.Second, true},
+		{"Tue, 01 Jun 2021 08:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		wait, ok := parseRetryAfter(tt.value, now)
+		if wait != tt.expected || ok != tt.ok {
+			t.Errorf("[%s] got `%v`, `%v`, want `%v`, `%v`", tt.value, wait, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestGetTopicFormatChangeImpact(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+		switch r.URL.Path {
+		case "/api/topics/orders":
+			body = `{"topicName": "orders", "keyType": "STRING", "valueType": "JSON"}`
+		case "/api/topology":
+			body = `{
+				"nodes": [
+					{"id": "TOPIC-orders", "name": "orders", "type": "TOPIC"},
+					{"id": "PROCESSOR-enrich", "name": "enrich", "type": "PROCESSOR"},
+					{"id": "CONNECTOR-s3", "name": "s3-sink", "type": "CONNECTOR"},
+					{"id": "CONNECTOR-source", "name": "jdbc-source", "type": "CONNECTOR"},
+					{"id": "TOPIC-enriched", "name": "enriched", "type": "TOPIC"},
+					{"id": "APP-billing", "name": "billing", "type": "APP"}
+				],
+				"edges": [
+					{"source": "CONNECTOR-source", "target": "TOPIC-orders"},
+					{"source": "TOPIC-orders", "target": "PROCESSOR-enrich"},
+					{"source": "TOPIC-orders", "target": "CONNECTOR-s3"},
+					{"source": "PROCESSOR-enrich", "target": "TOPIC-enriched"},
+					{"source": "TOPIC-enriched", "target": "APP-billing"}
+				]
+			}`
+		default:
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+
+		w.Write([]byte(body))
+	})
+
+	impacts, err := client.GetTopicFormatChangeImpact("orders", "AVRO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(impacts.Processors) != 1 || impacts.Processors[0].Name != "enrich" {
+		t.Errorf("got processors `%v`, want the `enrich` processor", impacts.Processors)
+	}
+	if len(impacts.Connectors) != 1 || impacts.Connectors[0].Name != "s3-sink" {
+		t.Errorf("got connectors `%v`, want only the connector which reads from the topic", impacts.Connectors)
+	}
+	if len(impacts.Apps) != 0 {
+		t.Errorf("got apps `%v`, want none as they do not read from the topic", impacts.Apps)
+	}
+
+	impacts, err = client.GetTopicFormatChangeImpact("orders", "json")
+	if err != nil || len(impacts.Processors)+len(impacts.Connectors)+len(impacts.Apps) != 0 {
+		t.Errorf("got `%v`, `%v`, want no impacts for the same format", impacts, err)
+	}
+}
+
+func TestReadJSONStrict(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"clientId": "id", "expiry": 0, "newField": true}`))
+	})
+
+	if lc, err := client.GetLicenseInfo(); err != nil || lc.ClientID != "id" {
+		t.Errorf("got `%v`, `%v`, want unknown fields to be ignored by default", lc, err)
+	}
+
+	client.Config.StrictJSON = true
+	_, err := client.GetLicenseInfo()
+	if err == nil || !strings.Contains(err.Error(), `unknown field "newField"`) || !strings.Contains(err.Error(), "GET /api/v1/license") {
+		t.Errorf("got `%v`, want an unknown field error with the request", err)
+	}
+}
+
+func TestGetAlertSettingsByCategory(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"categories": {
+				"infrastructure": [{"id": 1000, "description": "Kafka Broker is down"}],
+				"Data Produced": [{"id": 5000, "description": "Data Produced Below Threshold"}]
+			}}`))
+	})
+
+	settings, err := client.GetAlertSettingsByCategory("Producers")
+	if err != nil || len(settings) != 1 || settings[0].ID != 5000 {
+		t.Errorf("got `%v`, `%v`, want the producers settings", settings, err)
+	}
+
+	before := atomic.LoadInt32(&calls)
+	if _, err = client.GetAlertSettingsByCategory("brokers"); err == nil || !strings.Contains(err.Error(), "infrastructure, consumers, producers, apps") {
+		t.Errorf("got `%v`, want an unknown category error", err)
+	}
+	if atomic.LoadInt32(&calls) != before {
+		t.Error("want an unknown category to fail before the request")
+	}
+
+	setting, err := client.FindAlertSettingByDescription("kafka broker is down")
+	if err != nil || setting.ID != 1000 {
+		t.Errorf("got `%v`, `%v`, want the setting 1000", setting, err)
+	}
+
+	if _, err = client.FindAlertSettingByDescription("Zookeeper is down"); err == nil {
+		t.Error("expected a not found error")
+	}
+}
+
+func TestConfigureAlert(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := "{}"
+		if r.Method == http.MethodGet {
+			body = `{"categories": {"consumers": [{"id": 2000, "conditions": {
+				"a": "lag >= 100000 on group g1 and topic t1",
+				"b": "lag >= 500 on group g2 and topic t2"
+			}}]}}`
+		} else {
+			payload := ""
+			if r.Body != nil {
+				b, _ := ioutil.ReadAll(r.Body)
+				payload = " " + strings.ReplaceAll(string(b), `\u003e`, ">")
+			}
+			requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+payload))
+		}
+
+		w.Write([]byte(body))
+	})
+
+	err := client.ConfigureAlert(2000, true, []string{"lag >= 500 on group g2 and topic t2", "lag >= 10 on group g3 and topic t3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"DELETE /api/v1/alert/settings/2000/conditions/a",
+		`POST /api/v1/alert/settings/2000/conditions {"condition":"lag >= 10 on group g3 and topic t3","channels":[]}`,
+		`PUT /api/v1/alert/settings/2000 {"enable":true,"channels":[]}`,
+	}
+	if got, want := strings.Join(requests, "\n"), strings.Join(expected, "\n"); got != want {
+		t.Errorf("got requests:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestConnectorStatusFailedTasks(t *testing.T) {
+	status := ConnectorStatus{Tasks: []ConnectorStatusTask{
+		{ID: 0, State: "RUNNING"},
+		{ID: 1, State: "FAILED", Trace: "\norg.apache.kafka.connect.errors.ConnectException: Tolerance exceeded in error handler\n\tat org.apache.kafka.connect.runtime.WorkerTask.run(WorkerTask.java:185)\nCaused by: org.apache.kafka.common.errors.RecordTooLargeException: too large\n"},
+		{ID: 2, State: "FAILED"},
+	}}
+
+	failed := status.FailedTasks()
+	if len(failed) != 2 || failed[0].ID != 1 || failed[1].ID != 2 {
+		t.Fatalf("got `%v`, want the tasks 1 and 2", failed)
+	}
+
+	if got, want := failed[0].ShortError(), "ConnectException: Tolerance exceeded in error handler"; got != want {
+		t.Errorf("got `%v`, want `%v`", got, want)
+	}
+
+	if got := failed[1].ShortError(); got != "" {
+		t.Errorf("got `%v`, want an empty error without a trace", got)
+	}
+
+	if got, want := (ConnectorStatusTask{Trace: "Task is being killed."}).ShortError(), "Task is being killed."; got != want {
+		t.Errorf("got `%v`, want `%v`", got, want)
+	}
+}
+
+type closeIdleTransport struct {
+	roundTripperFunc
+	closed int
+}
+
+func (t *closeIdleTransport) CloseIdleConnections() {
+	t.closed++
+}
+
+func TestClientClose(t *testing.T) {
+	transport := &closeIdleTransport{roundTripperFunc: func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader("")), Request: r}, nil
+	}}
+
+	client, err := OpenConnection(ClientConfig{Host: "http://domain.com", Token: "secret"}, UsingTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Close()
+	if transport.closed != 1 {
+		t.Errorf("got `%v` calls, want the idle connections to be closed once", transport.closed)
+	}
+
+	if client.GetAccessToken() != "secret" {
+		t.Error("want Close to keep the token")
+	}
+}
+
+func TestCreateTopicWithAssignment(t *testing.T) {
+	var sent string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		sent = r.Method + " " + r.URL.Path + " " + string(b)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.CreateTopicWithAssignment("orders", map[int][]int{0: {1, 2}, 1: {2, 3}}, KV{"cleanup.policy": "compact"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `POST /api/topics {"topicName":"orders","replicaAssignment":{"0":[1,2],"1":[2,3]},"configs":{"cleanup.policy":"compact"}}`
+	if sent != expected {
+		t.Errorf("got `%v`, want `%v`", sent, expected)
+	}
+
+	for _, invalid := range []map[int][]int{
+		nil,
+		{0: {1, 2}, 1: {2}},
+		{0: {1}, 2: {2}},
+		{0: {1, 1}},
+	} {
+		sent = ""
+		if err = client.CreateTopicWithAssignment("orders", invalid, nil); err == nil || sent != "" {
+			t.Errorf("got `%v`, want an error without a request for the assignment `%v`", err, invalid)
+		}
+	}
+}
+
+func TestGetTopicReplicaPlacement(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/kafka/topics/orders/partitions" {
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+
+		w.Write([]byte(`[
+				{"partition": 1, "leader": 2, "replicas": [2, 3], "isr": [2], "offlineReplicas": [3]},
+				{"partition": 0, "leader": 1, "replicas": [1, 2], "isr": [1, 2], "offlineReplicas": []}
+			]`))
+	})
+
+	placement, err := client.GetTopicReplicaPlacement("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(placement) != 2 || placement[0].Leader != 1 || placement[0].UnderReplicated() {
+		t.Errorf("got `%v`, want partition 0 led by broker 1 and fully replicated", placement)
+	}
+
+	if p := placement[1]; p.Leader != 2 || !p.UnderReplicated() || len(p.OfflineReplicas) != 1 || p.OfflineReplicas[0] != 3 {
+		t.Errorf("got `%v`, want partition 1 under-replicated with broker 3 offline", p)
+	}
+}
+
+func TestPatchConnectorConfig(t *testing.T) {
+	var sent map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := `{"name": "sink", "config": {"connector.class": "FileSink", "tasks.max": "1", "file": "/tmp/out"}}`
+		if r.Method == http.MethodPut {
+			if r.URL.Path != "/api/proxy-connect/dev/connectors/sink/config" {
+				t.Errorf("unexpected path `%s`", r.URL.Path)
+			}
+
+			b, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &sent); err != nil {
+				t.Fatal(err)
+			}
+			body = `{"name": "sink", "config": ` + string(b) + `}`
+		}
+
+		w.Write([]byte(body))
+	})
+
+	connector, err := client.PatchConnectorConfig("dev", "sink", ConnectorConfig{"tasks.max": "3", "file": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{"connector.class": "FileSink", "tasks.max": "3"}
+	if fmt.Sprint(sent) != fmt.Sprint(expected) {
+		t.Errorf("got `%v`, want `%v`", sent, expected)
+	}
+
+	if connector.Config["tasks.max"] != "3" {
+		t.Errorf("got `%v`, want the updated connector", connector)
+	}
+}
+
+func TestWhoAmI(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth" {
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+
+		w.Write([]byte(`{"user": "admin", "roles": ["admins"], "permissions": ["ViewKafkaSettings", "ManageConnectors"],
+				"namespaces": [{"connection": "kafka", "wildcards": ["*"], "permissions": ["ShowTopic", "CreateTopic"]}]}`))
+	})
+
+	user, err := client.WhoAmI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !user.HasPermission("manageconnectors") || user.HasPermission("ManageAudits") {
+		t.Errorf("got `%v`, want only the given permissions", user.Permissions)
+	}
+
+	if len(user.Namespaces) != 1 || user.Namespaces[0].Connection != "kafka" || len(user.Namespaces[0].Permissions) != 2 {
+		t.Errorf("got `%v`, want the kafka namespace", user.Namespaces)
+	}
+
+	if client.User.Name != "admin" || len(client.User.Permissions) != 2 {
+		t.Errorf("got `%v`, want the client's user to be refreshed", client.User)
+	}
+}
+
+func TestResourceErrorJSONDetails(t *testing.T) {
+	body := `{"error": {"message": "Topic [orders] already exists", "details": [{"field": "topicName"}]}}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(body))
+	})
+
+	err := client.CreateTopic("orders", 1, 1, nil)
+	resErr, ok := err.(ResourceError)
+	if !ok {
+		t.Fatalf("got `%v`, want a resource error", err)
+	}
+
+	if resErr.Body != "Topic [orders] already exists" || resErr.Raw != body {
+		t.Errorf("got body `%v` and raw `%v`, want the message and the raw body", resErr.Body, resErr.Raw)
+	}
+
+	if _, ok := resErr.Details["error"].(map[string]interface{}); !ok {
+		t.Errorf("got `%v`, want the decoded details", resErr.Details)
+	}
+
+	if got, want := jsonErrorMessage(map[string]interface{}{"errors": []interface{}{
+		map[string]interface{}{"msg": "a"}, map[string]interface{}{"reason": "b"},
+	}}), "a, b"; got != want {
+		t.Errorf("got `%v`, want `%v`", got, want)
+	}
+}
+
+func TestGetDatasets(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"datasets": {"pagesAmount": 2, "totalCount": 3, "values": [
+			{"sourceType": "Kafka", "name": "orders", "connectionName": "kafka", "valueType": "AVRO", "description": "All orders", "tags": [{"name": "pii"}]},
+			{"sourceType": "Kafka", "name": "customers-orders", "connectionName": "kafka", "valueType": "JSON"}
+		]}}`,
+		"2": `{"datasets": {"pagesAmount": 2, "totalCount": 3, "values": [
+			{"sourceType": "SchemaRegistrySubject", "name": "orders-value", "connectionName": "schema-registry", "format": "AVRO"}
+		]}}`,
+	}
+
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	})
+
+	datasets, err := client.GetDatasets(DatasetQuery{NamePrefix: "orders", Tags: []string{"pii"}, PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Dataset{
+		{Name: "orders", Connection: "kafka", Format: "AVRO", Description: "All orders", Tags: []string{"pii"}},
+		{Name: "orders-value", Connection: "schema-registry", Format: "AVRO"},
+	}
+	if fmt.Sprint(datasets) != fmt.Sprint(expected) {
+		t.Errorf("got `%v`, want `%v`", datasets, expected)
+	}
+
+	if len(queries) != 2 || !strings.Contains(queries[0], "tags=pii") || !strings.Contains(queries[0], "query=orders") {
+		t.Errorf("got queries `%v`, want two pages filtered by the tag and the name", queries)
+	}
+
+	queries = nil
+	if datasets, err = client.GetDatasets(DatasetQuery{Max: 1}); err != nil || len(datasets) != 1 || len(queries) != 1 {
+		t.Errorf("got `%v`, `%v` after `%d` requests, want one dataset from the first page", datasets, err, len(queries))
+	}
+}
+
+func TestSchemaRegistryMode(t *testing.T) {
+	var sent []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+		if r.Method == http.MethodGet {
+			body = `{"mode": "READWRITE"}`
+		} else {
+			b, _ := ioutil.ReadAll(r.Body)
+			sent = append(sent, r.Method+" "+r.URL.Path+" "+string(b))
+		}
+		w.Write([]byte(body))
+	})
+
+	if mode, err := client.GetSchemaRegistryMode(); err != nil || mode != SchemaModeReadWrite {
+		t.Errorf("got `%v`, `%v`, want `%v`", mode, err, SchemaModeReadWrite)
+	}
+
+	if err := client.SetSchemaRegistryMode("import"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.SetSubjectMode("orders-value", SchemaModeReadOnly); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`PUT /api/v1/sr/default/mode {"mode":"IMPORT"}`,
+		`PUT /api/v1/sr/default/subject/orders-value/mode {"mode":"READONLY"}`,
+	}
+	if strings.Join(sent, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got `%v`, want `%v`", sent, expected)
+	}
+
+	if err := client.SetSchemaRegistryMode("WRITEONLY"); err == nil || len(sent) != 2 {
+		t.Errorf("got `%v`, want an unknown mode error without a request", err)
+	}
+}
+
+func TestGetTopicLagSnapshots(t *testing.T) {
+	responses := map[string]string{
+		"/api/topics/orders":   `{"topicName": "orders", "messagesPerSecond": 12, "totalMessages": 1000, "consumers": [{"id": "billing"}, {"id": "shipping"}]}`,
+		"/api/topics/payments": `{"topicName": "payments", "messagesPerSecond": 3, "totalMessages": 50, "consumers": [{"id": "billing"}]}`,
+		"/api/consumers/billing": `{"id": "billing", "consumers": [
+			{"topic": "orders", "partition": 0, "lag": 5},
+			{"topic": "orders", "partition": 1, "lag": 7},
+			{"topic": "payments", "partition": 0, "lag": 1}
+		]}`,
+		"/api/consumers/shipping": `{"id": "shipping", "consumers": [{"topic": "orders", "partition": 0, "lag": 20}]}`,
+	}
+
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+		w.Write([]byte(body))
+	})
+
+	snapshots, err := client.GetTopicLagSnapshots([]string{"orders", "payments"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(snapshots) != 2 || snapshots[0].Timestamp == 0 || snapshots[0].Timestamp != snapshots[1].Timestamp {
+		t.Fatalf("got `%v`, want two snapshots of the same time", snapshots)
+	}
+
+	orders, payments := snapshots[0], snapshots[1]
+	if orders.Topic != "orders" || orders.ConsumerGroups != 2 || orders.TotalLag != 32 || orders.MaxLag != 20 || orders.MessagesPerSecond != 12 {
+		t.Errorf("got `%+v`, want the lag of both groups on orders", orders)
+	}
+
+	if payments.Topic != "payments" || payments.TotalLag != 1 || payments.MaxLag != 1 || payments.TotalMessages != 50 {
+		t.Errorf("got `%+v`, want the lag of billing on payments", payments)
+	}
+
+	if requests != 4 {
+		t.Errorf("got `%d` requests, want each consumer group to be fetched once", requests)
+	}
+}
+
+func TestGetProcessorsByState(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body := `{"streams": [
+			{"id": "1", "name": "enrich", "state": {"deploymentStatus": "RUNNING"}},
+			{"id": "2", "name": "dedup", "state": {"deploymentStatus": "FAILED", "deploymentError": "boom"}}
+		]}`
+		w.Write([]byte(body))
+	})
+
+	processors, err := client.GetProcessorsByState("failed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(processors) != 1 || processors[0].Name != "dedup" || processors[0].DeploymentState != ProcessorStateFailed {
+		t.Errorf("got `%v`, want only the failed processor", processors)
+	}
+
+	requests = 0
+	if _, err = client.GetProcessorsByState("broken"); err == nil || !strings.Contains(err.Error(), ProcessorStateNotRunning) {
+		t.Errorf("got `%v`, want an error listing the known states", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("got `%v` requests, want none for an unknown state", requests)
+	}
+}
+
+func TestTopicConfigDiff(t *testing.T) {
+	topic := Topic{Configs: []KV{
+		{"name": "retention.ms", "originalValue": "1000"},
+		{"name": "cleanup.policy", "originalValue": "delete"},
+		{"name": "message.format.version", "originalValue": "3.0-IV1"},
+	}}
+
+	current := topic.ConfigKV()
+	if comparable := (TopicConfig{KV: current}).ComparableKeys(); len(comparable) != 2 || comparable["message.format.version"] != nil {
+		t.Errorf("got `%v`, want the server managed keys excluded", comparable)
+	}
+
+	desired := KV{"retention.ms": 1000, "cleanup.policy": "delete", "message.format.version": "2.8"}
+	if diff := TopicConfigDiff(current, desired); len(diff) != 0 {
+		t.Errorf("got `%v`, want no changes", diff)
+	}
+
+	desired["cleanup.policy"] = "compact"
+	desired["segment.ms"] = "600000"
+	if diff := TopicConfigDiff(current, desired); len(diff) != 2 || diff["cleanup.policy"] != "compact" || diff["segment.ms"] != "600000" {
+		t.Errorf("got `%v`, want the changed and the new keys", diff)
+	}
+}
+
+func TestApplyACLs(t *testing.T) {
+	var created, deleted []ACL
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+		switch r.Method {
+		case http.MethodGet:
+			body = `[
+				{"permissionType": "ALLOW", "principal": "User:app", "operation": "READ", "resourceType": "TOPIC", "patternType": "LITERAL", "resourceName": "orders", "host": "*"},
+				{"permissionType": "ALLOW", "principal": "User:old", "operation": "WRITE", "resourceType": "TOPIC", "patternType": "LITERAL", "resourceName": "orders", "host": "*"}
+			]`
+		case http.MethodPut:
+			var acl ACL
+			json.NewDecoder(r.Body).Decode(&acl)
+			created = append(created, acl)
+		case http.MethodDelete:
+			var acl ACL
+			json.NewDecoder(r.Body).Decode(&acl)
+			deleted = append(deleted, acl)
+		}
+
+		w.Write([]byte(body))
+	})
+
+	desired := []ACL{
+		// same as the existing one, only the letter case, the host and the pattern type differ.
+		{PermissionType: "allow", Principal: "User:app", Operation: "read", ResourceType: "topic", ResourceName: "orders"},
+		{PermissionType: ACLPermissionAllow, Principal: "User:app", Operation: ACLOperationWrite, ResourceType: ACLResourceTopic, ResourceName: "payments", Host: "*"},
+	}
+
+	toAdd, toRemove, err := client.ACLsDiff(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(toAdd) != 1 || toAdd[0].ResourceName != "payments" || len(toRemove) != 1 || toRemove[0].Principal != "User:old" {
+		t.Fatalf("got `%v` to add and `%v` to remove, want only the payments one added and the old one removed", toAdd, toRemove)
+	}
+
+	if err = client.ApplyACLs(desired, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(created) != 1 || len(deleted) != 0 {
+		t.Errorf("got `%d` created and `%d` deleted, want the extra ACL kept without prune", len(created), len(deleted))
+	}
+
+	if err = client.ApplyACLs(desired, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deleted) != 1 || deleted[0].Principal != "User:old" {
+		t.Errorf("got `%v` deleted, want the old ACL pruned", deleted)
+	}
+}
+
+func TestDoFailover(t *testing.T) {
+	var requested []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.Hostname()+r.URL.Path)
+		switch r.URL.Hostname() {
+		case "primary.com":
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}
+		case "secondary.com":
+			status := http.StatusOK
+			if r.URL.Path == "/api/missing" {
+				status = http.StatusNotFound
+			}
+			return &http.Response{StatusCode: status, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader("")), Request: r}, nil
+		default:
+			t.Errorf("unexpected host `%s`", r.URL.Host)
+			return nil, fmt.Errorf("unexpected host")
+		}
+	})
+
+	cfg := ClientConfig{Host: "http://primary.com", FallbackHosts: []string{"http://secondary.com", "http://tertiary.com"}, Token: "secret"}
+	client, err := OpenConnection(cfg, UsingTransport(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Do(http.MethodGet, "api/topics", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// the secondary responded, it is used from now on.
+	resp, err = client.Do(http.MethodGet, "api/topics", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// an error response of the box does not fail over.
+	if _, err = client.Do(http.MethodGet, "api/missing", "", nil); err == nil {
+		t.Error("expected the 404 of the secondary")
+	}
+
+	expected := "primary.com/api/topics,secondary.com/api/topics,secondary.com/api/topics,secondary.com/api/missing"
+	if got := strings.Join(requested, ","); got != expected {
+		t.Errorf("got `%v`, want `%v`", got, expected)
+	}
+}
+
+func TestDoFailoverNotIdempotent(t *testing.T) {
+	var requested []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.Method+" "+r.URL.Hostname())
+		if r.URL.Hostname() == "primary.com" {
+			// the connection was made but reset while reading the response.
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: fmt.Errorf("connection reset by peer")}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader("")), Request: r}, nil
+	})
+
+	cfg := ClientConfig{Host: "http://primary.com", FallbackHosts: []string{"http://secondary.com"}, Token: "secret"}
+	client, err := OpenConnection(cfg, UsingTransport(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.Do(http.MethodPost, "api/topics", contentTypeJSON, []byte(`{"name":"orders"}`)); err == nil {
+		t.Error("expected the reset of the primary, the POST must not be sent again")
+	}
+
+	resp, err := client.Do(http.MethodGet, "api/topics", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	expected := "POST primary.com,GET primary.com,GET secondary.com"
+	if got := strings.Join(requested, ","); got != expected {
+		t.Errorf("got `%v`, want `%v`", got, expected)
+	}
+}
+
+func TestGetSchemasByIDs(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/sr/default/schemas/ids/")
+		status, body := http.StatusOK, fmt.Sprintf(`{"schema": "schema-%s"}`, id)
+		if id == "404" {
+			status, body = http.StatusNotFound, `{"message": "Schema not found"}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}, UsingSchemaIDCache(2))
+
+	schemas, err := client.GetSchemasByIDs([]int{1, 2, 1, 2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(schemas) != 2 || schemas[1] != "schema-1" || schemas[2] != "schema-2" || requests != 2 {
+		t.Fatalf("got `%v` after `%d` requests, want each id resolved once", schemas, requests)
+	}
+
+	// both are cached, the 3 evicts the least recently used 1.
+	client.GetSchemaByID(2)
+	client.GetSchemaByID(3)
+	if requests != 3 {
+		t.Errorf("got `%d` requests, want only the 3 requested", requests)
+	}
+
+	client.GetSchemaByID(1)
+	if requests != 4 {
+		t.Errorf("got `%d` requests, want the evicted 1 requested again", requests)
+	}
+
+	if _, err = client.GetSchemasByIDs([]int{1, 404}); err == nil || !strings.Contains(err.Error(), "schema [404]") {
+		t.Errorf("got `%v`, want the error of the unknown id", err)
+	}
+}
+
+func TestSchemaCacheTTL(t *testing.T) {
+	var requests int32
+	failWrites := false
+	if err := (ClientConfig{Host: "http://domain.com", Token: "secret", SchemaCacheTTL: "forever"}).Validate(); err == nil {
+		t.Error("expected an error for an invalid schema cache ttl")
+	}
+
+	client := newTestClientWithConfig(t, ClientConfig{Host: "http://domain.com", Token: "secret", SchemaCacheTTL: "1m"}, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body := `{"name": "orders-value", "format": "AVRO", "version": 1}`
+		if r.Method != http.MethodGet {
+			if failWrites {
+				http.Error(w, "Subject not found", http.StatusNotFound)
+				return
+			}
+			body = ""
+		}
+		w.Write([]byte(body))
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetSchema("orders-value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("got `%d` requests, want the schema served from the cache", requests)
+	}
+
+	client.InvalidateSchemaCache()
+	client.GetSchema("orders-value")
+	if requests != 2 {
+		t.Errorf("got `%d` requests, want the schema fetched again after the invalidation", requests)
+	}
+
+	// a write changes the latest version.
+	client.RemoveSchemaVersion("orders-value", "1")
+	client.GetSchema("orders-value")
+	if requests != 4 {
+		t.Errorf("got `%d` requests, want the schema fetched again after a write", requests)
+	}
+
+	// a failed write does not change it.
+	failWrites = true
+	if err := client.RemoveSchema("orders-value"); err == nil {
+		t.Error("expected the error of the failed write")
+	}
+	client.GetSchema("orders-value")
+	if requests != 5 {
+		t.Errorf("got `%d` requests, want the schema still served from the cache after a failed write", requests)
+	}
+
+	client.schemaCache.ttl = -time.Second
+	client.InvalidateSchemaCache()
+	client.GetSchema("orders-value")
+	client.GetSchema("orders-value")
+	if requests != 7 {
+		t.Errorf("got `%d` requests, want the expired schemas fetched again", requests)
+	}
+
+	if _, ok := client.schemaCache.get(schemaCacheKey("orders-value")); ok || len(client.schemaCache.entries) != 0 {
+		t.Errorf("got `%v`, want the expired schema removed once read", client.schemaCache.entries)
+	}
+}
+
+func TestGetAuditEntriesLiveFiltered(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query["type"]; len(got) != 2 || got[0] != "TOPIC" || got[1] != "ACL" {
+			t.Errorf("got type params `%v`, want `[TOPIC ACL]`", got)
+		}
+		if got := query.Get("user"); got != "admin" {
+			t.Errorf("got user param `%s`, want `admin`", got)
+		}
+
+		// the box may ignore the filters.
+		body := `data:{"type":"TOPIC","user":"admin","change":"ADD"}
+data:{"type":"SCHEMA","user":"admin","change":"ADD"}
+data:{"type":"ACL","user":"other","change":"ADD"}
+data:{"type":"ACL","user":"admin","change":"REMOVE"}
+`
+		w.Write([]byte(body))
+	})
+
+	var got []AuditEntryType
+	opts := AuditLiveOptions{Types: []AuditEntryType{"topic", AuditEntryACL}, Users: []string{"admin"}}
+	err := client.GetAuditEntriesLiveFiltered(opts, func(entry AuditEntry) error {
+		got = append(got, entry.Type)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[0] != AuditEntryTopic || got[1] != AuditEntryACL {
+		t.Errorf("got entries of types `%v`, want `[TOPIC ACL]`", got)
+	}
+
+	err = client.GetAuditEntriesLiveFiltered(AuditLiveOptions{Types: []AuditEntryType{"TABLE"}}, func(AuditEntry) error { return nil })
+	if err == nil {
+		t.Errorf("expected an error for an unknown type")
+	}
+}
+
+func TestGetByURL(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		body := `{"entityType":"USER","entityName":"alice"}`
+		w.Write([]byte(body))
+	})
+
+	for _, link := range []string{"/api/quotas/users/alice", "http://domain.com/api/quotas/users/alice", "HTTP://DOMAIN.com:80/api/quotas/users/alice?x=1"} {
+		var quota Quota
+		if err := client.GetByURL(link, &quota); err != nil {
+			t.Fatalf("[%s]: %v", link, err)
+		}
+		if quota.EntityName != "alice" {
+			t.Errorf("[%s]: got entity name `%s`, want `alice`", link, quota.EntityName)
+		}
+	}
+
+	for _, link := range []string{"http://evil.com/api/quotas", "//evil.com/api/quotas", "https://domain.com/api/quotas", "http://domain.com:8080/api/quotas"} {
+		if err := client.GetByURL(link, &Quota{}); err == nil {
+			t.Errorf("[%s]: expected an error for a different origin", link)
+		}
+	}
+
+	expected := []string{"/api/quotas/users/alice", "/api/quotas/users/alice", "/api/quotas/users/alice?x=1"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("got requests `%v`, want `%v`", paths, expected)
+	}
+}
+
+func TestWithMethodOverride(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("got method `%s`, want `POST`", r.Method)
+		}
+		if got := r.Header.Get("X-HTTP-Method-Override"); got != http.MethodDelete {
+			t.Errorf("got override header `%s`, want `DELETE`", got)
+		}
+	})
+
+	resp, err := client.Do(http.MethodDelete, "api/proxy-connect/dev/connectors/orders-sink", "", nil, WithMethodOverride(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if _, err = client.Do(http.MethodGet, "api/topics", "", nil, WithMethodOverride("TRACE")); err == nil {
+		t.Errorf("expected an error for an unsupported method")
+	}
+}
+
+func TestGetProcessorLogsAll(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/api/config":
+			body = `{"lenses.sql.execution.mode": "KUBERNETES"}`
+		case "/api/v1/streams":
+			body = `{"streams": [
+				{"id": "p1", "name": "enrich", "clusterName": "aks", "namespace": "dev", "state": {"runnerStatus": {
+					"enrich-0": {"id": "enrich-0", "status": "RUNNING"},
+					"enrich-1": {"id": "enrich-1", "status": "RUNNING"}
+				}}},
+				{"id": "p2", "name": "enrich", "clusterName": "aks", "namespace": "prod", "state": {"runnerStatus": {"enrich-prod": {}}}}
+			]}`
+		case "/api/sse/k8/logs/aks/dev/enrich-0":
+			body = `data:{"@timestamp": "2021-06-01T09:00:00Z", "message": "first", "level": "INFO"}
+data:{"@timestamp": "2021-06-01T09:00:02Z", "message": "third", "level": "INFO"}
+data:continued
+`
+		case "/api/sse/k8/logs/aks/dev/enrich-1":
+			body = `data:{"@timestamp": "2021-06-01T09:00:01Z", "message": "second", "level": "WARN"}
+data:{"@timestamp": "2021-06-01T09:00:03Z", "message": "fourth", "level": "INFO"}
+`
+		default:
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.Write([]byte(body))
+	})
+
+	var got []string
+	err := client.GetProcessorLogsAll("aks", "dev", "enrich", false, 0, func(pod, level, log string) error {
+		got = append(got, pod+" "+strings.TrimSpace(log))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"enrich-0 2021-06-01 09:00:00 first",
+		"enrich-1 2021-06-01 09:00:01 second",
+		"enrich-0 2021-06-01 09:00:02 third",
+		"enrich-0 continued",
+		"enrich-1 2021-06-01 09:00:03 fourth",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("got logs\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if err = client.GetProcessorLogsAll("aks", "staging", "enrich", false, 0, func(string, string, string) error { return nil }); err == nil {
+		t.Errorf("expected an error for a processor which does not exist")
+	}
+}
+
+func TestGetProcessorLogsAllFollow(t *testing.T) {
+	var ended int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/config":
+			w.Write([]byte(`{"lenses.sql.execution.mode": "KUBERNETES"}`))
+		case "/api/v1/streams":
+			w.Write([]byte(`{"streams": [{"id": "p1", "name": "enrich", "clusterName": "aks", "namespace": "dev", "state": {"runnerStatus": {
+				"enrich-0": {"id": "enrich-0"}, "enrich-1": {"id": "enrich-1"}
+			}}}]}`))
+		default:
+			w.Write([]byte(`data:{"@timestamp": "2021-06-01T09:00:00Z", "message": "started", "level": "INFO"}` + "\n"))
+			w.(http.Flusher).Flush()
+			if atomic.LoadInt32(&ended) == 1 && r.URL.Path == "/api/sse/k8/logs/aks/dev/enrich-0" {
+				return
+			}
+			// a quiet pod, its stream is open until the client cancels it.
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	follow := func(handler func(pod, level, log string) error) error {
+		done := make(chan error, 1)
+		go func() { done <- client.GetProcessorLogsAll("aks", "dev", "enrich", true, 10, handler) }()
+
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("the quiet streams were not canceled")
+			return nil
+		}
+	}
+
+	errStop := fmt.Errorf("stop")
+	if err = follow(func(string, string, string) error { return errStop }); err != errStop {
+		t.Errorf("got `%v`, want the error of the handler", err)
+	}
+
+	atomic.StoreInt32(&ended, 1)
+	if err = follow(func(string, string, string) error { return nil }); err != nil {
+		t.Errorf("got `%v`, want no error when a stream ends", err)
+	}
+}
+
+func TestTopologyToMermaid(t *testing.T) {
+	topology := Topology{
+		Nodes: []TopologyNode{
+			{ID: "TOPIC-orders", Name: "orders", Type: TopologyNodeTopic},
+			{ID: "CONNECTOR-sink", Name: `the "sink"`, Type: TopologyNodeConnector},
+		},
+		Edges: []TopologyEdge{
+			{Source: "TOPIC-orders", Target: "CONNECTOR-sink"},
+			{Source: "CONNECTOR-sink", Target: "EXTERNAL-db"},
+		},
+	}
+
+	expected := `flowchart LR
+  n0["orders"]
+  n1{{"the #quot;sink#quot;"}}
+  n0 --> n1
+  n2["EXTERNAL-db"]
+  n1 --> n2
+`
+	if got := topology.ToMermaid(); got != expected {
+		t.Errorf("got\n%s\nwant\n%s", got, expected)
+	}
+
+	if got := topology.ToDOT(); !strings.Contains(got, `"CONNECTOR-sink" [label="the \"sink\"", shape=hexagon];`) {
+		t.Errorf("got DOT without the escaped connector label\n%s", got)
+	}
+}
+
+func TestGetTopicMetricsHistory(t *testing.T) {
+	supported := true
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/kafka/topics/orders/metrics/history":
+			if !supported {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+
+			query := r.URL.Query()
+			if query.Get("from") != "1622538000000" || query.Get("to") != "1622541600000" || query.Get("step") != "60" {
+				t.Errorf("got query `%s`", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[
+				{"timestamp": 1622538000000, "messagesPerSec": 10.5, "bytesPerSec": 1024},
+				{"timestamp": 1622538060000, "messagesPerSec": 12, "bytesPerSec": 2048}
+			]`))
+		case "/api/topics/orders":
+			w.Write([]byte(`{"topicName": "orders"}`))
+		default:
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+	})
+
+	from := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+	points, err := client.GetTopicMetricsHistory("orders", from, from.Add(time.Hour), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(points) != 2 || points[1].MessagesPerSec != 12 || points[1].BytesPerSec != 2048 {
+		t.Errorf("got points `%v`", points)
+	}
+
+	supported = false
+	_, err = client.GetTopicMetricsHistory("orders", from, from.Add(time.Hour), time.Minute)
+	if !errors.Is(err, ErrTopicMetricsHistoryNotSupported) {
+		t.Errorf("got `%v`, want a not supported error", err)
+	}
+
+	if _, err = client.GetTopicMetricsHistory("orders", from, from, 0); err == nil {
+		t.Errorf("expected an error for an empty time range")
+	}
+
+	if _, err = client.GetTopicMetricsHistory("orders", from, from.Add(time.Hour), 500*time.Millisecond); err == nil {
+		t.Errorf("expected an error for a step under a second")
+	}
+}
+
+func TestWithCache(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		status := http.StatusOK
+		if strings.HasSuffix(r.URL.Path, "missing") {
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`["plugin"]`))
+	})
+
+	get := func(path string, options ...RequestOption) (string, error) {
+		resp, err := client.Do(http.MethodGet, path, "", nil, options...)
+		if err != nil {
+			return "", err
+		}
+		b, err := client.ReadResponseBody(resp)
+		return string(b), err
+	}
+
+	for i := 0; i < 3; i++ {
+		body, err := get("api/proxy-connect/dev/plugins", WithCache(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body != `["plugin"]` {
+			t.Errorf("got body `%s` on call [%d]", body, i)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("got [%d] calls, want the cached response after the first", calls)
+	}
+
+	// not cached without the option, on a different query or after clearing.
+	get("api/proxy-connect/dev/plugins")
+	get("api/proxy-connect/dev/plugins?expand=true", WithCache(time.Minute))
+	client.ClearCache()
+	get("api/proxy-connect/dev/plugins", WithCache(time.Minute))
+	if calls != 4 {
+		t.Errorf("got [%d] calls, want [4]", calls)
+	}
+
+	// not served to another identity.
+	client.Config.ImpersonateUser = "john"
+	get("api/proxy-connect/dev/plugins", WithCache(time.Minute))
+	client.Config.Token = "another"
+	get("api/proxy-connect/dev/plugins", WithCache(time.Minute))
+	if calls != 6 {
+		t.Errorf("got [%d] calls, want the cached response per token and impersonated user", calls)
+	}
+
+	// failures are not cached.
+	calls = 0
+	get("api/proxy-connect/dev/missing", WithCache(time.Minute))
+	get("api/proxy-connect/dev/missing", WithCache(time.Minute))
+	if calls != 2 {
+		t.Errorf("got [%d] calls, want the failed call to be sent again", calls)
+	}
+}
+
+func TestGetTopicConfigWithSource(t *testing.T) {
+	described := true
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/kafka/topics/orders/configs":
+			if !described {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`[
+				{"name": "retention.ms", "value": "1000", "source": "DYNAMIC_TOPIC_CONFIG"},
+				{"name": "cleanup.policy", "value": "delete", "source": "DEFAULT_CONFIG"},
+				{"name": "min.insync.replicas", "value": "2", "source": "STATIC_BROKER_CONFIG"},
+				{"name": "max.message.bytes", "value": "2000000", "source": "DYNAMIC_DEFAULT_BROKER_CONFIG"},
+				{"name": "ssl.truststore.password", "value": null, "source": "DEFAULT_CONFIG"}
+			]`))
+		case "/api/topics/orders":
+			w.Write([]byte(`{"topicName": "orders", "config": [
+				{"name": "retention.ms", "originalValue": "1000", "isDefault": false},
+				{"name": "cleanup.policy", "originalValue": "delete", "isDefault": true}
+			]}`))
+		default:
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+	})
+
+	entries, err := client.GetTopicConfigWithSource("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]TopicConfigEntry{
+		"retention.ms":            {Value: "1000", Source: TopicConfigSourceTopic},
+		"cleanup.policy":          {Value: "delete", Source: TopicConfigSourceDefault},
+		"min.insync.replicas":     {Value: "2", Source: TopicConfigSourceStatic},
+		"max.message.bytes":       {Value: "2000000", Source: TopicConfigSourceDynamic},
+		"ssl.truststore.password": {Source: TopicConfigSourceDefault},
+	}
+	if fmt.Sprint(entries) != fmt.Sprint(expected) {
+		t.Errorf("got `%v`, want `%v`", entries, expected)
+	}
+
+	// the box does not expose the describe configs.
+	described = false
+	entries, err = client.GetTopicConfigWithSource("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = map[string]TopicConfigEntry{
+		"retention.ms":   {Value: "1000", Source: TopicConfigSourceTopic},
+		"cleanup.policy": {Value: "delete", Source: TopicConfigSourceDefault},
+	}
+	if fmt.Sprint(entries) != fmt.Sprint(expected) {
+		t.Errorf("got `%v`, want `%v`", entries, expected)
+	}
+}
+
+func TestGetDynamicBrokerConfigsMap(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/configs/brokers/1" {
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+
+		w.Write([]byte(`{"log.segment.bytes": 1048576, "log.retention.ms": 9223372036854775807, "log.cleaner.min.cleanable.ratio": 0.5,
+			"log.cleanup.policy": "delete", "sasl.jaas.config": null, "unclean.leader.election.enable": false}`))
+	})
+
+	configs, err := client.GetDynamicBrokerConfigsMap(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"log.segment.bytes":               "1048576",
+		"log.retention.ms":                "9223372036854775807",
+		"log.cleaner.min.cleanable.ratio": "0.5",
+		"log.cleanup.policy":              "delete",
+		"sasl.jaas.config":                "",
+		"unclean.leader.election.enable":  "false",
+	}
+	if !reflect.DeepEqual(configs, expected) {
+		t.Errorf("got `%v`, want `%v`", configs, expected)
+	}
+}
+
+func TestUpdateDynamicBrokerConfigsMapValidation(t *testing.T) {
+	keysSupported := true
+	var updated bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/configs/default/brokers/keys":
+			if !keysSupported {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`["log.cleaner.threads"]`))
+		case "/api/configs/brokers/1":
+			updated = true
+		default:
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+		}
+	})
+
+	if err := client.UpdateDynamicBrokerConfigsMap(1, map[string]string{"broker.id": "2"}); err == nil || updated {
+		t.Errorf("got `%v`, want an error for a key which is not dynamically updatable", err)
+	}
+
+	keysSupported = false
+	if err := client.UpdateDynamicBrokerConfigsMap(1, map[string]string{"broker.id": "2"}); err != nil || !updated {
+		t.Errorf("got `%v`, want the update without validation when the keys are not served", err)
+	}
+}
+
+func TestServiceAccountWritePayload(t *testing.T) {
+	var sent []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		sent = append(sent, string(body))
+		w.Write([]byte(`{"token": "new"}`))
+	})
+
+	// as read by `GetServiceAccount`, i.e. to be edited and updated.
+	serviceAccount := ServiceAccount{Name: "ci", Owner: "admin", Groups: []string{"dev"}, CreatedAt: 1600000000000, TokenActive: true}
+	if _, err := client.CreateServiceAccount(&serviceAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.UpdateServiceAccount(&serviceAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"name":"ci","owner":"admin","groups":["dev"]}`
+	if len(sent) != 2 || sent[0] != expected || sent[1] != expected {
+		t.Errorf("got `%v`, want `%s` without the read-only fields", sent, expected)
+	}
+}
+
+func TestACLErrorNoAuthorizer(t *testing.T) {
+	body := "No authorizer is configured on the broker"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, body, http.StatusBadRequest)
+	})
+
+	acl := ACL{PermissionType: ACLPermissionAllow, Principal: "User:bob", Operation: ACLOperationRead, ResourceType: ACLResourceTopic, ResourceName: "orders", Host: "*"}
+	if err := client.CreateOrUpdateACL(acl); !errors.Is(err, ErrNoAuthorizer) || !strings.Contains(err.Error(), body) {
+		t.Errorf("got `%v`, want the no authorizer error along with the message of the box", err)
+	}
+
+	body = "Invalid principal"
+	if err := client.CreateOrUpdateACL(acl); errors.Is(err, ErrNoAuthorizer) || !strings.Contains(strings.ToLower(err.Error()), "invalid principal") {
+		t.Errorf("got `%v`, want the bad request error of the box", err)
+	}
+}
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestInstallConnectorPluginStreamed(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.ContentLength != -1 {
+			t.Errorf("got content length [%d], want the archive streamed", r.ContentLength)
+		}
+
+		if _, _, err := r.FormFile("file"); err != nil {
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a streamed archive is not sent again on a 429.
+	if err = client.InstallConnectorPlugin("dev", strings.NewReader("jar contents"), "my-connector.jar"); err == nil {
+		t.Error("expected the error of the 429")
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("got [%d] requests, want [1]", n)
+	}
+
+	readErr := fmt.Errorf("disk failure")
+	if err = client.InstallConnectorPlugin("dev", failingReader{readErr}, "my-connector.jar"); err == nil || !strings.Contains(err.Error(), readErr.Error()) {
+		t.Errorf("got `%v`, want the read error of the archive", err)
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/lensesio/lenses-go/v5/pkg"
+)
+
+// KafkaClusterBroker describes a broker entry of the `KafkaClusterInfo`.
+type KafkaClusterBroker struct {
+	ID   int    `json:"id" header:"ID,text"`
+	Host string `json:"host" header:"Host"`
+	Port int    `json:"port" header:"Port,text"`
+	Rack string `json:"rack,omitempty" header:"Rack"`
+}
+
+// KafkaClusterInfo describes the data received from the `GetKafkaClusterInfo`.
+type KafkaClusterInfo struct {
+	ClusterID    string               `json:"clusterId" header:"Cluster ID"`
+	Version      string               `json:"kafkaVersion" header:"Kafka Version"`
+	ControllerID int                  `json:"controllerId" header:"Controller,text"`
+	BrokerCount  int                  `json:"brokerCount" header:"Brokers"`
+	Brokers      []KafkaClusterBroker `json:"brokers,omitempty"`
+}
+
+// GetKafkaClusterInfo returns the Kafka version, the controller broker id, the cluster id
+// and the number of brokers of the Kafka cluster that the lenses box is connected to.
+//
+// Boxes that do not expose the cluster endpoint fall back to the `BoxConfig`,
+// in that case only the `BrokerCount` is filled.
+func (c *Client) GetKafkaClusterInfo() (KafkaClusterInfo, error) {
+	var info KafkaClusterInfo
+
+	resp, err := c.Do(http.MethodGet, pkg.KafkaClusterPath, "", nil)
+	if err != nil {
+		if !isNotFound(err) {
+			return info, err
+		}
+
+		cfg, err := c.GetConfig()
+		if err != nil {
+			return info, err
+		}
+
+		for _, broker := range strings.Split(cfg.KafkaBrokers, ",") {
+			if strings.TrimSpace(broker) != "" {
+				info.BrokerCount++
+			}
+		}
+
+		return info, nil
+	}
+
+	if err = c.ReadJSON(resp, &info); err != nil {
+		return info, err
+	}
+
+	if info.BrokerCount == 0 {
+		info.BrokerCount = len(info.Brokers)
+	}
+
+	return info, nil
+}
+
+// ClusterMetrics is the health summary of the Kafka cluster, see `GetClusterMetrics`.
+type ClusterMetrics struct {
+	BrokerCount               int     `json:"brokerCount" yaml:"brokerCount" header:"Brokers"`
+	TopicCount                int     `json:"topicCount" yaml:"topicCount" header:"Topics"`
+	PartitionCount            int     `json:"partitionCount" yaml:"partitionCount" header:"Partitions"`
+	UnderReplicatedPartitions int     `json:"underReplicatedPartitions" yaml:"underReplicatedPartitions" header:"Under Replicated"`
+	OfflinePartitions         int     `json:"offlinePartitions" yaml:"offlinePartitions" header:"Offline"`
+	MessagesPerSecond         float64 `json:"messagesPerSecond" yaml:"messagesPerSecond" header:"msg/sec"`
+}
+
+// Healthy reports whether the cluster has no under-replicated and no offline partitions.
+func (m ClusterMetrics) Healthy() bool {
+	return m.UnderReplicatedPartitions == 0 && m.OfflinePartitions == 0
+}
+
+// GetClusterMetrics returns the number of brokers, topics and partitions of the Kafka cluster,
+// its under-replicated and offline partitions and the total messages per second, as the box reports them.
+func (c *Client) GetClusterMetrics() (ClusterMetrics, error) {
+	var metrics ClusterMetrics
+
+	resp, err := c.Do(http.MethodGet, pkg.KafkaClusterMetricsPath, "", nil)
+	if err != nil {
+		return metrics, err
+	}
+
+	err = c.ReadJSON(resp, &metrics)
+	return metrics, err
+}
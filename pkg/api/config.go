@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 )
 
 const (
@@ -59,6 +61,12 @@ type (
 		// Host is the network shema  address and port that your lenses backend box is listening on.
 		Host string `json:"host" yaml:"Host" survey:"host"`
 
+		// FallbackHosts are the addresses of other lenses boxes, i.e of a high availability deployment,
+		// which are tried in order when the host in use cannot be reached.
+		// Only connection errors fail over, the error responses of the box, i.e 4xx, do not.
+		// The host which responded last is used for the next requests until it fails too.
+		FallbackHosts []string `json:"fallbackHosts,omitempty" yaml:"FallbackHosts,omitempty" survey:"-"`
+
 		// Authentication, in order to gain access using different kind of options.
 		//
 		// See `BasicAuthentication` and `KerberosAuthentication` or the example for more.
@@ -74,6 +82,14 @@ type (
 		// fill the `Authentication` field instead.
 		Token string `json:"token,omitempty" yaml:"Token,omitempty" survey:"-"`
 
+		// ImpersonateUser is the username the requests are performed on behalf of,
+		// i.e. for admins to verify what a user can and cannot access.
+		// It is sent as the "X-Kafka-Lenses-Impersonate-User" request header on authenticated requests,
+		// the box allows it only for users with the necessary permissions.
+		//
+		// It is a no-op unless impersonation is enabled on the box.
+		ImpersonateUser string `json:"impersonateUser,omitempty" yaml:"ImpersonateUser,omitempty" survey:"-"`
+
 		// Timeout specifies the timeout for connection establishment.
 		//
 		// Empty timeout value means no timeout.
@@ -83,11 +99,37 @@ type (
 		// Example: "5s" for 5 seconds, "5m" for 5 minutes and so on.
 		Timeout string `json:"timeout,omitempty" yaml:"Timeout,omitempty" survey:"timeout"`
 
+		// SchemaCacheTTL enables the in-client schema cache, the results of the `GetSchema` and `GetSchemaByID` calls
+		// are kept for this duration, i.e "5m", so decoders resolving the same schemas over and over do not hit the box every time.
+		// The cache can be cleared with `Client#InvalidateSchemaCache`.
+		//
+		// Empty value means no cache.
+		SchemaCacheTTL string `json:"schemaCacheTTL,omitempty" yaml:"SchemaCacheTTL,omitempty" survey:"-"`
+
 		// Insecure tells the client to connect even if the cert is invalid.
 		// Turn that to true if you get errors about invalid certifications for the specific host domain.
 		//
 		// Defaults to false.
 		Insecure bool `json:"insecure,omitempty" yaml:"Insecure,omitempty" survey:"insecure"`
+
+		// MaxRetries is the number of times a request is sent again when the box responds with 429 (Too Many Requests),
+		// i.e. behind a rate-limited gateway, each time after waiting as its "Retry-After" header says.
+		//
+		// Zero means the default of 3 retries, a negative value disables the retries.
+		MaxRetries int `json:"maxRetries,omitempty" yaml:"MaxRetries,omitempty" survey:"-"`
+
+		// StrictJSON makes the responses decoding fail when the box returns fields that the client does not know about,
+		// i.e. to catch the API changes between the client and the box versions.
+		//
+		// Defaults to false, unknown fields are ignored.
+		StrictJSON bool `json:"strictJSON,omitempty" yaml:"StrictJSON,omitempty" survey:"-"`
+
+		// VerifyOnConnect tells `OpenConnection` to check, after the authentication,
+		// that the box is reachable and its license is not expired, so a misconfigured
+		// or unhealthy box fails at startup instead of on the first real call.
+		//
+		// Defaults to false.
+		VerifyOnConnect bool `json:"verifyOnConnect,omitempty" yaml:"VerifyOnConnect,omitempty" survey:"-"`
 		// Debug activates the debug mode, it logs every request, the configuration (except the `Password`)
 		// and its raw response before decoded but after gzip reading.
 		//
@@ -128,6 +170,53 @@ func (c *ClientConfig) IsValid() bool {
 	return c.Host != "" && (c.Token != "" || c.Authentication != nil)
 }
 
+// Validate checks that the Host is a valid url, the Timeout, if any, is a valid duration
+// and that a Token or an Authentication is present. It returns all the problems found as a single error.
+func (c ClientConfig) Validate() error {
+	var problems []string
+
+	if c.Host == "" {
+		problems = append(problems, "host is missing")
+	} else {
+		c.FormatHost()
+		if u, err := url.Parse(c.Host); err != nil {
+			problems = append(problems, fmt.Sprintf("host [%s] is not a valid url: %v", c.Host, err))
+		} else if u.Hostname() == "" {
+			problems = append(problems, fmt.Sprintf("host [%s] is not a valid url", c.Host))
+		}
+	}
+
+	if c.SchemaCacheTTL != "" {
+		if _, err := time.ParseDuration(c.SchemaCacheTTL); err != nil {
+			problems = append(problems, fmt.Sprintf("schema cache ttl [%s] is not a valid duration, i.e. 5m", c.SchemaCacheTTL))
+		}
+	}
+
+	for _, host := range c.FallbackHosts {
+		fallback := ClientConfig{Host: host}
+		fallback.FormatHost()
+		if u, err := url.Parse(fallback.Host); err != nil || u.Hostname() == "" {
+			problems = append(problems, fmt.Sprintf("fallback host [%s] is not a valid url", host))
+		}
+	}
+
+	if c.Timeout != "" {
+		if _, err := time.ParseDuration(c.Timeout); err != nil {
+			problems = append(problems, fmt.Sprintf("timeout [%s] is not a valid duration, i.e. 15s", c.Timeout))
+		}
+	}
+
+	if c.Token == "" && c.Authentication == nil {
+		problems = append(problems, "token or authentication is missing")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
 // DefaultContextKey is used to set an empty client configuration when no custom context available.
 var DefaultContextKey = "master"
 
@@ -257,10 +346,26 @@ func (c *ClientConfig) Fill(other ClientConfig) bool {
 		c.Token = v
 	}
 
+	if v := other.ImpersonateUser; v != "" && v != c.ImpersonateUser {
+		c.ImpersonateUser = v
+	}
+
+	if v := other.FallbackHosts; len(v) > 0 {
+		c.FallbackHosts = v
+	}
+
 	if v := other.Timeout; v != "" && v != c.Timeout {
 		c.Timeout = v
 	}
 
+	if v := other.SchemaCacheTTL; v != "" && v != c.SchemaCacheTTL {
+		c.SchemaCacheTTL = v
+	}
+
+	if v := other.MaxRetries; v != 0 && v != c.MaxRetries {
+		c.MaxRetries = v
+	}
+
 	// set only when true.
 	if v := other.Debug; v {
 		c.Debug = v
@@ -270,9 +375,33 @@ func (c *ClientConfig) Fill(other ClientConfig) bool {
 		c.Insecure = v
 	}
 
+	if v := other.VerifyOnConnect; v {
+		c.VerifyOnConnect = v
+	}
+
+	if v := other.StrictJSON; v {
+		c.StrictJSON = v
+	}
+
 	return c.IsValid()
 }
 
+// Hosts returns the `Host` followed by the `FallbackHosts`, all formatted by `FormatHost`.
+func (c *ClientConfig) Hosts() []string {
+	hosts := make([]string, 0, len(c.FallbackHosts)+1)
+	hosts = append(hosts, c.Host)
+
+	for _, host := range c.FallbackHosts {
+		fallback := ClientConfig{Host: host}
+		fallback.FormatHost()
+		if fallback.Host != "" {
+			hosts = append(hosts, fallback.Host)
+		}
+	}
+
+	return hosts
+}
+
 // FormatHost will try to make sure that the schema:host:port pattern is followed on the `Host` field.
 func (c *ClientConfig) FormatHost() {
 	if len(c.Host) == 0 {
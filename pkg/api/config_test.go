@@ -229,3 +229,30 @@ Contexts:
 		t.Fatalf("expected result yaml to be written as:\n'%s'\nbut:\n'%s'", expected, got)
 	}
 }
+
+func TestClientConfigValidate(t *testing.T) {
+	valid := ClientConfig{Host: "domain.com:3030", Authentication: testBasicAuthenticationField, Timeout: "15s"}
+	if err := valid.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if valid.Host != "domain.com:3030" {
+		t.Errorf("got host `%v`, want Validate to leave the config as it is", valid.Host)
+	}
+
+	invalid := ClientConfig{Host: "http://domain com:3030", Timeout: "15"}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, expected := range []string{"not a valid url", "timeout [15]", "token or authentication"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("got `%v`, want it to contain `%v`", err, expected)
+		}
+	}
+
+	if _, err = OpenConnection(ClientConfig{Token: "secret"}); err == nil || !strings.Contains(err.Error(), "host is missing") {
+		t.Errorf("got `%v`, want OpenConnection to validate the config", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/lensesio/lenses-go/v5/pkg"
 )
@@ -56,6 +57,24 @@ func (c *Client) GetConnections() (response []ConnectionList, err error) {
 	return
 }
 
+// GetConnectionsByTemplate returns the connections of the "templateName" type, i.e. "Elasticsearch",
+// template names are matched case-insensitively. If "templateName" is empty it returns all the connections.
+func (c *Client) GetConnectionsByTemplate(templateName string) ([]ConnectionList, error) {
+	connections, err := c.GetConnections()
+	if err != nil || templateName == "" {
+		return connections, err
+	}
+
+	filtered := []ConnectionList{}
+	for _, conn := range connections {
+		if strings.EqualFold(conn.TemplateName, templateName) {
+			filtered = append(filtered, conn)
+		}
+	}
+
+	return filtered, nil
+}
+
 // GetConnection returns a specific connection
 func (c *Client) GetConnection(name string) (response Connection, err error) {
 	path := fmt.Sprintf("api/%s/%s", pkg.ConnectionsAPIPath, name)
@@ -72,6 +91,37 @@ func (c *Client) GetConnection(name string) (response Connection, err error) {
 	return
 }
 
+// MaskConnection returns a copy of the "conn" where the values of the secret configuration keys are replaced with the `MaskedConfigValue`.
+// The secret keys are the ones marked as secret by the connection's "template",
+// if "template" is nil then the keys that match the `DefaultSecretConfigPatterns` are masked instead.
+func MaskConnection(conn Connection, template *ConnectionTemplate) Connection {
+	secrets := make(map[string]bool)
+	if template != nil {
+		for _, cfg := range template.Config {
+			if cfg.IsSecret() {
+				secrets[cfg.Key] = true
+			}
+		}
+	}
+
+	masked := make([]ConnectionConfig, len(conn.Configuration))
+	for i, cfg := range conn.Configuration {
+		masked[i] = cfg
+
+		isSecret := secrets[cfg.Key]
+		if template == nil {
+			isSecret = matchesSecretPattern(cfg.Key, DefaultSecretConfigPatterns)
+		}
+
+		if isSecret && cfg.Value != nil {
+			masked[i].Value = MaskedConfigValue
+		}
+	}
+
+	conn.Configuration = masked
+	return conn
+}
+
 // ConnectionConfig type
 type ConnectionConfig struct {
 	Key   string      `json:"key" yaml:"key"`
@@ -178,14 +228,131 @@ func (c *Client) CreateConnection(connectionName string, templateName string, co
 	return
 }
 
+// ConnectionRequest is a connection of any template for the generic connections endpoint,
+// see `CreateGenericConnection` and `UpdateGenericConnection`.
+type ConnectionRequest struct {
+	Name          string             `json:"name" yaml:"name"`
+	TemplateName  string             `json:"templateName" yaml:"templateName"`
+	Configuration []ConnectionConfig `json:"configuration" yaml:"configuration"`
+	Tags          []string           `json:"tags" yaml:"tags"`
+}
+
+// Validate checks that all the configuration keys which are required by the "template" are set.
+func (r ConnectionRequest) Validate(template ConnectionTemplate) error {
+	set := make(map[string]bool, len(r.Configuration))
+	for _, cfg := range r.Configuration {
+		if cfg.Value != nil {
+			set[cfg.Key] = true
+		}
+	}
+
+	var missing []string
+	for _, cfg := range template.Config {
+		if cfg.Required && !set[cfg.Key] {
+			missing = append(missing, cfg.Key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("connection [%s]: missing required [%s] configuration [%s]", r.Name, template.Name, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// CreateGenericConnection validates the "payload" against its connection template,
+// creates the connection through the generic endpoint and returns the created connection.
+func (c *Client) CreateGenericConnection(payload ConnectionRequest) (Connection, error) {
+	if payload.Name == "" {
+		return Connection{}, errRequired("name")
+	}
+
+	if payload.TemplateName == "" {
+		return Connection{}, errRequired("templateName")
+	}
+
+	template, err := c.validateConnectionRequest(payload)
+	if err != nil {
+		return Connection{}, err
+	}
+	// the template is matched case-insensitively, send its canonical name.
+	payload.TemplateName = template.Name
+
+	send, err := json.Marshal(payload)
+	if err != nil {
+		return Connection{}, err
+	}
+
+	resp, err := c.Do(http.MethodPost, fmt.Sprintf("api/%s", pkg.ConnectionsAPIPath), contentTypeJSON, send)
+	if err != nil {
+		return Connection{}, err
+	}
+	resp.Body.Close()
+
+	return c.GetConnection(payload.Name)
+}
+
+// UpdateGenericConnection validates the "payload" against its connection template, the template of the
+// existing connection if not set, updates the connection of "name" and returns the updated connection.
+// The connection is renamed if the payload's Name differs.
+func (c *Client) UpdateGenericConnection(name string, payload ConnectionRequest) (Connection, error) {
+	if name == "" {
+		return Connection{}, errRequired("name")
+	}
+
+	if payload.Name == "" {
+		payload.Name = name
+	}
+
+	if payload.TemplateName == "" {
+		current, err := c.GetConnection(name)
+		if err != nil {
+			return Connection{}, err
+		}
+		payload.TemplateName = current.TemplateName
+	}
+
+	template, err := c.validateConnectionRequest(payload)
+	if err != nil {
+		return Connection{}, err
+	}
+	// the template is matched case-insensitively, send its canonical name.
+	payload.TemplateName = template.Name
+
+	send, err := json.Marshal(UpdateConnectionPayload{
+		Name:          payload.Name,
+		Configuration: payload.Configuration,
+		Tags:          payload.Tags,
+	})
+	if err != nil {
+		return Connection{}, err
+	}
+
+	resp, err := c.Do(http.MethodPut, fmt.Sprintf("api/%s/%s", pkg.ConnectionsAPIPath, name), contentTypeJSON, send)
+	if err != nil {
+		return Connection{}, err
+	}
+	resp.Body.Close()
+
+	return c.GetConnection(payload.Name)
+}
+
+// validateConnectionRequest validates the "payload" against its connection template and returns the template.
+func (c *Client) validateConnectionRequest(payload ConnectionRequest) (ConnectionTemplate, error) {
+	template, err := c.GetConnectionTemplate(payload.TemplateName)
+	if err != nil {
+		return template, err
+	}
+
+	return template, payload.Validate(template)
+}
+
 // GetConnectClusters Read KafkaConnect clusters via connections API
 func (c *Client) GetConnectClusters() (clusters []string, err error) {
-	connections, err := c.GetConnections()
+	connections, err := c.GetConnectionsByTemplate("KafkaConnect")
 
 	for _, conn := range connections {
-		if conn.TemplateName == "KafkaConnect" {
-			clusters = append(clusters, conn.Name)
-		}
+		clusters = append(clusters, conn.Name)
 	}
 
 	return
@@ -235,3 +402,69 @@ func (c *Client) DeleteConnection(connectionName string) (err error) {
 
 	return
 }
+
+// ConnectionUsage describes the resources that depend on a connection, see `GetConnectionUsage`.
+type ConnectionUsage struct {
+	Connection string   `json:"connection" yaml:"connection" header:"Connection,text"`
+	Connectors []string `json:"connectors" yaml:"connectors" header:"Connectors,count"`
+	Datasets   []string `json:"datasets" yaml:"datasets" header:"Datasets,count"`
+}
+
+// InUse reports whether any resource depends on the connection.
+func (u ConnectionUsage) InUse() bool {
+	return len(u.Connectors) > 0 || len(u.Datasets) > 0
+}
+
+// GetConnectionUsage returns the connectors and the datasets which depend on the connection,
+// connectors depend on the KafkaConnect connection of their cluster.
+func (c *Client) GetConnectionUsage(name string) (usage ConnectionUsage, err error) {
+	if name == "" {
+		err = errRequired("name")
+		return
+	}
+
+	connection, err := c.GetConnection(name)
+	if err != nil {
+		return
+	}
+
+	usage = ConnectionUsage{Connection: name, Connectors: []string{}, Datasets: []string{}}
+
+	if connection.TemplateName == "KafkaConnect" {
+		connectors, connErr := c.GetConnectors(name)
+		if connErr != nil {
+			err = connErr
+			return
+		}
+
+		usage.Connectors = append(usage.Connectors, connectors...)
+	}
+
+	matches, err := c.ListDatasetsPg(ListDatasetsParameters{Connections: []string{name}}, 0)
+	if err != nil {
+		return
+	}
+
+	for _, match := range matches {
+		b, marshalErr := json.Marshal(match)
+		if marshalErr != nil {
+			err = marshalErr
+			return
+		}
+
+		var dataset struct {
+			Name           string `json:"name"`
+			ConnectionName string `json:"connectionName"`
+		}
+		if err = json.Unmarshal(b, &dataset); err != nil {
+			return
+		}
+
+		// servers that ignore the connections filter return the datasets of all the connections.
+		if dataset.ConnectionName == name {
+			usage.Datasets = append(usage.Datasets, dataset.Name)
+		}
+	}
+
+	return
+}
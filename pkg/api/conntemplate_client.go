@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/lensesio/lenses-go/v5/pkg"
 )
@@ -34,9 +35,15 @@ type ConnectionTemplateConfig struct {
 	Description string                       `json:"description" yaml:"description" header:"Description,text"`
 	Required    bool                         `json:"required" yaml:"required" header:"Required,text"`
 	Mounted     bool                         `json:"mounted" yaml:"mounted" header:"Mounted,text"`
+	Secret      bool                         `json:"secret,omitempty" yaml:"secret,omitempty" header:"Secret,text"`
 	Type        ConnectionTemplateConfigType `json:"type" yaml:"type" header:"Type,text"`
 }
 
+// IsSecret reports whether the configuration holds a secret, either it is marked as secret or it is of the "SECRET" type.
+func (cfg ConnectionTemplateConfig) IsSecret() bool {
+	return cfg.Secret || strings.EqualFold(cfg.Type.Name, "SECRET")
+}
+
 // ConnectionTemplate type
 type ConnectionTemplate struct {
 	Name            string                     `json:"name,omitempty" yaml:"name" header:"Name,text"`
@@ -65,3 +72,26 @@ func (c *Client) GetConnectionTemplates() (response []ConnectionTemplate, err er
 
 	return
 }
+
+// GetConnectionTemplate returns the connection template of "name", i.e. "Elasticsearch",
+// template names are matched case-insensitively.
+func (c *Client) GetConnectionTemplate(name string) (template ConnectionTemplate, err error) {
+	if name == "" {
+		err = errRequired("name")
+		return
+	}
+
+	templates, err := c.GetConnectionTemplates()
+	if err != nil {
+		return
+	}
+
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, name) {
+			return t, nil
+		}
+	}
+
+	err = fmt.Errorf("connection template [%s] not found", name)
+	return
+}
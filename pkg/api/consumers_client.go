@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/lensesio/lenses-go/v5/pkg"
 )
@@ -56,3 +58,271 @@ func (c *Client) UpdateMultipleTopicsOffset(groupID, offsetType, target string,
 
 	return nil
 }
+
+// ConsumerGroupPartition describes a topic's partition assigned to a consumer group member,
+// along with its committed offset and lag.
+type ConsumerGroupPartition struct {
+	Consumer
+	Partition int `json:"partition" yaml:"partition"`
+}
+
+// ConsumerGroupMember describes a member of a consumer group and its assigned partitions,
+// see `ConsumerGroupDetail`.
+type ConsumerGroupMember struct {
+	ConsumerID string                   `json:"consumerId" yaml:"consumerId"`
+	ClientID   string                   `json:"clientId" yaml:"clientId"`
+	Host       string                   `json:"host" yaml:"host"`
+	Partitions []ConsumerGroupPartition `json:"partitions" yaml:"partitions"`
+}
+
+// ConsumerGroupDetail describes a consumer group, its coordinator, state and the per-member assignment,
+// see `GetConsumerGroupDetail`.
+type ConsumerGroupDetail struct {
+	ID          string                   `json:"id" yaml:"id" header:"ID"`
+	Coordinator ConsumerCoordinator      `json:"coordinator" yaml:"coordinator"`
+	State       ConsumerGroupState       `json:"state" yaml:"state" header:"State"`
+	Partitions  []ConsumerGroupPartition `json:"consumers" yaml:"consumers" header:"Partitions,count"`
+	Members     []ConsumerGroupMember    `json:"members" yaml:"members" header:"Members,count"`
+}
+
+// GetConsumerGroupDetail returns the details of a consumer group,
+// its members are filled by grouping the assigned partitions by their consumer id.
+func (c *Client) GetConsumerGroupDetail(groupID string) (ConsumerGroupDetail, error) {
+	var detail ConsumerGroupDetail
+
+	if groupID == "" {
+		return detail, errRequired("groupID")
+	}
+
+	path := fmt.Sprintf("%s/%s", pkg.ConsumersGroupPath, groupID)
+	resp, err := c.Do(http.MethodGet, path, "", nil)
+	if err != nil {
+		return detail, err
+	}
+
+	if err = c.ReadJSON(resp, &detail); err != nil {
+		return detail, err
+	}
+
+	detail.Members = groupConsumerGroupMembers(detail.Partitions)
+	return detail, nil
+}
+
+// groupConsumerGroupMembers groups the partitions by their consumer id, keeping the order of their first appearance.
+// Partitions without a consumer id are not assigned to any member.
+func groupConsumerGroupMembers(partitions []ConsumerGroupPartition) []ConsumerGroupMember {
+	var members []ConsumerGroupMember
+	indexes := make(map[string]int)
+
+	for _, partition := range partitions {
+		if partition.ConsumerID == "" {
+			continue
+		}
+
+		idx, ok := indexes[partition.ConsumerID]
+		if !ok {
+			idx = len(members)
+			indexes[partition.ConsumerID] = idx
+			members = append(members, ConsumerGroupMember{
+				ConsumerID: partition.ConsumerID,
+				ClientID:   partition.ClientID,
+				Host:       partition.Host,
+			})
+		}
+
+		members[idx].Partitions = append(members[idx].Partitions, partition)
+	}
+
+	return members
+}
+
+// isInactive reports whether the consumer group is in the `StateEmpty` or `StateDead` state,
+// a group without members may still be joining or rebalancing, so its members are not enough to tell.
+func (detail ConsumerGroupDetail) isInactive() bool {
+	return detail.State == StateEmpty || detail.State == StateDead
+}
+
+// DeleteConsumerGroup removes a consumer group, only empty (inactive) groups can be deleted.
+// It returns an error if the group is not in the `StateEmpty` or `StateDead` state.
+func (c *Client) DeleteConsumerGroup(groupID string) error {
+	if groupID == "" {
+		return errRequired("groupID")
+	}
+
+	detail, err := c.GetConsumerGroupDetail(groupID)
+	if err != nil {
+		return err
+	}
+
+	if !detail.isInactive() {
+		return fmt.Errorf("consumer group [%s] is not empty, it is [%s] and has [%d] active members", groupID, detail.State, len(detail.Members))
+	}
+
+	path := fmt.Sprintf("%s/%s", pkg.ConsumersGroupPath, groupID)
+	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// GetConsumerGroupsForTopic returns the consumer groups which consume from the "topicName" topic,
+// e.g. to check the impact before deleting or reconfiguring a topic.
+// They are the `ConsumersGroup` of `GetTopic`, the consumer groups list of the box can not be trusted
+// to be filtered by topic, its groups do not carry their topic assignments to filter them on the client side.
+func (c *Client) GetConsumerGroupsForTopic(topicName string) ([]ConsumersGroup, error) {
+	if topicName == "" {
+		return nil, errRequired("topicName")
+	}
+
+	topic, err := c.GetTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	return topic.ConsumersGroup, nil
+}
+
+// AllPartitions can be passed as the partition of `ResetConsumerGroupToTimestamp` to reset every partition of the topic.
+const AllPartitions = -1
+
+// ResetConsumerGroupToTimestamp resets the offset of the "group" on a "topic"'s partition, or on every partition if "partition" is `AllPartitions`,
+// to the earliest offset whose timestamp is equal or greater than the "ts" time, i.e. to reprocess everything since then.
+// Partitions without records at or after "ts" are reset to their latest offset.
+// The group must be inactive, it returns an error if the group is not in the `StateEmpty` or `StateDead` state.
+func (c *Client) ResetConsumerGroupToTimestamp(group, topic string, partition int, ts time.Time) error {
+	if group == "" {
+		return errRequired("group")
+	}
+
+	if topic == "" {
+		return errRequired("topic")
+	}
+
+	if partition < AllPartitions {
+		return fmt.Errorf("invalid partition [%d]", partition)
+	}
+
+	detail, err := c.GetConsumerGroupDetail(group)
+	if err != nil {
+		return err
+	}
+
+	if !detail.isInactive() {
+		return fmt.Errorf("consumer group [%s] is active, it is [%s] and has [%d] members, stop its consumers before resetting its offsets", group, detail.State, len(detail.Members))
+	}
+
+	offsets, err := c.GetTopicOffsetsForTimestamp(topic, ts)
+	if err != nil {
+		return err
+	}
+
+	reset := false
+	for _, offset := range offsets {
+		if partition != AllPartitions && offset.Partition != partition {
+			continue
+		}
+
+		offsetType, value := "absolute", int(offset.Offset)
+		if offset.Offset < 0 {
+			// no records at or after the given time.
+			offsetType, value = "end", 0
+		}
+
+		if err = c.UpdateSingleTopicOffset(group, topic, strconv.Itoa(offset.Partition), offsetType, value); err != nil {
+			return fmt.Errorf("reset partition [%d] of topic [%s]: %w", offset.Partition, topic, err)
+		}
+
+		reset = true
+	}
+
+	if !reset {
+		return fmt.Errorf("partition [%d] of topic [%s] does not exist", partition, topic)
+	}
+
+	return nil
+}
+
+// TopicLagSnapshot is the point-in-time lag and throughput of a topic, see `GetTopicLagSnapshots`.
+type TopicLagSnapshot struct {
+	// Timestamp is the time of the snapshot, in unix milliseconds.
+	Timestamp         int64  `json:"timestamp" yaml:"timestamp" header:"Timestamp,timestamp(ms|utc|02 Jan 2006 15:04:05)"`
+	Topic             string `json:"topic" yaml:"topic" header:"Topic"`
+	ConsumerGroups    int    `json:"consumerGroups" yaml:"consumerGroups" header:"Groups"`
+	TotalLag          int64  `json:"totalLag" yaml:"totalLag" header:"Total Lag"`
+	MaxLag            int64  `json:"maxLag" yaml:"maxLag" header:"Max Lag"`
+	MessagesPerSecond int64  `json:"messagesPerSecond" yaml:"messagesPerSecond" header:"msg/sec"`
+	TotalMessages     int64  `json:"totalMessages" yaml:"totalMessages" header:"Total Msg"`
+}
+
+// lagSnapshotRequestsLimit is the maximum number of concurrent requests of `GetTopicLagSnapshots`.
+const lagSnapshotRequestsLimit = 8
+
+// GetTopicLagSnapshots returns a snapshot of the lag of all the consumer groups and of the throughput of each of the "topics",
+// in the given order and with the same timestamp, i.e to be appended to a CSV file on every run to graph the lag over time.
+// The TotalLag is the sum of the lag of every partition of the topic for every consumer group, the MaxLag is the highest of them.
+func (c *Client) GetTopicLagSnapshots(topics []string) ([]TopicLagSnapshot, error) {
+	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
+
+	fetched := make([]Topic, len(topics))
+	err := forEachConcurrently(len(topics), lagSnapshotRequestsLimit, func(i int) (err error) {
+		fetched[i], err = c.GetTopic(topics[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var groupIDs []string
+	seen := make(map[string]bool)
+	for _, topic := range fetched {
+		for _, group := range topic.ConsumersGroup {
+			if !seen[group.ID] {
+				seen[group.ID] = true
+				groupIDs = append(groupIDs, group.ID)
+			}
+		}
+	}
+
+	groups := make([]ConsumerGroupDetail, len(groupIDs))
+	err = forEachConcurrently(len(groupIDs), lagSnapshotRequestsLimit, func(i int) (err error) {
+		groups[i], err = c.GetConsumerGroupDetail(groupIDs[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]TopicLagSnapshot, len(fetched))
+	for i, topic := range fetched {
+		snapshot := TopicLagSnapshot{
+			Timestamp:         timestamp,
+			Topic:             topic.TopicName,
+			ConsumerGroups:    len(topic.ConsumersGroup),
+			MessagesPerSecond: topic.MessagesPerSecond,
+			TotalMessages:     topic.TotalMessages,
+		}
+
+		if snapshot.Topic == "" {
+			snapshot.Topic = topics[i]
+		}
+
+		for _, group := range groups {
+			for _, partition := range group.Partitions {
+				if partition.Topic != snapshot.Topic {
+					continue
+				}
+
+				snapshot.TotalLag += partition.Lag
+				if partition.Lag > snapshot.MaxLag {
+					snapshot.MaxLag = partition.Lag
+				}
+			}
+		}
+
+		snapshots[i] = snapshot
+	}
+
+	return snapshots, nil
+}
@@ -94,3 +94,177 @@ func (c *Client) UpdateDatasetTags(connection, name string, tags []string) (err
 	defer resp.Body.Close()
 	return nil
 }
+
+// ClearDatasetDescription removes the description of a dataset, if any.
+func (c *Client) ClearDatasetDescription(connection, name string) error {
+	// an empty description is omitted from the payload, which unsets it.
+	return c.UpdateDatasetDescription(connection, name, "")
+}
+
+// getDatasetTags returns the tag names of a dataset of any type.
+func (c *Client) getDatasetTags(connection, name string) ([]string, error) {
+	path := fmt.Sprintf("api/%s/%s/%s", pkg.DatasetsAPIPath, connection, name)
+	resp, err := c.Do(http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataset struct {
+		Tags []DatasetTag `json:"tags"`
+	}
+	if err = c.ReadJSON(resp, &dataset); err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(dataset.Tags))
+	for _, tag := range dataset.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return tags, nil
+}
+
+// RemoveDatasetTags removes the given "tags" from a dataset and keeps the rest of its tags.
+// Tags that the dataset does not have are ignored, if none of the "tags" is set then the dataset is not updated.
+func (c *Client) RemoveDatasetTags(connection, name string, tags []string) error {
+	if len(strings.TrimSpace(connection)) == 0 {
+		return errors.New("Required argument --connection not given or blank")
+	}
+
+	if len(strings.TrimSpace(name)) == 0 {
+		return errors.New("Required argument --name not given or blank")
+	}
+
+	current, err := c.getDatasetTags(connection, name)
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		remove[tag] = true
+	}
+
+	kept := make([]string, 0, len(current))
+	for _, tag := range current {
+		if !remove[tag] {
+			kept = append(kept, tag)
+		}
+	}
+
+	if len(kept) == len(current) {
+		return nil
+	}
+
+	return c.UpdateDatasetTags(connection, name, kept)
+}
+
+// DatasetQuery contains the filters of `GetDatasets`, all of them are optional.
+type DatasetQuery struct {
+	// Connections to list the datasets of, all connections when empty.
+	Connections []string
+	// NamePrefix lists only the datasets whose name starts with it.
+	NamePrefix string
+	// Tags lists only the datasets with any of these tags.
+	Tags []string
+	// Max is the maximum number of datasets to return, all of them when zero.
+	Max int
+	// PageSize is the number of datasets fetched per request, defaults to 50.
+	PageSize int
+}
+
+// Dataset is the summary of a dataset of any connection, see `GetDatasets`.
+type Dataset struct {
+	Name        string   `json:"name" yaml:"name" header:"Name"`
+	Connection  string   `json:"connection" yaml:"connection" header:"Connection"`
+	Format      string   `json:"format,omitempty" yaml:"format,omitempty" header:"Format"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty" header:"Description"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty" header:"Tags"`
+}
+
+// NewDataset returns the summary of a "match" of `ListDatasets`.
+// The format is the value type of a Kafka topic and the schema format of a Schema Registry subject.
+func NewDataset(match DatasetMatch) (Dataset, error) {
+	var (
+		ds          Dataset
+		description *string
+		tags        []DatasetTag
+	)
+
+	switch v := match.(type) {
+	case Elastic:
+		ds = Dataset{Name: v.Name, Connection: v.ConnectionName}
+		description, tags = v.Description, v.Tags
+	case Kafka:
+		ds = Dataset{Name: v.Name, Connection: v.ConnectionName, Format: v.ValueType}
+		description, tags = v.Description, v.Tags
+	case Postgres:
+		ds = Dataset{Name: v.Name, Connection: v.ConnectionName}
+		description, tags = v.Description, v.Tags
+	case SchemaRegistrySubject:
+		ds = Dataset{Name: v.Name, Connection: v.ConnectionName, Format: v.Format}
+		description, tags = v.Description, v.Tags
+	default:
+		return Dataset{}, fmt.Errorf("unknown dataset type: %T", match)
+	}
+
+	if description != nil {
+		ds.Description = *description
+	}
+
+	for _, tag := range tags {
+		ds.Tags = append(ds.Tags, tag.Name)
+	}
+
+	return ds, nil
+}
+
+// GetDatasets returns the datasets of all or some connections filtered by the "opts",
+// it walks through all the pages of `ListDatasets` until "opts.Max" datasets are found.
+func (c *Client) GetDatasets(opts DatasetQuery) ([]Dataset, error) {
+	params := ListDatasetsParameters{
+		PageSize:    opts.PageSize,
+		Connections: opts.Connections,
+		Tags:        opts.Tags,
+	}
+
+	if params.PageSize <= 0 {
+		params.PageSize = 50
+	}
+
+	if opts.NamePrefix != "" {
+		// narrow the search down to the names, the prefix is checked below.
+		params.Query = &opts.NamePrefix
+		includeMetadata := false
+		params.IncludeMetadata = &includeMetadata
+	}
+
+	datasets := make([]Dataset, 0)
+	for page := 1; ; page++ {
+		params.Page = &page
+		res, err := c.ListDatasets(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range res.Datasets.Values {
+			ds, err := NewDataset(match)
+			if err != nil {
+				return nil, err
+			}
+
+			if !strings.HasPrefix(ds.Name, opts.NamePrefix) {
+				continue
+			}
+
+			datasets = append(datasets, ds)
+			if opts.Max > 0 && len(datasets) == opts.Max {
+				return datasets, nil
+			}
+		}
+
+		if page >= res.Datasets.PagesAmount {
+			return datasets, nil
+		}
+	}
+}
@@ -124,3 +124,48 @@ func (c *Client) CloneGroup(currentName string, newName string) error {
 	}
 	return nil
 }
+
+// GroupPermissions describes the data access and the permissions a group grants,
+// see `GetGroupPermissions`.
+type GroupPermissions struct {
+	Name                       string      `json:"name" yaml:"name" header:"Name"`
+	Description                string      `json:"description,omitempty" yaml:"description,omitempty" header:"Description"`
+	Namespaces                 []Namespace `json:"namespaces" yaml:"dataNamespaces" header:"Namespaces,count"`
+	ApplicationPermissions     []string    `json:"applicationPermissions" yaml:"applicationPermissions" header:"Application Permissions"`
+	AdminPermissions           []string    `json:"adminPermissions" yaml:"adminPermissions" header:"Admin Permissions"`
+	ConnectClustersPermissions []string    `json:"connectClustersPermissions" yaml:"connectClustersPermissions" header:"Connect clusters access"`
+}
+
+// NewGroupPermissions returns the permissions the "group" grants.
+func NewGroupPermissions(group Group) GroupPermissions {
+	return GroupPermissions{
+		Name:                       group.Name,
+		Description:                group.Description,
+		Namespaces:                 group.Namespaces,
+		ApplicationPermissions:     group.ScopedPermissions,
+		AdminPermissions:           group.AdminPermissions,
+		ConnectClustersPermissions: group.ConnectClustersPermissions,
+	}
+}
+
+// AsGroup returns the group payload to create or update a group which grants these permissions.
+func (p GroupPermissions) AsGroup() Group {
+	return Group{
+		Name:                       p.Name,
+		Description:                p.Description,
+		Namespaces:                 p.Namespaces,
+		ScopedPermissions:          p.ApplicationPermissions,
+		AdminPermissions:           p.AdminPermissions,
+		ConnectClustersPermissions: p.ConnectClustersPermissions,
+	}
+}
+
+// GetGroupPermissions returns the data namespaces, application, admin and connect clusters permissions the "group" grants.
+func (c *Client) GetGroupPermissions(group string) (GroupPermissions, error) {
+	g, err := c.GetGroup(group)
+	if err != nil {
+		return GroupPermissions{}, err
+	}
+
+	return NewGroupPermissions(g), nil
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrIteratorDone is returned by `Iterator.Next` when all the items have been consumed.
+var ErrIteratorDone = errors.New("no more items")
+
+// PageFetcher fetches a page of a paginated endpoint, pages start from 1.
+// It reports whether there are more pages after the fetched one.
+type PageFetcher[T any] func(ctx context.Context, page int) (items []T, more bool, err error)
+
+// Iterator walks over all the items of a paginated endpoint,
+// the next page is fetched only when the items of the current one are consumed.
+//
+// Usage:
+//
+//	it := client.IterateAuditEntries(api.AuditEntryQuery{})
+//	for {
+//		entry, err := it.Next(ctx)
+//		if err == api.ErrIteratorDone {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		[...]
+//	}
+type Iterator[T any] struct {
+	fetch PageFetcher[T]
+	page  int
+	items []T
+	more  bool
+	err   error
+}
+
+// NewIterator returns an `Iterator` which fetches its pages through "fetch".
+func NewIterator[T any](fetch PageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, more: true}
+}
+
+// Next returns the next item, it fetches the following page when needed.
+// It returns `ErrIteratorDone` when there are no more items,
+// a failed page fetch is returned by all the following calls.
+func (it *Iterator[T]) Next(ctx context.Context) (T, error) {
+	var item T
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for len(it.items) == 0 {
+		if it.err != nil {
+			return item, it.err
+		}
+
+		if !it.more {
+			return item, ErrIteratorDone
+		}
+
+		if err := ctx.Err(); err != nil {
+			return item, err
+		}
+
+		it.page++
+		it.items, it.more, it.err = it.fetch(ctx, it.page)
+	}
+
+	item = it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// Collect drains the iterator and returns all its remaining items.
+func (it *Iterator[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+
+	for {
+		item, err := it.Next(ctx)
+		if err == ErrIteratorDone {
+			return items, nil
+		}
+
+		if err != nil {
+			return items, err
+		}
+
+		items = append(items, item)
+	}
+}
+
+// pageResult is the common response of the paginated endpoints.
+type pageResult struct {
+	Values      json.RawMessage `json:"values"`
+	PagesAmount int             `json:"pagesAmount"`
+}
+
+// pageFetcher returns a `PageFetcher` of the paginated endpoint at "path" which retrieves "pageSize" items per page.
+//
+// When the server reports the number of pages, there are more pages until the last one.
+// Otherwise a full page means that there may be more, until a page repeats the previous one,
+// so an endpoint which ignores the "page" parameter does not loop forever.
+func pageFetcher[T any](c *Client, path string, params url.Values, pageSize int) PageFetcher[T] {
+	var previous []byte
+
+	return func(ctx context.Context, page int) ([]T, bool, error) {
+		query := url.Values{}
+		for key, values := range params {
+			query[key] = values
+		}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("pageSize", strconv.Itoa(pageSize))
+
+		resp, err := c.Do(http.MethodGet, path+"?"+query.Encode(), "", nil, requestContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+
+		var result pageResult
+		if err = c.ReadJSON(resp, &result); err != nil {
+			return nil, false, err
+		}
+
+		var values []T
+		if len(result.Values) > 0 {
+			if err = json.Unmarshal(result.Values, &values); err != nil {
+				return nil, false, err
+			}
+		}
+
+		if len(values) == 0 {
+			return nil, false, nil
+		}
+
+		if result.PagesAmount > 0 {
+			return values, page < result.PagesAmount, nil
+		}
+
+		if previous != nil && bytes.Equal(previous, result.Values) {
+			return nil, false, nil
+		}
+		previous = result.Values
+
+		return values, len(values) >= pageSize, nil
+	}
+}
+
+// requestContext attaches "ctx" to the request.
+func requestContext(ctx context.Context) RequestOption {
+	return func(r *http.Request) error {
+		*r = *r.WithContext(ctx)
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	pages := [][]int{{1, 2}, {}, {3}}
+	var fetched []int
+
+	it := NewIterator(func(ctx context.Context, page int) ([]int, bool, error) {
+		fetched = append(fetched, page)
+		return pages[page-1], page < len(pages), nil
+	})
+
+	items, err := it.Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(items) != "[1 2 3]" || fmt.Sprint(fetched) != "[1 2 3]" {
+		t.Errorf("got items `%v` from pages `%v`, want all the items of all the pages", items, fetched)
+	}
+
+	if _, err = it.Next(context.Background()); err != ErrIteratorDone {
+		t.Errorf("got `%v`, want `%v`", err, ErrIteratorDone)
+	}
+
+	failing := NewIterator(func(ctx context.Context, page int) ([]int, bool, error) {
+		if page == 2 {
+			return nil, false, fmt.Errorf("page %d failed", page)
+		}
+		return []int{page}, true, nil
+	})
+
+	if items, err = failing.Collect(context.Background()); err == nil || len(items) != 1 {
+		t.Errorf("got `%v`, `%v`, want the first page and the error of the second", items, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = NewIterator(func(ctx context.Context, page int) ([]int, bool, error) {
+		t.Error("unexpected fetch with a canceled context")
+		return nil, false, nil
+	}).Next(ctx); err != context.Canceled {
+		t.Errorf("got `%v`, want `%v`", err, context.Canceled)
+	}
+}
+
+func TestIterateAuditEntries(t *testing.T) {
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		body := `{"values":[],"pagesAmount":2}`
+		switch r.URL.Query().Get("page") {
+		case "1":
+			body = `{"values":[{"type":"TOPIC","user":"admin"},{"type":"ACL","user":"admin"}],"pagesAmount":2}`
+		case "2":
+			body = `{"values":[{"type":"TOPIC","user":"john"}],"pagesAmount":2}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+
+	client, err := OpenConnection(ClientConfig{Host: "http://domain.com", Token: "secret"}, UsingTransport(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := client.GetAuditEntriesFiltered(AuditEntryQuery{Type: AuditEntryTopic, PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 || entries[0].User != "admin" || entries[1].User != "john" {
+		t.Errorf("got `%v`, want the topic entries of both pages", entries)
+	}
+}
+
+func TestIterateAuditEntriesIgnoredPage(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 3 {
+			t.Fatal("the iterator did not stop on a repeated page")
+		}
+
+		// no pagesAmount and a full page, whatever the page parameter is.
+		w.Write([]byte(`{"values":[{"type":"TOPIC","user":"admin"},{"type":"TOPIC","user":"john"}]}`))
+	})
+
+	entries, err := client.GetAuditEntriesFiltered(AuditEntryQuery{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 || requests != 2 {
+		t.Errorf("got `%v` after [%d] requests, want the entries of the first page after 2 requests", entries, requests)
+	}
+}
@@ -13,7 +13,7 @@ import (
 // ConnectionOption describes an optional runtime configurator that can be passed on `OpenConnection`.
 // Custom `ConnectionOption` can be used as well, it's just a type of `func(*lenses.Client)`.
 //
-// Look `UsingClient` and `UsingToken` for use-cases.
+// Look `UsingClient`, `UsingTransport` and `UsingToken` for use-cases.
 type ConnectionOption func(*Client)
 
 func getTimeout(httpClient *http.Client, timeoutStr string) time.Duration {
@@ -66,6 +66,44 @@ func UsingClient(httpClient *http.Client) ConnectionOption {
 	}
 }
 
+// UsingTransport sets a custom `http.RoundTripper` to the underline HTTP Client,
+// i.e for tracing, retries or test stubs, without replacing the whole client like the `UsingClient` does.
+// The rest of the client's settings are kept, the "rt" is responsible for its own TLS (`Insecure`) and dial timeout settings.
+//
+// Note that the client requests gzip content explicitly and decompresses the responses itself,
+// so the "rt" receives the compressed body as it is, it should not modify the body
+// without removing the "Content-Encoding" response header as well.
+//
+// When used with `UsingClient`, pass the `UsingTransport` after it, otherwise the `UsingClient` replaces it.
+func UsingTransport(rt http.RoundTripper) ConnectionOption {
+	return func(c *Client) {
+		if rt == nil {
+			return
+		}
+
+		if c.client == nil {
+			UsingClient(&http.Client{Transport: rt})(c)
+			return
+		}
+
+		c.client.Transport = rt
+	}
+}
+
+// UsingSchemaIDCache keeps up to "size" schemas, the least recently used are evicted first,
+// so `GetSchemaByID` and `GetSchemasByIDs` resolve an id once, i.e while decoding many records of the same schemas.
+// Schemas are immutable by id so the cached ones never expire. A non positive "size" disables the cache.
+func UsingSchemaIDCache(size int) ConnectionOption {
+	return func(c *Client) {
+		if size <= 0 {
+			c.schemaIDCache = nil
+			return
+		}
+
+		c.schemaIDCache = newSchemaIDCache(size)
+	}
+}
+
 // UsingToken can specify a custom token that can by-pass the "user" and "password".
 // It may be useful for testing purposes.
 func UsingToken(tok string) ConnectionOption {
@@ -99,7 +137,7 @@ func WithContext(contextName string) ConnectionOption {
 // Usage:
 // auth := lenses.BasicAuthentication{Username: "user", Password: "pass"}
 // config := lenses.ClientConfig{Host: "domain.com", Authentication: auth, Timeout: "15s"}
-// client, err := lenses.OpenConnection(config) // or (config, lenses.UsingClient/UsingToken)
+// client, err := lenses.OpenConnection(config) // or (config, lenses.UsingClient/UsingTransport/UsingToken)
 // if err != nil { panic(err) }
 // client.DeleteTopic("topicName")
 //
@@ -115,13 +153,19 @@ func OpenConnection(cfg ClientConfig, options ...ConnectionOption) (*Client, err
 		},
 	}
 
-	c := &Client{configFull: full, Config: clientConfig}
+	c := &Client{configFull: full, Config: clientConfig, activeHost: new(int32), responseCache: newResponseCache()}
 	for _, opt := range options {
 		opt(c)
 	}
 
-	if !clientConfig.IsValid() {
-		return nil, fmt.Errorf("invalid configuration: Token or Authentication missing")
+	if err := clientConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	clientConfig.FormatHost()
+
+	if ttl, _ := time.ParseDuration(clientConfig.SchemaCacheTTL); ttl > 0 {
+		c.schemaCache = newSchemaTTLCache(ttl)
 	}
 
 	// if client is not set-ed by any option, set it to a new one,
@@ -140,7 +184,7 @@ func OpenConnection(cfg ClientConfig, options ...ConnectionOption) (*Client, err
 	if clientConfig.Token != "" {
 		golog.Debugf("Connecting using just the token: [%s]", clientConfig.Token)
 		// User will be empty but it does its job.
-		return c, nil
+		return verifyConnection(c)
 	}
 
 	if clientConfig.Authentication == nil {
@@ -158,5 +202,25 @@ func OpenConnection(cfg ClientConfig, options ...ConnectionOption) (*Client, err
 	golog.Debugf("Connected on [%s] with token: [%s]\nUser details: [%#+v]",
 		c.Config.Host, c.User.Token, c.User)
 
+	return verifyConnection(c)
+}
+
+// verifyConnection returns the "c" client as it is, unless the `ClientConfig#VerifyOnConnect` is true,
+// then it fails if the box does not respond to the license call or the license has expired.
+func verifyConnection(c *Client) (*Client, error) {
+	if !c.Config.VerifyOnConnect {
+		return c, nil
+	}
+
+	lc, err := c.GetLicenseInfo()
+	if err != nil {
+		return nil, fmt.Errorf("client: verify failure: box [%s] is not reachable: [%v]", c.Config.Host, err)
+	}
+
+	if lc.Expiry > 0 && lc.ExpiresWithin(0) {
+		return nil, fmt.Errorf("client: verify failure: license of [%s] has expired at [%s]",
+			c.Config.Host, lc.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
 	return c, nil
 }
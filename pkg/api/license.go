@@ -32,6 +32,18 @@ type LicenseInfo struct {
 	DaysToExpire int `json:"daysToExpire,omitempty"`
 }
 
+// ExpiresWithin reports whether the license expires, or has already expired, within "d" from now.
+// It uses the exact expiration datetime and not the YearsToExpire, MonthsToExpire and DaysToExpire
+// fields as only the biggest unit of them is filled.
+func (lc LicenseInfo) ExpiresWithin(d time.Duration) bool {
+	expiresAt := lc.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = time.Unix(lc.Expiry/1000, 0)
+	}
+
+	return time.Until(expiresAt) <= d
+}
+
 // License is the JSON payload for updating a license.
 type License struct {
 	Source   string `json:"source"`
@@ -1,10 +1,16 @@
 package api
 
 import (
+	"container/list"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/kataras/golog"
 	"github.com/pkg/errors"
 )
 
@@ -86,6 +92,10 @@ func (c *Client) GetSchema(name string) (response GetSchemaRes, err error) {
 		return
 	}
 
+	if cached, ok := c.schemaCache.get(schemaCacheKey(name)); ok {
+		return cached.(GetSchemaRes), nil
+	}
+
 	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
@@ -98,13 +108,15 @@ func (c *Client) GetSchema(name string) (response GetSchemaRes, err error) {
 		return
 	}
 
+	c.schemaCache.put(schemaCacheKey(name), response)
 	return
 }
 
 // WriteSchemaReq Struct
 type WriteSchemaReq struct {
-	Format string `json:"format"`
-	Schema string `json:"schema"`
+	Format     string            `json:"format"`
+	Schema     string            `json:"schema"`
+	References []SchemaReference `json:"references,omitempty"`
 }
 
 // WriteSchema creates a schema if it doens't exist, updates it otherwise
@@ -134,9 +146,148 @@ func (c *Client) WriteSchema(name string, request WriteSchemaReq) (err error) {
 		return err
 	}
 	defer resp.Body.Close()
+
+	// the latest version changed, do not serve the cached one.
+	c.schemaCache.delete(schemaCacheKey(name))
+
 	return
 }
 
+// SchemaAsRequest is a subject's schema to register, see `RegisterSchemas`.
+type SchemaAsRequest struct {
+	Subject string `json:"subject" yaml:"subject"`
+	WriteSchemaReq
+}
+
+// orderSchemas sorts the "schemas" so that every schema comes after the schemas of the subjects it references,
+// schemas without a reference between them keep their given order.
+// References to subjects which are not part of the "schemas" are expected to be registered already.
+func orderSchemas(schemas []SchemaAsRequest) ([]SchemaAsRequest, error) {
+	pending := make(map[string]int, len(schemas))
+	for _, schema := range schemas {
+		if schema.Subject == "" {
+			return nil, errRequired("subject")
+		}
+
+		if _, ok := pending[schema.Subject]; ok {
+			return nil, fmt.Errorf("subject [%s] is given more than once", schema.Subject)
+		}
+		pending[schema.Subject] = 0
+	}
+
+	for _, schema := range schemas {
+		for _, ref := range schema.References {
+			if _, ok := pending[ref.SubjectName]; ok {
+				pending[schema.Subject]++
+			}
+		}
+	}
+
+	ordered := make([]SchemaAsRequest, 0, len(schemas))
+	registered := make(map[string]bool, len(schemas))
+
+	for len(ordered) < len(schemas) {
+		progressed := false
+
+		for _, schema := range schemas {
+			if registered[schema.Subject] || pending[schema.Subject] > 0 {
+				continue
+			}
+
+			ordered = append(ordered, schema)
+			registered[schema.Subject] = true
+			progressed = true
+
+			for _, dependent := range schemas {
+				for _, ref := range dependent.References {
+					if ref.SubjectName == schema.Subject {
+						pending[dependent.Subject]--
+					}
+				}
+			}
+			// start over so the given order is kept between the schemas that became ready.
+			break
+		}
+
+		if !progressed {
+			var unresolved []string
+			for _, schema := range schemas {
+				if !registered[schema.Subject] {
+					unresolved = append(unresolved, schema.Subject)
+				}
+			}
+			return nil, fmt.Errorf("subjects [%s] have cyclic references", strings.Join(unresolved, ", "))
+		}
+	}
+
+	return ordered, nil
+}
+
+// RegisterSchemas registers the "schemas" after the subjects they reference, i.e. a set of Protobuf schemas which import each other,
+// and returns the schema id of each registered subject.
+// On failure it returns the ids of the subjects registered so far and an error which reports the failed subject.
+func (c *Client) RegisterSchemas(schemas []SchemaAsRequest) (map[string]int, error) {
+	ordered, err := orderSchemas(schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int, len(ordered))
+	for _, schema := range ordered {
+		if err = c.WriteSchema(schema.Subject, schema.WriteSchemaReq); err != nil {
+			return ids, fmt.Errorf("register schema of subject [%s]: %w", schema.Subject, err)
+		}
+
+		registered, err := c.GetSchema(schema.Subject)
+		if err != nil {
+			return ids, fmt.Errorf("register schema of subject [%s]: %w", schema.Subject, err)
+		}
+
+		id, err := strconv.Atoi(registered.SchemaID)
+		if err != nil {
+			return ids, fmt.Errorf("register schema of subject [%s]: invalid schema id [%s]", schema.Subject, registered.SchemaID)
+		}
+
+		ids[schema.Subject] = id
+	}
+
+	return ids, nil
+}
+
+// LookupSchema reports whether the latest version of the "name" subject is the same schema as the "request" one,
+// AVRO and JSON schemas are compared by their `NormalizeAvroSchema` form so formatting differences do not count.
+// It returns false if the subject is not registered.
+func (c *Client) LookupSchema(name string, request WriteSchemaReq) (bool, error) {
+	current, err := c.GetSchema(name)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !strings.EqualFold(current.Format, request.Format) {
+		return false, nil
+	}
+
+	return sameSchema(current.Format, current.Schema, request.Schema), nil
+}
+
+// sameSchema reports whether the "a" and "b" schemas of the "format" are the same,
+// schemas which cannot be normalized are compared as they are.
+func sameSchema(format, a, b string) bool {
+	switch strings.ToUpper(format) {
+	case "AVRO", "JSON":
+		normalizedA, errA := NormalizeAvroSchema(a)
+		normalizedB, errB := NormalizeAvroSchema(b)
+		if errA == nil && errB == nil {
+			return normalizedA == normalizedB
+		}
+	}
+
+	return strings.TrimSpace(a) == strings.TrimSpace(b)
+}
+
 // SetSchemaCompatibilityReq Struct
 type SetSchemaCompatibilityReq struct {
 	Compatibility string `json:"compatibility"`
@@ -170,6 +321,26 @@ func (c *Client) SetSchemaCompatibility(name string, request SetSchemaCompatibil
 	return
 }
 
+// DeleteSubjectCompatibilityLevel removes the compatibility override of a subject,
+// the subject falls back to the global compatibility level afterwards.
+func (c *Client) DeleteSubjectCompatibilityLevel(subject string) (err error) {
+	const basePath = "api/v1/sr/default/subject"
+	path := fmt.Sprintf("%s/%s/config", basePath, subject)
+
+	if subject == "" {
+		return fmt.Errorf("subject is required")
+	}
+
+	resp, err := c.Do(http.MethodDelete, path, contentTypeJSON, nil)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return
+}
+
 // SetGlobalCompatibilityReq Struct
 type SetGlobalCompatibilityReq struct {
 	Compatibility string `json:"compatibility"`
@@ -198,6 +369,93 @@ func (c *Client) SetGlobalCompatibility(request SetGlobalCompatibilityReq) (err
 	return
 }
 
+// The valid schema registry modes, see `SetSchemaRegistryMode`.
+const (
+	SchemaModeReadWrite = "READWRITE"
+	SchemaModeReadOnly  = "READONLY"
+	// SchemaModeImport allows registering schemas with given ids, i.e while migrating schemas between registries.
+	SchemaModeImport = "IMPORT"
+)
+
+// SchemaModes are the valid schema registry modes.
+var SchemaModes = []string{SchemaModeReadWrite, SchemaModeReadOnly, SchemaModeImport}
+
+// SchemaModeReq is the payload of the schema registry mode calls.
+type SchemaModeReq struct {
+	Mode string `json:"mode"`
+}
+
+// validateSchemaMode returns the "mode" in upper case or an error if it is not one of the `SchemaModes`.
+func validateSchemaMode(mode string) (string, error) {
+	upper := strings.ToUpper(strings.TrimSpace(mode))
+	for _, valid := range SchemaModes {
+		if upper == valid {
+			return upper, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown mode [%s], available modes are: [%s]", mode, strings.Join(SchemaModes, ", "))
+}
+
+func (c *Client) getSchemaMode(path string) (string, error) {
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var res SchemaModeReq
+	err = c.ReadJSON(resp, &res)
+	return res.Mode, err
+}
+
+func (c *Client) setSchemaMode(path, mode string) error {
+	mode, err := validateSchemaMode(mode)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(SchemaModeReq{Mode: mode})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// GetSchemaRegistryMode returns the global mode of the schema registry, one of the `SchemaModes`.
+func (c *Client) GetSchemaRegistryMode() (string, error) {
+	return c.getSchemaMode("api/v1/sr/default/mode")
+}
+
+// SetSchemaRegistryMode sets the global mode of the schema registry, one of the `SchemaModes`.
+// A registry in the READONLY mode rejects any write, one in the IMPORT mode keeps the ids of the imported schemas.
+func (c *Client) SetSchemaRegistryMode(mode string) error {
+	return c.setSchemaMode("api/v1/sr/default/mode", mode)
+}
+
+// GetSubjectMode returns the mode of a subject, one of the `SchemaModes`.
+func (c *Client) GetSubjectMode(subject string) (string, error) {
+	if subject == "" {
+		return "", fmt.Errorf("subject is required")
+	}
+
+	return c.getSchemaMode(fmt.Sprintf("api/v1/sr/default/subject/%s/mode", subject))
+}
+
+// SetSubjectMode sets the mode of a subject, one of the `SchemaModes`, overriding the global one.
+func (c *Client) SetSubjectMode(subject, mode string) error {
+	if subject == "" {
+		return fmt.Errorf("subject is required")
+	}
+
+	return c.setSchemaMode(fmt.Sprintf("api/v1/sr/default/subject/%s/mode", subject), mode)
+}
+
 // RemoveSchemaVersion removes a particular schema version
 func (c *Client) RemoveSchemaVersion(name string, version string) (err error) {
 	const basePath = "api/v1/sr/default/subject"
@@ -215,12 +473,176 @@ func (c *Client) RemoveSchemaVersion(name string, version string) (err error) {
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
+
+	// the latest version changed, do not serve the cached one.
+	c.schemaCache.delete(schemaCacheKey(name))
+
+	return
+}
+
+// SchemaVersionReferencedError is returned by `RemoveSchemaVersionSafe`
+// when other schemas reference the version to remove.
+type SchemaVersionReferencedError struct {
+	Subject      string
+	Version      string
+	ReferencedBy []int
+}
+
+// Error implements the error interface.
+func (e *SchemaVersionReferencedError) Error() string {
+	return fmt.Sprintf("schema [%s] version [%s] is referenced by schema ids %v", e.Subject, e.Version, e.ReferencedBy)
+}
+
+// RemoveSchemaVersionSafe removes a particular schema version like the `RemoveSchemaVersion`
+// but it refuses to, with a `SchemaVersionReferencedError`, if other schemas reference the version.
+// Registries without references support, which do not serve the lookup, are not checked.
+func (c *Client) RemoveSchemaVersionSafe(name string, version string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if version == "" {
+		return fmt.Errorf("version is required")
+	}
+
+	ids, err := c.getSchemaReferencedBy(name, version)
+	if err != nil {
+		if !isNotFound(err) {
+			return err
+		}
+
+		golog.Debugf("Client#RemoveSchemaVersionSafe: references of schema [%s] version [%s] are not served, removing without the check", name, version)
+	} else if len(ids) > 0 {
+		return &SchemaVersionReferencedError{Subject: name, Version: version, ReferencedBy: ids}
+	}
+
+	return c.RemoveSchemaVersion(name, version)
+}
+
+// GetSchemaReferencedBy returns the ids of the schemas that reference
+// a particular version of a subject, i.e. Avro or Protobuf schemas importing it.
+func (c *Client) GetSchemaReferencedBy(subject string, version int) (ids []int, err error) {
+	return c.getSchemaReferencedBy(subject, strconv.Itoa(version))
+}
+
+// getSchemaReferencedBy is the `GetSchemaReferencedBy` for a version as given to the registry, i.e. "latest".
+func (c *Client) getSchemaReferencedBy(subject string, version string) (ids []int, err error) {
+	const basePath = "api/v1/sr/default/subject"
+	path := fmt.Sprintf("%s/%s/version/%s/referencedby", basePath, subject, version)
+
+	if subject == "" {
+		err = fmt.Errorf("subject is required")
+		return
+	}
+
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	if err != nil {
+		return
+	}
 
 	defer resp.Body.Close()
 
+	err = c.ReadJSON(resp, &ids)
+	return
+}
+
+// SchemaVersionInfo describes a registered version of a subject, see `GetSubjectVersionsDetailed`.
+// The RegisteredAt (unix milliseconds) and RegisteredBy are zero if the registry does not provide them.
+type SchemaVersionInfo struct {
+	Version      int    `json:"version" yaml:"version" header:"Version"`
+	ID           int    `json:"id" yaml:"id" header:"ID,text"`
+	RegisteredAt int64  `json:"registeredAt,omitempty" yaml:"registeredAt,omitempty" header:"Registered At,timestamp(ms|utc|02 Jan 2006 15:04)"`
+	RegisteredBy string `json:"registeredBy,omitempty" yaml:"registeredBy,omitempty" header:"Registered By"`
+}
+
+// RegistrationTime returns the time the version was registered and true, or false if the registry did not provide it.
+func (v SchemaVersionInfo) RegistrationTime() (time.Time, bool) {
+	if v.RegisteredAt <= 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, v.RegisteredAt*int64(time.Millisecond)), true
+}
+
+// GetSubjectVersionsDetailed returns all the versions of a subject along with their schema id
+// and, if the registry provides it, when and by whom each version was registered.
+// Registries that list only the version numbers are asked for the schema id of each version.
+func (c *Client) GetSubjectVersionsDetailed(subject string) (versions []SchemaVersionInfo, err error) {
+	const basePath = "api/v1/sr/default/subject"
+	path := fmt.Sprintf("%s/%s/versions", basePath, subject)
+
+	if subject == "" {
+		err = fmt.Errorf("subject is required")
+		return
+	}
+
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	if err != nil {
+		return
+	}
+
+	var raw json.RawMessage
+	if err = c.ReadJSON(resp, &raw); err != nil {
+		return
+	}
+
+	if versions, err = parseSubjectVersions(raw); err != nil {
+		return
+	}
+
+	err = forEachConcurrently(len(versions), schemaRequestsLimit, func(i int) (err error) {
+		if versions[i].ID > 0 {
+			return nil
+		}
+
+		versions[i].ID, err = c.getSubjectVersionID(subject, versions[i].Version)
+		return
+	})
 	return
 }
 
+// getSubjectVersionID returns the schema id of a version of a subject.
+func (c *Client) getSubjectVersionID(subject string, version int) (int, error) {
+	const basePath = "api/v1/sr/default/subject"
+	path := fmt.Sprintf("%s/%s/versions/%d", basePath, subject, version)
+
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var schema struct {
+		ID int `json:"id"`
+	}
+	if err = c.ReadJSON(resp, &schema); err != nil {
+		return 0, err
+	}
+
+	return schema.ID, nil
+}
+
+// parseSubjectVersions decodes either a list of version objects or a plain list of version numbers,
+// the ID of the latter is left zero, see `GetSubjectVersionsDetailed`.
+func parseSubjectVersions(raw json.RawMessage) ([]SchemaVersionInfo, error) {
+	var versions []SchemaVersionInfo
+	if err := json.Unmarshal(raw, &versions); err == nil {
+		return versions, nil
+	}
+
+	var numbers []int
+	if err := json.Unmarshal(raw, &numbers); err != nil {
+		return nil, fmt.Errorf("unexpected subject versions response: %v", err)
+	}
+
+	versions = make([]SchemaVersionInfo, len(numbers))
+	for i, number := range numbers {
+		versions[i].Version = number
+	}
+
+	return versions, nil
+}
+
 // RemoveSchema removes the schema and all its versions
 func (c *Client) RemoveSchema(name string) (err error) {
 	const basePath = "api/v1/sr/default/subject"
@@ -236,5 +658,332 @@ func (c *Client) RemoveSchema(name string) (err error) {
 	}
 	defer resp.Body.Close()
 
+	// the latest version changed, do not serve the cached one.
+	c.schemaCache.delete(schemaCacheKey(name))
+
 	return
 }
+
+// TopicSchemaSubject describes a schema registry subject linked to a topic's key or value,
+// see `TopicSchemas`.
+type TopicSchemaSubject struct {
+	Subject  string `json:"subject" yaml:"subject" header:"Subject"`
+	Version  int    `json:"version" yaml:"version" header:"Version"`
+	SchemaID string `json:"schemaId" yaml:"schemaId" header:"Schema ID,text"`
+}
+
+// TopicSchemas contains the resolved key and value subjects of a topic,
+// a nil Key or Value means that there is no registered subject for it.
+type TopicSchemas struct {
+	Topic string              `json:"topic" yaml:"topic"`
+	Key   *TopicSchemaSubject `json:"key,omitempty" yaml:"key,omitempty"`
+	Value *TopicSchemaSubject `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// topicSubjectsMapping is the response of the topic to subjects linkage endpoint.
+type topicSubjectsMapping struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// GetTopicSchemas returns the key and value subjects of a topic along with their latest version and schema id.
+// The subject names are resolved by the server when it exposes the topic linkage,
+// otherwise it falls back to the default TopicNameStrategy, i.e. `<topic>-key` and `<topic>-value`.
+func (c *Client) GetTopicSchemas(topicName string) (schemas TopicSchemas, err error) {
+	const basePath = "api/v1/sr/default/topic"
+	path := fmt.Sprintf("%s/%s/subjects", basePath, topicName)
+
+	if topicName == "" {
+		err = fmt.Errorf("topic name is required")
+		return
+	}
+
+	schemas.Topic = topicName
+
+	mapping := topicSubjectsMapping{
+		Key:   topicName + "-key",
+		Value: topicName + "-value",
+	}
+
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	if err != nil {
+		if !isNotFound(err) {
+			return
+		}
+	} else {
+		var serverMapping topicSubjectsMapping
+		if err = c.ReadJSON(resp, &serverMapping); err != nil {
+			return
+		}
+
+		if serverMapping.Key != "" || serverMapping.Value != "" {
+			mapping = serverMapping
+		}
+	}
+
+	if schemas.Key, err = c.getTopicSchemaSubject(mapping.Key); err != nil {
+		return
+	}
+
+	schemas.Value, err = c.getTopicSchemaSubject(mapping.Value)
+	return
+}
+
+// getTopicSchemaSubject returns the latest version of a subject, nil if the subject is not registered.
+func (c *Client) getTopicSchemaSubject(subject string) (*TopicSchemaSubject, error) {
+	if subject == "" {
+		return nil, nil
+	}
+
+	schema, err := c.GetSchema(subject)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &TopicSchemaSubject{
+		Subject:  subject,
+		Version:  schema.Version,
+		SchemaID: schema.SchemaID,
+	}, nil
+}
+
+// schemaIDCache is a least recently used cache of the schemas by their id, it is safe for concurrent use.
+type schemaIDCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of schema ids, the most recently used first.
+	entries map[int]*list.Element
+	schemas map[int]string
+}
+
+func newSchemaIDCache(size int) *schemaIDCache {
+	return &schemaIDCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[int]*list.Element, size),
+		schemas: make(map[int]string, size),
+	}
+}
+
+func (cache *schemaIDCache) get(id int) (string, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	elem, ok := cache.entries[id]
+	if !ok {
+		return "", false
+	}
+
+	cache.order.MoveToFront(elem)
+	return cache.schemas[id], true
+}
+
+func (cache *schemaIDCache) clear() {
+	cache.mu.Lock()
+	cache.order.Init()
+	cache.entries = make(map[int]*list.Element, cache.size)
+	cache.schemas = make(map[int]string, cache.size)
+	cache.mu.Unlock()
+}
+
+func (cache *schemaIDCache) put(id int, schema string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if elem, ok := cache.entries[id]; ok {
+		cache.order.MoveToFront(elem)
+		cache.schemas[id] = schema
+		return
+	}
+
+	cache.entries[id] = cache.order.PushFront(id)
+	cache.schemas[id] = schema
+
+	for cache.order.Len() > cache.size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(int))
+		delete(cache.schemas, oldest.Value.(int))
+	}
+}
+
+// schemaRequestsLimit is the maximum number of concurrent requests of the `GetSchemasByIDs`.
+const schemaRequestsLimit = 8
+
+// schemaByIDRes is the response of the schema registry for a schema id.
+type schemaByIDRes struct {
+	Schema string `json:"schema"`
+}
+
+// GetSchemaByID returns the schema registered with the "id",
+// it is served from the cache, if enabled by the `UsingSchemaIDCache` option or the `ClientConfig#SchemaCacheTTL`.
+func (c *Client) GetSchemaByID(id int) (string, error) {
+	if id <= 0 {
+		return "", fmt.Errorf("schema id should be a positive number")
+	}
+
+	if c.schemaIDCache != nil {
+		if schema, ok := c.schemaIDCache.get(id); ok {
+			return schema, nil
+		}
+	}
+
+	if cached, ok := c.schemaCache.get(schemaIDCacheKey(id)); ok {
+		return cached.(string), nil
+	}
+
+	resp, err := c.Do(http.MethodGet, fmt.Sprintf("api/v1/sr/default/schemas/ids/%d", id), contentTypeJSON, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var res schemaByIDRes
+	if err = c.ReadJSON(resp, &res); err != nil {
+		return "", err
+	}
+
+	if c.schemaIDCache != nil {
+		c.schemaIDCache.put(id, res.Schema)
+	}
+	c.schemaCache.put(schemaIDCacheKey(id), res.Schema)
+
+	return res.Schema, nil
+}
+
+// GetSchemasByIDs returns the schemas of the "ids" keyed by their id, i.e to decode a batch of records.
+// The ids are resolved once, a few at a time, and the cached ones are not requested at all, see `UsingSchemaIDCache`.
+// It fails if any of the ids cannot be resolved.
+func (c *Client) GetSchemasByIDs(ids []int) (map[int]string, error) {
+	var unique []int
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	schemas := make([]string, len(unique))
+	err := forEachConcurrently(len(unique), schemaRequestsLimit, func(i int) (err error) {
+		schemas[i], err = c.GetSchemaByID(unique[i])
+		if err != nil {
+			return fmt.Errorf("schema [%d]: %v", unique[i], err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[int]string, len(unique))
+	for i, id := range unique {
+		resolved[id] = schemas[i]
+	}
+
+	return resolved, nil
+}
+
+// schemaTTLCache keeps the schema results for a fixed duration, see `ClientConfig#SchemaCacheTTL`.
+// It is safe for concurrent use and all of its methods are no-op on a nil cache.
+type schemaTTLCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]schemaTTLCacheEntry
+	// nextSweep is the time of the next removal of all the expired entries, see `put`.
+	nextSweep time.Time
+}
+
+type schemaTTLCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newSchemaTTLCache(ttl time.Duration) *schemaTTLCache {
+	return &schemaTTLCache{ttl: ttl, entries: make(map[string]schemaTTLCacheEntry)}
+}
+
+func schemaCacheKey(name string) string { return "name:" + name }
+
+func schemaIDCacheKey(id int) string { return "id:" + strconv.Itoa(id) }
+
+func (cache *schemaTTLCache) get(key string) (interface{}, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	cache.mu.RLock()
+	entry, ok := cache.entries[key]
+	cache.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		cache.mu.Lock()
+		// it may be put again in the meantime.
+		if current, ok := cache.entries[key]; ok && current.expiresAt == entry.expiresAt {
+			delete(cache.entries, key)
+		}
+		cache.mu.Unlock()
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (cache *schemaTTLCache) put(key string, value interface{}) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	// drop the expired ones which are not read again, at most once per ttl,
+	// so a long running client does not grow forever without a sweep on every put.
+	if now.After(cache.nextSweep) {
+		for k, entry := range cache.entries {
+			if now.After(entry.expiresAt) {
+				delete(cache.entries, k)
+			}
+		}
+		cache.nextSweep = now.Add(cache.ttl)
+	}
+
+	cache.entries[key] = schemaTTLCacheEntry{value: value, expiresAt: now.Add(cache.ttl)}
+}
+
+func (cache *schemaTTLCache) delete(key string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	delete(cache.entries, key)
+	cache.mu.Unlock()
+}
+
+func (cache *schemaTTLCache) clear() {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	cache.entries = make(map[string]schemaTTLCacheEntry)
+	cache.mu.Unlock()
+}
+
+// InvalidateSchemaCache removes all the cached schemas, both the ones kept for the `ClientConfig#SchemaCacheTTL`
+// and the ones by id of the `UsingSchemaIDCache`, so the next calls fetch them from the box.
+func (c *Client) InvalidateSchemaCache() {
+	c.schemaCache.clear()
+
+	if c.schemaIDCache != nil {
+		c.schemaIDCache.clear()
+	}
+}
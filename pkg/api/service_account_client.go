@@ -13,7 +13,30 @@ type ServiceAccount struct {
 	Name   string   `json:"name" yaml:"name" header:"Name"`
 	Owner  string   `json:"owner,omitempty" yaml:"owner,omitempty" header:"Owner"`
 	Groups []string `json:"groups" yaml:"groups" header:"Groups"`
-	Token  string   `json:"token,omitempty" yaml:"token,omitempty"`
+	// Token is only sent on creation, it is never returned by `GetServiceAccounts` and `GetServiceAccount`,
+	// see `CreateServiceAccount` and `RevokeServiceAccountToken` instead.
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+	// CreatedAt and TokenActive are read-only, set by the server.
+	CreatedAt   int64 `json:"createdAt,omitempty" yaml:"createdAt,omitempty" header:"Created At,timestamp(ms|utc|02 Jan 2006 15:04)"`
+	TokenActive bool  `json:"tokenActive,omitempty" yaml:"tokenActive,omitempty" header:"Token Active"`
+}
+
+// serviceAccountPayload is the writable part of a `ServiceAccount`, sent on create and update,
+// its read-only fields are set by the server and they are not sent back.
+type serviceAccountPayload struct {
+	Name   string   `json:"name"`
+	Owner  string   `json:"owner,omitempty"`
+	Groups []string `json:"groups"`
+	Token  string   `json:"token,omitempty"`
+}
+
+func newServiceAccountPayload(serviceAccount *ServiceAccount) serviceAccountPayload {
+	return serviceAccountPayload{
+		Name:   serviceAccount.Name,
+		Owner:  serviceAccount.Owner,
+		Groups: serviceAccount.Groups,
+		Token:  serviceAccount.Token,
+	}
 }
 
 // CreateSvcAccPayload the data transfer object when we create a new service account
@@ -21,17 +44,22 @@ type CreateSvcAccPayload struct {
 	Token string `json:"token,omitempty"`
 }
 
-// GetServiceAccounts returns the list of service accounts
+// GetServiceAccounts returns the list of service accounts along with their creation time and token state,
+// their token values are not returned.
 func (c *Client) GetServiceAccounts() (serviceAccounts []ServiceAccount, err error) {
 	resp, err := c.Do(http.MethodGet, serviceAccountPath, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
 	err = c.ReadJSON(resp, &serviceAccounts)
+
+	for i := range serviceAccounts {
+		serviceAccounts[i].Token = ""
+	}
 	return
 }
 
-// GetServiceAccount returns the service account by the provided name
+// GetServiceAccount returns the service account by the provided name, its token value is not returned.
 func (c *Client) GetServiceAccount(name string) (serviceAccount ServiceAccount, err error) {
 	if name == "" {
 		err = errRequired("name")
@@ -45,6 +73,7 @@ func (c *Client) GetServiceAccount(name string) (serviceAccount ServiceAccount,
 	}
 
 	err = c.ReadJSON(resp, &serviceAccount)
+	serviceAccount.Token = ""
 	return
 }
 
@@ -59,7 +88,7 @@ func (c *Client) CreateServiceAccount(serviceAccount *ServiceAccount) (token Cre
 		return
 	}
 
-	payload, err := json.Marshal(serviceAccount)
+	payload, err := json.Marshal(newServiceAccountPayload(serviceAccount))
 	if err != nil {
 		return
 	}
@@ -95,7 +124,7 @@ func (c *Client) UpdateServiceAccount(serviceAccount *ServiceAccount) error {
 		return errRequired("groups")
 	}
 
-	payload, err := json.Marshal(serviceAccount)
+	payload, err := json.Marshal(newServiceAccountPayload(serviceAccount))
 	if err != nil {
 		return err
 	}
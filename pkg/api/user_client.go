@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const usersPath = "api/v1/user"
@@ -44,6 +45,51 @@ func (c *Client) GetUser(name string) (user UserMember, err error) {
 	return
 }
 
+// ManagementUser is the read-only view of a Lenses user, for auditing the users access,
+// it carries the group membership and the last login but never the password, see `GetManagementUsers`.
+type ManagementUser struct {
+	Username string   `json:"username" yaml:"username" header:"Username"`
+	Email    string   `json:"email,omitempty" yaml:"email,omitempty" header:"Email"`
+	Groups   []string `json:"groups" yaml:"groups" header:"Groups"`
+	Type     string   `json:"type,omitempty" yaml:"security,omitempty" header:"Security Type"`
+	// LastLogin is the epoch milliseconds of the user's last login, zero if the user never logged in
+	// or the box does not report it.
+	LastLogin int64 `json:"lastLogin,omitempty" yaml:"lastLogin,omitempty" header:"Last Login,timestamp(ms|utc|02 Jan 2006 15:04)"`
+}
+
+// GetManagementUsers returns the users with their groups and last login.
+func (c *Client) GetManagementUsers() ([]ManagementUser, error) {
+	resp, err := c.Do(http.MethodGet, usersPath, contentTypeJSON, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []ManagementUser
+	if err = c.ReadJSON(resp, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetManagementUser returns the user of the "username" with its groups and last login.
+func (c *Client) GetManagementUser(username string) (ManagementUser, error) {
+	var user ManagementUser
+
+	if username == "" {
+		return user, errRequired("username")
+	}
+
+	path := fmt.Sprintf("%s/%s", usersPath, username)
+	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	if err != nil {
+		return user, err
+	}
+
+	err = c.ReadJSON(resp, &user)
+	return user, err
+}
+
 // CreateUser creates a user
 func (c *Client) CreateUser(user *UserMember) error {
 	if user.Username == "" {
@@ -98,6 +144,56 @@ func (c *Client) UpdateUser(user *UserMember) error {
 	return nil
 }
 
+// GetUserGroups returns the names of the groups a user belongs to.
+func (c *Client) GetUserGroups(username string) ([]string, error) {
+	user, err := c.GetUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Groups, nil
+}
+
+// SetUserGroups replaces the groups a user belongs to with the given "groups".
+// It returns an error, without updating the user, if any of the groups does not exist.
+func (c *Client) SetUserGroups(username string, groups []string) error {
+	if username == "" {
+		return errRequired("username")
+	}
+	if len(groups) == 0 {
+		return errRequired("groups")
+	}
+
+	existingGroups, err := c.GetGroups()
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(existingGroups))
+	for _, group := range existingGroups {
+		existing[group.Name] = true
+	}
+
+	var unknown []string
+	for _, group := range groups {
+		if !existing[group] {
+			unknown = append(unknown, group)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("groups [%s] do not exist", strings.Join(unknown, ", "))
+	}
+
+	user, err := c.GetUser(username)
+	if err != nil {
+		return err
+	}
+
+	user.Groups = groups
+	return c.UpdateUser(&user)
+}
+
 type changePassword struct {
 	Value string `json:"value"`
 }
@@ -123,3 +219,54 @@ func (c *Client) UpdateUserPassword(username, password string) error {
 	}
 	return nil
 }
+
+const whoAmIPath = "api/auth"
+
+// NamespacePermissions describes the permissions of a user on the datasets
+// whose names match any of the "Wildcards" of a data connection, see `UserPermissions`.
+type NamespacePermissions struct {
+	Connection  string   `json:"connection,omitempty" yaml:"connection" header:"Connection"`
+	Wildcards   []string `json:"wildcards" yaml:"wildcards" header:"Wildcards"`
+	Permissions []string `json:"permissions" yaml:"permissions" header:"Permissions"`
+}
+
+// UserPermissions describes what the authenticated user can do, see `WhoAmI`.
+type UserPermissions struct {
+	Name                 string                 `json:"user" yaml:"user" header:"Name"`
+	Roles                []string               `json:"roles" yaml:"roles" header:"Roles"`
+	Permissions          []string               `json:"permissions" yaml:"permissions" header:"Permissions"`
+	Namespaces           []NamespacePermissions `json:"namespaces" yaml:"namespaces" header:"Namespaces,count"`
+	SchemaRegistryDelete bool                   `json:"schemaRegistryDelete" yaml:"schemaRegistryDelete" header:"Schema Registry Delete"`
+}
+
+// HasPermission reports whether the user has the application-wide "permission", i.e "ManageConnectors".
+func (u UserPermissions) HasPermission(permission string) bool {
+	for _, p := range u.Permissions {
+		if strings.EqualFold(p, permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WhoAmI returns the roles, the application-wide and the per-namespace permissions of the authenticated user,
+// i.e to check whether the current token can perform an action before trying it.
+// It refreshes the Client's `User` as well.
+func (c *Client) WhoAmI() (UserPermissions, error) {
+	resp, err := c.Do(http.MethodGet, whoAmIPath, "", nil)
+	if err != nil {
+		return UserPermissions{}, err
+	}
+
+	var user UserPermissions
+	if err = c.ReadJSON(resp, &user); err != nil {
+		return UserPermissions{}, err
+	}
+
+	c.User.Name = user.Name
+	c.User.Permissions = user.Permissions
+	c.User.SchemaRegistryDelete = user.SchemaRegistryDelete
+
+	return user, nil
+}
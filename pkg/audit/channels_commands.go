@@ -9,6 +9,7 @@ import (
 	"github.com/lensesio/lenses-go/v5/pkg"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -80,6 +81,10 @@ func NewDeleteAuditChannelCommand() *cobra.Command {
 		TraverseChildren: true,
 		SilenceErrors:    true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.Confirm(cmd, "Delete audit channel [%s]?", channelID); err != nil {
+				return err
+			}
+
 			err := config.Client.DeleteChannel(pkg.AuditChannelsPath, channelID)
 			if err != nil {
 				return fmt.Errorf("failed to delete audit channel [%s]. [%s]", channelID, err.Error())
@@ -91,6 +96,7 @@ func NewDeleteAuditChannelCommand() *cobra.Command {
 	cmd.Flags().StringVar(&channelID, "channelID", "", "The audit channel id, e.g. d15-4960-9ea6-2ccb4d26ebb4")
 	cmd.MarkFlagRequired("channelID")
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/lensesio/tableprinter"
 	"github.com/spf13/cobra"
 )
@@ -16,12 +17,17 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 	var (
 		sse                  bool
 		tableOnlyWithContent bool
+		exportFile           string
+		exportFormat         string
+		liveTypes            []string
+		liveUsers            []string
 	)
 
 	cmd := &cobra.Command{
-		Use:              "audits",
-		Short:            "List the last buffered audit entries",
-		Example:          `audits [--live] [--with-content]`,
+		Use:   "audits",
+		Short: "List the last buffered audit entries",
+		Example: `audits [--live] [--with-content] [--export=audits.csv --export-format=csv|ndjson]
+audits --live --type=TOPIC --type=ACL --by-user=admin`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -38,7 +44,12 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 					return bite.PrintObject(cmd, entry)
 				}
 
-				return config.Client.GetAuditEntriesLive(handler)
+				opts := api.AuditLiveOptions{Users: liveUsers}
+				for _, typ := range liveTypes {
+					opts.Types = append(opts.Types, api.AuditEntryType(typ))
+				}
+
+				return config.Client.GetAuditEntriesLiveFiltered(opts, handler)
 			}
 
 			entries, err := config.Client.GetAuditEntries()
@@ -46,6 +57,14 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 				return err
 			}
 
+			if exportFile != "" {
+				if err = exportAuditEntriesToFile(exportFile, exportFormat, entries); err != nil {
+					return fmt.Errorf("failed to export audit entries to [%s]. [%s]", exportFile, err.Error())
+				}
+
+				return bite.PrintInfo(cmd, "[%d] audit entries exported to [%s]", len(entries), exportFile)
+			}
+
 			if len(entries) == 0 {
 				return nil
 			}
@@ -73,9 +92,14 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&sse, "live", false, "Subscribe to live audit feeds")
+	cmd.Flags().StringArrayVar(&liveTypes, "type", nil, "Receive only the live audit entries of this type, i.e. TOPIC or ACL, can be repeated")
+	cmd.Flags().StringArrayVar(&liveUsers, "by-user", nil, "Receive only the live audit entries made by this user, can be repeated")
 	cmd.Flags().BoolVar(&tableOnlyWithContent, "with-content", false, "Add a table column to display the raw json content of the event action")
+	cmd.Flags().StringVar(&exportFile, "export", "", "Write all the fetched audit entries to a file instead of printing them")
+	cmd.Flags().StringVar(&exportFormat, "export-format", "", `Export format, "csv" or "ndjson", defaults to the file's extension or "csv"`)
 
 	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
 
 	cmd.AddCommand(DeleteAuditEntriesCommand())
 
@@ -97,6 +121,10 @@ func DeleteAuditEntriesCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete the audit logs older than timestamp [%d]?", olderThanTimestamp); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteAuditEntries(olderThanTimestamp); err != nil {
 				return fmt.Errorf("Failed to delete audit logs. [%s]", err.Error())
 			}
@@ -107,6 +135,7 @@ func DeleteAuditEntriesCommand() *cobra.Command {
 	cmd.Flags().Int64Var(&olderThanTimestamp, "timestamp", 0, "All the audit logs older than that timestamp will be removed.")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 	return cmd
 }
 
@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportContentPrefix is prepended to the flattened Content keys of the CSV header.
+const exportContentPrefix = "content."
+
+// resolveExportFormat returns the export format, if empty it is resolved by the file extension
+// and it defaults to CSV.
+func resolveExportFormat(format, filename string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".ndjson", ".jsonl", ".json":
+			return exportFormatNDJSON, nil
+		default:
+			return exportFormatCSV, nil
+		}
+	}
+
+	format = strings.ToLower(format)
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		return "", fmt.Errorf("unsupported export format [%s], available formats are: [%s, %s]", format, exportFormatCSV, exportFormatNDJSON)
+	}
+
+	return format, nil
+}
+
+// exportAuditEntriesToFile writes the "entries" to the "filename" in the given "format".
+func exportAuditEntriesToFile(filename, format string, entries []api.AuditEntry) error {
+	format, err := resolveExportFormat(format, filename)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err = exportAuditEntries(f, format, entries); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// exportAuditEntries writes the "entries" to "w" as CSV or NDJSON (one JSON entry per line).
+func exportAuditEntries(w io.Writer, format string, entries []api.AuditEntry) error {
+	if format == exportFormatNDJSON {
+		return writeAuditEntriesNDJSON(w, entries)
+	}
+
+	return writeAuditEntriesCSV(w, entries)
+}
+
+func writeAuditEntriesNDJSON(w io.Writer, entries []api.AuditEntry) error {
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeAuditEntriesCSV writes the entries as CSV, the Content map is flattened to one column per key,
+// the keys of all entries are collected and sorted so the header is stable between exports.
+func writeAuditEntriesCSV(w io.Writer, entries []api.AuditEntry) error {
+	keysSet := make(map[string]struct{})
+	for _, entry := range entries {
+		for key := range entry.Content {
+			keysSet[key] = struct{}{}
+		}
+	}
+
+	contentKeys := make([]string, 0, len(keysSet))
+	for key := range keysSet {
+		contentKeys = append(contentKeys, key)
+	}
+	sort.Strings(contentKeys)
+
+	header := []string{"type", "action", "resource", "user", "timestamp"}
+	for _, key := range contentKeys {
+		header = append(header, exportContentPrefix+key)
+	}
+
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		record := []string{
+			string(entry.Type),
+			entry.Action,
+			entry.Resource,
+			entry.User,
+			strconv.FormatInt(entry.Timestamp, 10),
+		}
+
+		for _, key := range contentKeys {
+			record = append(record, entry.Content[key])
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
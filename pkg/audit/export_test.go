@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+)
+
+func TestExportAuditEntriesCSV(t *testing.T) {
+	entries := []api.AuditEntry{
+		{Type: "TOPIC", Action: "ADD", Resource: "a", User: "admin", Timestamp: 1, Content: map[string]string{"partitions": "1", "name": "a"}},
+		{Type: "TOPIC", Action: "REMOVE", Resource: "b", User: "admin", Timestamp: 2, Content: map[string]string{"replication": "3"}},
+	}
+
+	var buf bytes.Buffer
+	if err := exportAuditEntries(&buf, exportFormatCSV, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "type,action,resource,user,timestamp,content.name,content.partitions,content.replication\n" +
+		"TOPIC,ADD,a,admin,1,a,1,\n" +
+		"TOPIC,REMOVE,b,admin,2,,,3\n"
+
+	if got := buf.String(); got != expected {
+		t.Errorf("got `%v`, want `%v`", got, expected)
+	}
+}
+
+func TestResolveExportFormat(t *testing.T) {
+	tests := []struct {
+		format, filename, expected string
+	}{
+		{"", "audits.csv", exportFormatCSV},
+		{"", "audits.ndjson", exportFormatNDJSON},
+		{"NDJSON", "audits.txt", exportFormatNDJSON},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveExportFormat(tt.format, tt.filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.expected {
+			t.Errorf("got `%v`, want `%v`", got, tt.expected)
+		}
+	}
+
+	if _, err := resolveExportFormat("xml", "audits.xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
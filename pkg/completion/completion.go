@@ -0,0 +1,229 @@
+// Package completion provides the shell completion of the CLI, including the names of topics, connectors and subjects.
+package completion
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// Setup adds the `completion` command to the "root" and registers the completion of the resource names
+// on the flags of its topic, connector and schema registry commands.
+// The "root" must not have a `completion` command already, i.e. the bash only one of the `bite.Application#Run`.
+func Setup(root *cobra.Command) {
+	root.AddCommand(NewCompletionCommand())
+
+	for _, cmd := range root.Commands() {
+		if flags, ok := nameCompletions[cmd.Name()]; ok {
+			registerFlagCompletions(cmd, flags)
+		}
+	}
+}
+
+// nameCompletions are the completion functions per flag name of the top-level commands and their sub-commands.
+var nameCompletions = map[string]map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+	"topic":           {"name": TopicNames},
+	"topics":          {"name": TopicNames, "topic": TopicNames},
+	"connector":       {"name": ConnectorNames, "cluster-name": ConnectClusterNames},
+	"connectors":      {"name": ConnectorNames, "cluster-name": ConnectClusterNames},
+	"schema-registry": {"name": SubjectNames, "topic": TopicNames},
+}
+
+func registerFlagCompletions(cmd *cobra.Command, flags map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)) {
+	for name, fn := range flags {
+		if cmd.Flags().Lookup(name) != nil {
+			// it fails only if already registered, i.e by the command itself.
+			cmd.RegisterFlagCompletionFunc(name, fn)
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		registerFlagCompletions(sub, flags)
+	}
+}
+
+// NewCompletionCommand creates `completion` command
+func NewCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate the shell completion script",
+		Long: `Generate the shell completion script, the names of topics, connectors and subjects are completed as well.
+
+To load the completion of the current bash session run:
+
+	source <(lenses-cli completion bash)
+
+To load it for each session add it to your bashrc:
+
+	echo "source <(lenses-cli completion bash)" >> ~/.bashrc`,
+		Example:               `completion zsh > "${fpath[1]}/_lenses-cli"`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.ExactValidArgs(1),
+		DisableFlagsInUseLine: true,
+		SilenceErrors:         true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, out := cmd.Root(), cmd.OutOrStdout()
+
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletion(out)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			default:
+				return root.GenPowerShellCompletion(out)
+			}
+		},
+	}
+
+	return cmd
+}
+
+// TopicNames completes the names of the topics.
+func TopicNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete("topics", toComplete, config.Client.GetTopicsNames)
+}
+
+// SubjectNames completes the names of the schema registry subjects.
+func SubjectNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete("subjects", toComplete, func() ([]string, error) {
+		subjects, err := config.Client.GetSubjects()
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, len(subjects))
+		for i, subject := range subjects {
+			names[i] = subject.Name
+		}
+		return names, nil
+	})
+}
+
+// ConnectClusterNames completes the names of the connect clusters.
+func ConnectClusterNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete("connect-clusters", toComplete, config.Client.GetConnectClusters)
+}
+
+// ConnectorNames completes the names of the connectors of the `--cluster-name` flag's cluster,
+// or of all the connect clusters if not set.
+func ConnectorNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	clusterName, _ := cmd.Flags().GetString("cluster-name")
+
+	return complete("connectors/"+clusterName, toComplete, func() ([]string, error) {
+		clusters := []string{clusterName}
+		if clusterName == "" || clusterName == "*" {
+			var err error
+			if clusters, err = config.Client.GetConnectClusters(); err != nil {
+				return nil, err
+			}
+		}
+
+		var names []string
+		for _, cluster := range clusters {
+			connectors, err := config.Client.GetConnectors(cluster)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, connectors...)
+		}
+		return names, nil
+	})
+}
+
+// complete returns the names which start with "toComplete", the names are fetched once per `CacheTTL` for each "kind".
+// No names are given if there is no valid configuration or the names cannot be fetched.
+func complete(kind, toComplete string, fetch func() ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	if config.Client == nil || config.Client.Config == nil || config.Client.Config.Host == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names, err := cachedNames(cacheKey(config.Client.Config, kind), fetch)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			matches = append(matches, name)
+		}
+	}
+
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// cacheKey returns the key of the "kind" names of the current context, its host and user,
+// so contexts and users of the same host do not complete each other's names.
+func cacheKey(clientConfig *api.ClientConfig, kind string) string {
+	var contextName string
+	if config.Manager != nil && config.Manager.Config != nil {
+		contextName = config.Manager.Config.CurrentContext
+	}
+
+	var user string
+	if auth, ok := clientConfig.IsBasicAuth(); ok {
+		user = auth.Username
+	} else if auth, ok := clientConfig.IsKerberosAuth(); ok {
+		if method, ok := auth.WithPassword(); ok {
+			user = method.Username
+		}
+	} else if clientConfig.Authentication == nil {
+		// a service account is known by its token only.
+		user = clientConfig.Token
+	}
+
+	return strings.Join([]string{contextName, clientConfig.Host, user, clientConfig.ImpersonateUser, kind}, "/")
+}
+
+// CacheTTL is the time the fetched names are kept, every key press is a new CLI invocation
+// so the names are kept in files under the `CacheDir`.
+var CacheTTL = 30 * time.Second
+
+// CacheDir is the directory of the cached names, defaults to the user's cache directory.
+var CacheDir = defaultCacheDir()
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "lenses-cli", "completion")
+}
+
+func cachedNames(key string, fetch func() ([]string, error)) ([]string, error) {
+	filename := filepath.Join(CacheDir, fmt.Sprintf("%x.json", sha1.Sum([]byte(key))))
+
+	if info, err := os.Stat(filename); err == nil && time.Since(info.ModTime()) < CacheTTL {
+		if b, err := ioutil.ReadFile(filename); err == nil {
+			var names []string
+			if err = json.Unmarshal(b, &names); err == nil {
+				return names, nil
+			}
+		}
+	}
+
+	names, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	// a failure to cache only costs a new fetch on the next completion.
+	if b, err := json.Marshal(names); err == nil && os.MkdirAll(CacheDir, 0700) == nil {
+		ioutil.WriteFile(filename, b, 0600)
+	}
+
+	return names, nil
+}
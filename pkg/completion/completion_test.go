@@ -0,0 +1,85 @@
+package completion
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameCompletions(t *testing.T) {
+	var requests int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/api/topics":
+			w.Write([]byte(`[{"topicName": "orders"}, {"topicName": "orders-dlq"}, {"topicName": "payments"}]`))
+		case "/api/proxy-connect/dev/connectors":
+			w.Write([]byte(`["orders-sink", "payments-sink"]`))
+		default:
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	CacheDir = t.TempDir()
+
+	names, directive := TopicNames(nil, nil, "ord")
+	assert.Equal(t, []string{"orders", "orders-dlq"}, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	// the next key press is served from the cache.
+	names, _ = TopicNames(nil, nil, "pay")
+	assert.Equal(t, []string{"payments"}, names)
+	assert.Equal(t, int32(1), requests)
+
+	cmd := &cobra.Command{Use: "status"}
+	cmd.Flags().String("cluster-name", "", "")
+	cmd.Flags().Set("cluster-name", "dev")
+
+	names, _ = ConnectorNames(cmd, nil, "")
+	assert.Equal(t, []string{"orders-sink", "payments-sink"}, names)
+}
+
+func TestCompletionCommand(t *testing.T) {
+	root := &cobra.Command{Use: "lenses-cli"}
+	topicCmd := &cobra.Command{Use: "topic", Run: func(*cobra.Command, []string) {}}
+	topicCmd.Flags().String("name", "", "")
+	root.AddCommand(topicCmd)
+
+	Setup(root)
+
+	output, err := test.ExecuteCommand(root, "completion", "zsh")
+	assert.Nil(t, err)
+	test.CheckStringContains(t, output, "#compdef _lenses-cli lenses-cli")
+
+	_, err = test.ExecuteCommand(root, "completion", "tcsh")
+	assert.NotNil(t, err)
+}
+
+func TestCacheKey(t *testing.T) {
+	defer func(m *config.ConfigurationManager) { config.Manager = m }(config.Manager)
+	config.Manager = config.NewEmptyConfigManager()
+	config.Manager.Config = &api.Config{CurrentContext: "dev"}
+
+	admin := &api.ClientConfig{Host: "http://lenses:9991", Authentication: api.BasicAuthentication{Username: "admin"}}
+	john := &api.ClientConfig{Host: "http://lenses:9991", Authentication: api.BasicAuthentication{Username: "john"}}
+
+	devKey := cacheKey(admin, "topics")
+	assert.NotEqual(t, devKey, cacheKey(john, "topics"))
+	assert.NotEqual(t, devKey, cacheKey(&api.ClientConfig{Host: admin.Host, Token: "service-account-token"}, "topics"))
+
+	config.Manager.Config.CurrentContext = "prod"
+	assert.NotEqual(t, devKey, cacheKey(admin, "topics"))
+}
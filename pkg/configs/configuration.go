@@ -1,10 +1,13 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/kataras/golog"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	"github.com/lensesio/lenses-go/v5/pkg/utils"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
@@ -25,8 +29,11 @@ var Manager *ConfigurationManager
 type ConfigurationManager struct {
 	Config *api.Config
 	// flags below.
-	CurrentContext, host, timeout, token, user, pass, kerberosConf, kerberosRealm, kerberosKeytab, kerberosCCache string
-	insecure, debug, WaitForLenses                                                                                bool
+	CurrentContext, host, timeout, token, impersonateUser, user, pass, kerberosConf, kerberosRealm, kerberosKeytab, kerberosCCache string
+	insecure, verifyOnConnect, strictJSON, debug, configFromEnv, WaitForLenses                                                     bool
+	maxRetries                                                                                                                     int
+	// CommandTimeout bounds the total runtime of a command's API calls, see `ApplyCommandTimeout`.
+	CommandTimeout time.Duration
 
 	Filepath string
 }
@@ -57,7 +64,7 @@ func NewConfigurationManager(set *pflag.FlagSet) *ConfigurationManager {
 		},
 	}
 
-	set.StringVar(&m.CurrentContext, "context", "", "Load specific environment, embedded configuration based on the configuration's 'Contexts'")
+	set.StringVar(&m.CurrentContext, "context", "", "Load specific environment, embedded configuration based on the configuration's 'Contexts', for this command only")
 
 	set.StringVar(&m.host, "host", "", "Lenses host")
 	// basic auth.
@@ -76,11 +83,17 @@ func NewConfigurationManager(set *pflag.FlagSet) *ConfigurationManager {
 	set.StringVar(&m.kerberosCCache, "kerberos-ccache", "", "Kerberos keytab file")
 
 	set.StringVar(&m.timeout, "timeout", "", "Timeout for the connection establishment")
+	set.DurationVar(&m.CommandTimeout, "command-timeout", 0, "Maximum total time of the command's requests, i.e 30s, ignored by streaming commands")
 	set.BoolVar(&m.insecure, "insecure", false, "All insecure http requests")
+	set.IntVar(&m.maxRetries, "max-retries", 0, "Retries of a request rate-limited with 429 (Too Many Requests), 0 for the default of 3, negative to disable")
+	set.BoolVar(&m.strictJSON, "strict-json", false, "Fail when the box responds with fields unknown to the client, for debugging API changes")
+	set.BoolVar(&m.verifyOnConnect, "verify-on-connect", false, "Check that the box is reachable and its license is not expired before running the command")
 	set.StringVar(&m.token, "token", "", "Lenses auth token")
+	set.StringVar(&m.impersonateUser, "impersonate-user", "", "Perform the requests on behalf of another user, requires impersonation to be enabled on the box")
 	set.BoolVar(&m.debug, "debug", false, "Print some information that are necessary for debugging")
 
 	set.StringVar(&m.Filepath, "config", "", "Load or save the host, user, pass and debug fields from or to a configuration file (yaml or json)")
+	set.BoolVar(&m.configFromEnv, "config-from-env", false, "Load the configuration only from the LENSES_* environment variables, i.e LENSES_HOST and LENSES_TOKEN, without reading any configuration file")
 	set.BoolVar(&m.WaitForLenses, "wait-for-lenses", false, "when set will wait for Lenses server to respond")
 	return m
 }
@@ -96,13 +109,80 @@ func NewEmptyConfigManager() *ConfigurationManager {
 
 const currentContextEnvKey = "LENSES_CLI_CONTEXT"
 
-// Load loads the configuration
+// The environment variables of `ClientConfigFromEnv`.
+const (
+	hostEnvKey            = "LENSES_HOST"
+	tokenEnvKey           = "LENSES_TOKEN"
+	userEnvKey            = "LENSES_USER"
+	passwordEnvKey        = "LENSES_PASSWORD"
+	timeoutEnvKey         = "LENSES_TIMEOUT"
+	impersonateUserEnvKey = "LENSES_IMPERSONATE_USER"
+	insecureEnvKey        = "LENSES_INSECURE"
+	debugEnvKey           = "LENSES_DEBUG"
+)
+
+// ClientConfigFromEnv returns the client configuration of the LENSES_HOST, LENSES_TOKEN or LENSES_USER and LENSES_PASSWORD,
+// LENSES_TIMEOUT, LENSES_IMPERSONATE_USER, LENSES_INSECURE and LENSES_DEBUG environment variables, i.e for CI runners without a configuration file.
+// It reports whether any of them is set and returns an error if a boolean one is not a valid boolean.
+func ClientConfigFromEnv() (api.ClientConfig, bool, error) {
+	var (
+		cfg   api.ClientConfig
+		found bool
+	)
+
+	lookup := func(key string) string {
+		v, ok := os.LookupEnv(key)
+		v = strings.TrimSpace(v)
+		if ok && v != "" {
+			found = true
+		}
+		return v
+	}
+
+	cfg.Host = lookup(hostEnvKey)
+	cfg.Token = lookup(tokenEnvKey)
+	cfg.Timeout = lookup(timeoutEnvKey)
+	cfg.ImpersonateUser = lookup(impersonateUserEnvKey)
+
+	if user, pass := lookup(userEnvKey), lookup(passwordEnvKey); user != "" && pass != "" {
+		cfg.Authentication = api.BasicAuthentication{Username: user, Password: pass}
+	}
+
+	for key, ptr := range map[string]*bool{insecureEnvKey: &cfg.Insecure, debugEnvKey: &cfg.Debug} {
+		if v := lookup(key); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return cfg, found, fmt.Errorf("invalid %s value [%s], expected a boolean", key, v)
+			}
+			*ptr = b
+		}
+	}
+
+	return cfg, found, nil
+}
+
+// Load loads the configuration of the current context.
+//
+// The configuration of each field is taken by the following precedence: the flags,
+// then the LENSES_* environment variables (see `ClientConfigFromEnv`) and then the configuration file.
+// With the --config-from-env flag no configuration file is read at all.
 func (m *ConfigurationManager) Load() (bool, error) {
 	c := m.Config
 
+	envConfig, foundEnv, err := ClientConfigFromEnv()
+	if err != nil {
+		return false, err
+	}
+
+	if m.configFromEnv && envConfig.Host == "" {
+		return false, fmt.Errorf("the %s environment variable is required by the --config-from-env flag", hostEnvKey)
+	}
+
 	var found bool
 
-	if m.Filepath != "" {
+	if m.configFromEnv {
+		// don't read any file, the configuration is built from the env below.
+	} else if m.Filepath != "" {
 		// must read from file, otherwise fail.
 		if err := api.TryReadConfigFromFile(m.Filepath, c); err != nil {
 			return false, err
@@ -112,18 +192,44 @@ func (m *ConfigurationManager) Load() (bool, error) {
 	} else if found = api.TryReadConfigFromExecutable(c); found {
 	} else if found = api.TryReadConfigFromHome(c); found {
 	}
-	// check --context flag (prio) and the configuration's one, if it's there and set the current context upfront.
+
+	if found {
+		// decrypt before any password of the env or the flags is set below.
+		for _, v := range c.Contexts {
+			DecryptPassword(v)
+		}
+	}
+
+	// check --context flag (prio), the env's and the configuration's one, if it's there and set the current context upfront.
+	// Note that neither the flag nor the env change the `CurrentContext` field of the configuration file, they apply to this invocation only.
+	var unknownContextErr error
 	currentContext := c.CurrentContext
-	currentContextChanged := false
-	if flag := m.CurrentContext; flag != "" && flag != currentContext {
+	if flag := m.CurrentContext; flag != "" {
 		currentContext = flag
-		currentContextChanged = true
-	} else if currentContext == "" {
+		if found && !c.ContextExists(flag) {
+			unknownContextErr = fmt.Errorf("unknown context [%s] given by the --context flag, available contexts are: [%s]",
+				flag, strings.Join(contextNames(c), ", "))
+		}
+	} else if found {
+		// try to set the current context from *.env file or from system 's env variables,
+		// if not empty, the env value has a priority over the configurated `CurrentContext`.
+		godotenv.Load()
+		if envContext := strings.TrimSpace(os.Getenv(currentContextEnvKey)); envContext != "" {
+			currentContext = envContext
+		}
+	}
+
+	if currentContext == "" {
 		currentContext = api.DefaultContextKey
 	}
 
 	c.SetCurrent(currentContext)
 
+	// env has a priority over the configuration file but not over the flags below.
+	if foundEnv {
+		c.GetCurrent().Fill(envConfig)
+	}
+
 	// authentication flags passed, override or set the particular authentication method.
 	authFromFlags, authLoadedFromFlags := makeAuthFromFlags(m.user, m.pass, m.kerberosConf, m.kerberosRealm, m.kerberosKeytab, m.kerberosCCache)
 	if authLoadedFromFlags {
@@ -133,40 +239,20 @@ func (m *ConfigurationManager) Load() (bool, error) {
 	// flags have always priority, so transfer any non-empty client configuration flag to the current,
 	// so far we don't care about the configuration file found or not.
 	c.GetCurrent().Fill(api.ClientConfig{
-		Host:     m.host,
-		Token:    m.token,
-		Timeout:  m.timeout,
-		Insecure: m.insecure,
-		Debug:    m.debug,
+		Host:            m.host,
+		Token:           m.token,
+		ImpersonateUser: m.impersonateUser,
+		Timeout:         m.timeout,
+		Insecure:        m.insecure,
+		MaxRetries:      m.maxRetries,
+		VerifyOnConnect: m.verifyOnConnect,
+		StrictJSON:      m.strictJSON,
+		Debug:           m.debug,
 	})
 
-	if found {
-
-		if currentContextChanged {
-			// save the config, the current context changed.
-			for _, v := range c.Contexts {
-				DecryptPassword(v)
-			}
-			if err := m.Save(); err != nil {
-				return false, err
-			}
-		} else {
-			// check if loaded from flags, if so and we proceed then the password field goes empty.
-			if !authLoadedFromFlags {
-				// try to set the current context from *.env file or from system 's env variables,
-				// if not empty, the env value has a priority over the configurated `CurrentContext`
-				// but --context flag has a priority over all (look above).
-				//
-				// Note that the env variable will NOT change the `CurrentContext` field from the configuration file, by purpose.
-				godotenv.Load()
-				if envContext := strings.TrimSpace(os.Getenv(currentContextEnvKey)); envContext != "" {
-					c.CurrentContext = envContext
-				}
-				for _, v := range c.Contexts {
-					DecryptPassword(v)
-				}
-			}
-		}
+	// fail after the current context is set, the configure command creates it.
+	if unknownContextErr != nil {
+		return false, unknownContextErr
 	}
 
 	if c.CurrentContext != "" && !c.CurrentContextExists() {
@@ -176,6 +262,33 @@ func (m *ConfigurationManager) Load() (bool, error) {
 	return c.IsValid(), nil
 }
 
+// contextNames returns the sorted names of the contexts of "c".
+func contextNames(c *api.Config) []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ContextNames returns the sorted names of all the configured contexts.
+func (m *ConfigurationManager) ContextNames() []string {
+	return contextNames(m.Config)
+}
+
+// OpenContext opens a new client for the "name" context, the current context and the `Client` are not changed,
+// i.e. to check the connectivity of all the contexts, see `ContextNames`.
+func (m *ConfigurationManager) OpenContext(name string, options ...api.ConnectionOption) (*api.Client, error) {
+	if !m.Config.ContextExists(name) {
+		return nil, fmt.Errorf("unknown context [%s], available contexts are: [%s]", name, strings.Join(contextNames(m.Config), ", "))
+	}
+
+	// a copy, the connection fills the token of the authentication.
+	return api.OpenConnection(*m.Config.Contexts[name], options...)
+}
+
 // Save saves the configuration
 func (m *ConfigurationManager) Save() error {
 	c := m.Config.Clone() // copy the configuration so all changes here will not be present after the save().
@@ -298,6 +411,48 @@ func SetupClient() (err error) {
 	return err
 }
 
+// StreamingAnnotation is the cobra command annotation key which marks a command as long-running,
+// the `--command-timeout` is not applied to these commands.
+const StreamingAnnotation = "streaming"
+
+// streamingFlags are the flags that turn a command to a streaming one, i.e `audits --live`.
+var streamingFlags = []string{"live", "live-stream", "follow"}
+
+// IsStreamingCommand reports whether the "cmd" streams results until interrupted,
+// by its `StreamingAnnotation` or by any of the streaming flags set.
+func IsStreamingCommand(cmd *cobra.Command) bool {
+	if _, ok := cmd.Annotations[StreamingAnnotation]; ok {
+		return true
+	}
+
+	for _, name := range streamingFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commandCancel releases the resources of the `--command-timeout` context.
+var commandCancel context.CancelFunc
+
+// ApplyCommandTimeout attaches a context with the `--command-timeout` deadline to the `Client`,
+// it does nothing if the flag is missing or the "cmd" is a streaming one, see `IsStreamingCommand`.
+func ApplyCommandTimeout(cmd *cobra.Command) {
+	if Manager == nil || Manager.CommandTimeout <= 0 || IsStreamingCommand(cmd) {
+		return
+	}
+
+	if commandCancel != nil {
+		commandCancel()
+	}
+
+	var ctx context.Context
+	ctx, commandCancel = context.WithTimeout(context.Background(), Manager.CommandTimeout)
+	Client.SetContext(ctx)
+}
+
 func makeAuthFromFlags(user, pass, kerberosConf, kerberosRealm, kerberosKeytab, kerberosCCache string) (api.Authentication, bool) {
 	if kerberosConf != "" {
 		auth := api.KerberosAuthentication{
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	"github.com/spf13/pflag"
+)
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("LENSES_HOST", "http://lenses.ci:9991")
+	t.Setenv("LENSES_USER", "ci")
+	t.Setenv("LENSES_PASSWORD", "secret")
+	t.Setenv("LENSES_TIMEOUT", "30s")
+	t.Setenv("LENSES_INSECURE", "true")
+
+	set := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	m := NewConfigurationManager(set)
+	if err := set.Parse([]string{"--config-from-env", "--timeout=5s"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := m.Load()
+	if err != nil || !ok {
+		t.Fatalf("got `%v`, `%v`, want a valid configuration", ok, err)
+	}
+
+	current := m.Config.GetCurrent()
+	if current.Host != "http://lenses.ci:9991" || !current.Insecure {
+		t.Errorf("got `%+v`, want the configuration of the env", current)
+	}
+
+	if current.Timeout != "5s" {
+		t.Errorf("got timeout `%v`, want the flag to have a priority over the env", current.Timeout)
+	}
+
+	if auth, ok := current.IsBasicAuth(); !ok || auth.Username != "ci" || auth.Password != "secret" {
+		t.Errorf("got `%#v`, want the basic authentication of the env", current.Authentication)
+	}
+}
+
+func TestClientConfigFromEnv(t *testing.T) {
+	if _, found, err := ClientConfigFromEnv(); found || err != nil {
+		t.Fatalf("got `%v`, `%v`, want nothing without the env", found, err)
+	}
+
+	t.Setenv("LENSES_TOKEN", "token")
+	cfg, found, err := ClientConfigFromEnv()
+	if !found || err != nil || cfg.Token != "token" || cfg.Authentication != nil {
+		t.Errorf("got `%+v`, `%v`, `%v`, want the token only", cfg, found, err)
+	}
+
+	t.Setenv("LENSES_DEBUG", "maybe")
+	if _, _, err = ClientConfigFromEnv(); err == nil {
+		t.Error("expected an error for an invalid boolean")
+	}
+}
+
+func TestLoadContextFlag(t *testing.T) {
+	cfg := &api.Config{
+		CurrentContext: "prod",
+		Contexts: map[string]*api.ClientConfig{
+			"prod":    {Host: "http://prod:9991", Authentication: api.BasicAuthentication{Username: "admin", Password: "admin"}},
+			"staging": {Host: "http://staging:9991", Authentication: api.BasicAuthentication{Username: "admin", Password: "admin"}},
+		},
+	}
+
+	b, err := api.ConfigMarshalYAML(*cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "lenses-cli.yml")
+	if err = ioutil.WriteFile(filename, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	load := func(args ...string) (*ConfigurationManager, error) {
+		set := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		m := NewConfigurationManager(set)
+		if err := set.Parse(append([]string{"--config=" + filename}, args...)); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := m.Load()
+		return m, err
+	}
+
+	m, err := load("--context=staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if host := m.Config.GetCurrent().Host; m.Config.CurrentContext != "staging" || host != "http://staging:9991" {
+		t.Errorf("got context `%s` with host `%s`, want the staging one", m.Config.CurrentContext, host)
+	}
+
+	if saved, _ := ioutil.ReadFile(filename); string(saved) != string(b) {
+		t.Errorf("got configuration file:\n%s\nwant it unchanged", saved)
+	}
+
+	if m, err = load(); err != nil || m.Config.CurrentContext != "prod" {
+		t.Errorf("got context `%s`, `%v`, want the saved current context to be kept", m.Config.CurrentContext, err)
+	}
+
+	if _, err = load("--context=dev"); err == nil || !strings.Contains(err.Error(), "[prod, staging]") {
+		t.Errorf("got `%v`, want an unknown context error listing the available ones", err)
+	}
+}
@@ -1,12 +1,14 @@
 package connection
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	cobra "github.com/spf13/cobra"
 )
 
@@ -29,13 +31,18 @@ func NewGenericConnectionGroupCommand() *cobra.Command {
 }
 
 func NewGenericConnectionListCommand() *cobra.Command {
+	var templateName string
+
 	cmd := &cobra.Command{
-		Use:              "list",
-		Short:            `Lists Lenses connections`,
+		Use:   "list",
+		Short: `Lists Lenses connections`,
+		Example: `
+connections generic list --template Elasticsearch
+		`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			connections, err := config.Client.GetConnections()
+			connections, err := config.Client.GetConnectionsByTemplate(templateName)
 			if err != nil {
 				golog.Errorf("Failed to retrieve connections. [%s]", err.Error())
 				return err
@@ -50,6 +57,8 @@ func NewGenericConnectionListCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&templateName, "template", "", "List only the connections of a template, e.g. Elasticsearch or PostgreSQL")
+
 	bite.CanPrintJSON(cmd)
 
 	return cmd
@@ -58,12 +67,14 @@ func NewGenericConnectionListCommand() *cobra.Command {
 // NewConnectionGetCommand creates `connections get` group command
 func NewGenericConnectionGetCommand() *cobra.Command {
 	var name string
+	var showSecrets bool
 
 	cmd := &cobra.Command{
 		Use:   "get",
 		Short: `Get Lenses connections`,
 		Example: `
 connections generic get --name connection-name
+connections generic get --name connection-name --show-secrets
 		`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
@@ -74,6 +85,18 @@ connections generic get --name connection-name
 				return err
 			}
 
+			if !showSecrets {
+				// without the template, the secrets are guessed by their key names.
+				var template *api.ConnectionTemplate
+				if t, err := config.Client.GetConnectionTemplate(connection.TemplateName); err == nil {
+					template = &t
+				} else {
+					golog.Debugf("Failed to retrieve the template of connection [%s]. [%s]", name, err.Error())
+				}
+
+				connection = api.MaskConnection(connection, template)
+			}
+
 			outputFlagValue := strings.ToUpper(bite.GetOutPutFlag(cmd))
 			if outputFlagValue != "JSON" && outputFlagValue != "YAML" {
 				bite.PrintInfo(cmd, "Info: use JSON or YAML output to get the complete object\n\n")
@@ -84,6 +107,7 @@ connections generic get --name connection-name
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "connection name")
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Print the secret configuration values instead of masking them")
 	cmd.MarkFlagRequired("name")
 
 	bite.CanPrintJSON(cmd)
@@ -169,16 +193,36 @@ connections generic update --name connection1 \
 // NewGenericConnectionDeleteCommand creates `connections delete` group command
 func NewGenericConnectionDeleteCommand() *cobra.Command {
 	var name string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: `Delete a Lenses connections`,
+		Long:  `Delete a Lenses connection. A connection used by connectors or datasets is not deleted unless --force is set.`,
 		Example: `
 connections generic delete --name connection-name
+connections generic delete --name connection-name --force
                 `,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !force {
+				usage, err := config.Client.GetConnectionUsage(name)
+				if err != nil {
+					golog.Errorf("Failed to check the usage of connection [%s]. [%s]", name, err.Error())
+					return err
+				}
+
+				if usage.InUse() {
+					return fmt.Errorf("connection [%s] is in use by connectors [%s] and datasets [%s], use --force to delete it anyway",
+						name, strings.Join(usage.Connectors, ", "), strings.Join(usage.Datasets, ", "))
+				}
+			}
+
+			if err := utils.Confirm(cmd, "Delete connection [%s]?", name); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteConnection(name); err != nil {
 				golog.Errorf("Failed to delete connection. [%s]", err.Error())
 				return err
@@ -189,7 +233,9 @@ connections generic delete --name connection-name
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "connection name")
+	cmd.Flags().BoolVar(&force, "force", false, "Delete the connection even if connectors or datasets use it")
 	cmd.MarkFlagRequired("name")
+	utils.CanSkipConfirmation(cmd)
 
 	// Required for bite to send standard output to cmd execution buffer
 	_ = bite.CanBeSilent(cmd)
@@ -92,6 +92,40 @@ func TestGenericConnectionListCommandSuccess(t *testing.T) {
 	config.Client = nil
 }
 
+func TestGenericConnectionListCommandTemplate(t *testing.T) {
+	// setup http request handler
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"name": "es", "templateName": "Elasticsearch", "templateVersion": 1},
+			{"name": "slack", "templateName": "Slack", "templateVersion": 1}
+		]`))
+	})
+	// setup http client
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	cmd := NewGenericConnectionListCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "--template=elasticsearch")
+
+	assert.Nil(t, err)
+
+	var connections []api.ConnectionList
+	err = json.Unmarshal([]byte(output), &connections)
+
+	assert.Nil(t, err)
+	assert.Len(t, connections, 1)
+	assert.Equal(t, "es", connections[0].Name)
+	config.Client = nil
+}
+
 func TestGenericConnectionGetCommandSuccess(t *testing.T) {
 	// setup http request handler
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -125,6 +159,66 @@ func TestGenericConnectionGetCommandSuccess(t *testing.T) {
 	config.Client = nil
 }
 
+func TestGenericConnectionGetCommandMasksSecrets(t *testing.T) {
+	templates := `[{"name":"PostgreSQL","configuration":[{"key":"host"},{"key":"pass","type":{"name":"SECRET"}}]}]`
+
+	// setup http request handler
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/connection/connection-templates" {
+			w.Write([]byte(templates))
+			return
+		}
+		w.Write([]byte(`{"name":"pg","templateName":"PostgreSQL","configuration":[
+			{"key":"host","value":"pg.local"},
+			{"key":"pass","value":"s3cr3t"},
+			{"key":"password","value":"other"}
+		]}`))
+	})
+	// setup http client
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	get := func(args ...string) map[string]interface{} {
+		cmd := NewGenericConnectionGetCommand()
+		var outputValue string
+		cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+		output, err := test.ExecuteCommand(cmd, append([]string{"--name=pg"}, args...)...)
+		assert.Nil(t, err)
+
+		var connection api.Connection
+		assert.Nil(t, json.Unmarshal([]byte(output), &connection))
+
+		values := make(map[string]interface{})
+		for _, cfg := range connection.Configuration {
+			values[cfg.Key] = cfg.Value
+		}
+		return values
+	}
+
+	// the template marks only the "pass" as secret.
+	values := get()
+	assert.Equal(t, "pg.local", values["host"])
+	assert.Equal(t, api.MaskedConfigValue, values["pass"])
+	assert.Equal(t, "other", values["password"])
+
+	values = get("--show-secrets")
+	assert.Equal(t, "s3cr3t", values["pass"])
+
+	// without a template the key names are matched instead.
+	templates = `[]`
+	values = get()
+	assert.Equal(t, "s3cr3t", values["pass"])
+	assert.Equal(t, api.MaskedConfigValue, values["password"])
+
+	config.Client = nil
+}
+
 func TestGenericConnectionCreateCommandSuccess(t *testing.T) {
 	// setup http request handler
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -186,7 +280,14 @@ func TestGenericConnectionUpdateCommandSuccess(t *testing.T) {
 func TestGenericConnectionDeleteCommandSuccess(t *testing.T) {
 	// setup http request handler
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte(connectionListResponse))
+		switch {
+		case r.URL.Path == "/api/v1/datasets":
+			w.Write([]byte(`{"datasets":{"values":[],"pagesAmount":1,"totalCount":0}}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(connectionGetResponse))
+		default:
+			w.Write([]byte(connectionListResponse))
+		}
 	})
 	// setup http client
 	httpClient, teardown := test.TestingHTTPClient(h)
@@ -207,3 +308,48 @@ func TestGenericConnectionDeleteCommandSuccess(t *testing.T) {
 
 	config.Client = nil
 }
+
+func TestGenericConnectionDeleteCommandInUse(t *testing.T) {
+	deleted := false
+
+	// setup http request handler
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/datasets":
+			assert.Equal(t, "TestConn0", r.URL.Query().Get("connections"))
+			w.Write([]byte(`{"datasets":{"values":[
+				{"sourceType":"Elastic","name":"index-a","connectionName":"TestConn0"},
+				{"sourceType":"Elastic","name":"index-b","connectionName":"Other"}
+			],"pagesAmount":1,"totalCount":2}}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(connectionGetResponse))
+		case r.Method == http.MethodDelete:
+			deleted = true
+		}
+	})
+	// setup http client
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	cmd := NewGenericConnectionDeleteCommand()
+	_, err = test.ExecuteCommand(cmd, "--name=TestConn0")
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "datasets [index-a]")
+	assert.False(t, deleted)
+
+	cmd = NewGenericConnectionDeleteCommand()
+	output, err := test.ExecuteCommand(cmd, "--name=TestConn0", "--force")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Lenses connection has been successfully deleted.\n", output)
+	assert.True(t, deleted)
+
+	config.Client = nil
+}
@@ -3,17 +3,30 @@ package connector
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
+// connectorWithState is a connector along with its current state, as listed by the `connectors` command.
+type connectorWithState struct {
+	api.Connector `yaml:",inline" header:"inline"`
+	State         string `json:"state,omitempty" yaml:"state,omitempty" header:"State"`
+}
+
 // NewConnectorsCommand creates the `connectors` command
 func NewConnectorsCommand() *cobra.Command {
 	var (
@@ -22,19 +35,24 @@ func NewConnectorsCommand() *cobra.Command {
 		namesOnly bool // if true then print only the connector names and not the details as json.
 		unwrap    bool // if true and namesOnly is true then print just the connectors names as a list of strings.
 
-		showSupportedOnly bool // if true then show only the supported Kafka Connectors (static info).
+		showSupportedOnly bool   // if true then show only the supported Kafka Connectors (static info).
+		connectorType     string // if not empty then show only the supported Kafka Connectors of that type.
 	)
 
 	root := &cobra.Command{
 		Use:              "connectors",
 		Short:            "List of active connectors' names",
 		Aliases:          []string{"connect"},
-		Example:          `connectors [--supported] or connectors --cluster-name="cluster_name" or --cluster-name="*"`,
+		Example:          `connectors [--supported [--type source]] or connectors --cluster-name="cluster_name" or --cluster-name="*"`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if utils.GetQuietFlag(cmd) {
+				namesOnly, unwrap = true, true
+			}
+
 			if showSupportedOnly {
-				connectorsInfo, err := config.Client.GetSupportedConnectors()
+				connectorsInfo, err := config.Client.GetSupportedConnectorsByType(connectorType)
 				if err != nil {
 					golog.Errorf("Failed to find connector pugins. [%s]", err.Error())
 					return err
@@ -60,7 +78,7 @@ func NewConnectorsCommand() *cobra.Command {
 					return bite.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", names))
 				}
 
-				return bite.PrintObject(cmd, connectorsInfo)
+				return utils.PrintObject(cmd, connectorsInfo)
 			}
 
 			connectorNames := make(map[string][]string) // clusterName:[] connectors names.
@@ -112,21 +130,33 @@ func NewConnectorsCommand() *cobra.Command {
 
 			// if table mode view, select all connectors as a list,
 			// do not make the group "visual" based on cluster name here (still they are grouped).
-			var connectors []api.Connector
+			var connectors []connectorWithState
 			for cluster, names := range connectorNames {
-				sort.Strings(names)
-				for _, name := range names {
-					connector, err := config.Client.GetConnector(cluster, name)
-					if err != nil {
-						fmt.Fprintf(cmd.OutOrStderr(), "get connector error: [%v]\n", err)
-						continue
+				if len(names) == 0 {
+					continue
+				}
+
+				// fetch the connectors and their states of the cluster at once instead of requests per connector.
+				details, err := config.Client.GetConnectorsExpanded(cluster)
+				if err != nil {
+					fmt.Fprintf(cmd.OutOrStderr(), "get connectors error: [%v]\n", err)
+					continue
+				}
+
+				for _, detail := range details {
+					connector := api.Connector{ClusterName: cluster, Name: detail.Name, Config: detail.Config}
+					for _, task := range detail.Tasks {
+						connector.Tasks = append(connector.Tasks, api.ConnectorTaskReadOnly{Connector: detail.Name, Task: task.ID})
 					}
 
-					connectors = append(connectors, connector)
+					connectors = append(connectors, connectorWithState{
+						Connector: connector,
+						State:     detail.State,
+					})
 				}
 			}
 
-			return bite.PrintObject(cmd, connectors)
+			return utils.PrintObject(cmd, connectors)
 		},
 	}
 
@@ -134,8 +164,12 @@ func NewConnectorsCommand() *cobra.Command {
 	root.Flags().BoolVar(&namesOnly, "names", false, `Print connector names only`)
 	root.Flags().BoolVar(&unwrap, "unwrap", false, "--unwrap")
 	root.Flags().BoolVar(&showSupportedOnly, "supported", false, "List all the supported Kafka Connectors instead of the currently deployed")
+	root.Flags().StringVar(&connectorType, "type", "", `Filter the supported Kafka Connectors by type, "source" or "sink"`)
 
 	bite.CanPrintJSON(root)
+	utils.CanSelectColumns(root)
+	utils.CanUseTemplate(root)
+	utils.CanBeQuiet(root)
 
 	// plugins subcommand.
 	root.AddCommand(NewGetConnectorsPluginsCommand())
@@ -143,9 +177,54 @@ func NewConnectorsCommand() *cobra.Command {
 	// clusters subcommand.
 	root.AddCommand(NewGetConnectorsClustersCommand())
 
+	// restart subcommand.
+	root.AddCommand(NewConnectorsRestartCommand())
+
 	return root
 }
 
+// NewConnectorsRestartCommand creates the `connectors restart` command
+func NewConnectorsRestartCommand() *cobra.Command {
+	var (
+		clusterName string
+		names       []string
+		pause       time.Duration
+		onlyFailed  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restart",
+		Short: "Restart many connectors one after the other",
+		Long: `Restart the connectors of a cluster, and their tasks, one after the other with a pause between them,
+i.e. after a configuration change, so the connect cluster is not overwhelmed. All the connectors are restarted if no --name is given.`,
+		Example:          `connectors restart --cluster-name="cluster_name" [--name="connector_name" --name="other_connector"] [--pause=10s] [--only-failed]`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName}); err != nil {
+				return err
+			}
+
+			results, err := config.Client.RestartConnectors(clusterName, names, pause, onlyFailed)
+			if results != nil {
+				if printErr := bite.PrintObject(cmd, results); printErr != nil {
+					return printErr
+				}
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", "Connect cluster name")
+	cmd.Flags().StringArrayVar(&names, "name", nil, "Connector name, can be repeated, all the connectors of the cluster if not set")
+	cmd.Flags().DurationVar(&pause, "pause", 5*time.Second, "Time to wait between two restarts")
+	cmd.Flags().BoolVar(&onlyFailed, "only-failed", false, "Restart only the connectors and tasks that are in a FAILED state")
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
 // NewGetConnectorsPluginsCommand creates the `connectors plugins` command
 func NewGetConnectorsPluginsCommand() *cobra.Command {
 	var clusterName string
@@ -196,6 +275,44 @@ func NewGetConnectorsPluginsCommand() *cobra.Command {
 
 	bite.CanPrintJSON(cmd)
 
+	cmd.AddCommand(NewInstallConnectorPluginCommand())
+
+	return cmd
+}
+
+// NewInstallConnectorPluginCommand creates the `connectors plugins install` command
+func NewInstallConnectorPluginCommand() *cobra.Command {
+	var clusterName, file string
+
+	cmd := &cobra.Command{
+		Use:              "install",
+		Short:            "Upload a connector plugin archive (jar or zip) to a Kafka Connect cluster",
+		Example:          `connectors plugins install --cluster-name="cluster_name" --file=./my-connector.jar`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName, "file": file}); err != nil {
+				return err
+			}
+
+			archive, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer archive.Close()
+
+			if err := config.Client.InstallConnectorPlugin(clusterName, archive, file); err != nil {
+				return err
+			}
+
+			return bite.PrintInfo(cmd, "Connector plugin [%s] installed on cluster [%s]", filepath.Base(file), clusterName)
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
+	cmd.Flags().StringVar(&file, "file", "", "The connector plugin archive, jar or zip file")
+	bite.CanBeSilent(cmd)
+
 	return cmd
 }
 
@@ -285,11 +402,14 @@ func NewConnectorGroupCommand() *cobra.Command {
 	root.AddCommand(NewConnectorUpdateCommand())
 	root.AddCommand(NewConnectorGetConfigCommand())
 	root.AddCommand(NewConnectorGetStatusCommand())
+	root.AddCommand(NewConnectorDescribeCommand())
 	root.AddCommand(NewConnectorPauseCommand())
 	root.AddCommand(NewConnectorResumeCommand())
 	root.AddCommand(NewConnectorRestartCommand())
+	root.AddCommand(NewConnectorResetTopicsCommand())
 	root.AddCommand(NewConnectorGetTasksCommand())
 	root.AddCommand(NewConnectorDeleteCommand())
+	root.AddCommand(NewConnectorTemplateCommand())
 	// connector.task subcommands.
 	root.AddCommand(NewConnectorTaskGroupCommand())
 
@@ -421,11 +541,15 @@ func NewConnectorUpdateCommand() *cobra.Command {
 // NewConnectorGetConfigCommand creates the `connector config` command
 func NewConnectorGetConfigCommand() *cobra.Command {
 	var clusterName, name string
+	var maskSecrets bool
+	var secretPatterns []string
 
 	cmd := &cobra.Command{
-		Use:              "config",
-		Short:            "Get connector config",
-		Example:          `connector config --cluster-name="cluster_name" --name="connector_name"`,
+		Use:   "config",
+		Short: "Get connector config",
+		Example: `connector config --cluster-name="cluster_name" --name="connector_name"
+connector config --cluster-name="cluster_name" --name="connector_name" --mask-secrets
+connector config --cluster-name="cluster_name" --name="connector_name" --mask-secrets --secret-patterns="*password*,*.url"`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -439,6 +563,10 @@ func NewConnectorGetConfigCommand() *cobra.Command {
 				return err
 			}
 
+			if maskSecrets {
+				cfg = api.MaskConnectorConfig(cfg, secretPatterns)
+			}
+
 			// return printJSON(cmd, cfg)
 			return bite.PrintObject(cmd, cfg)
 		},
@@ -446,12 +574,91 @@ func NewConnectorGetConfigCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
 	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
+	cmd.Flags().BoolVar(&maskSecrets, "mask-secrets", false, `Redact the values of the secret-bearing configuration keys`)
+	cmd.Flags().StringSliceVar(&secretPatterns, "secret-patterns", api.DefaultSecretConfigPatterns, `Patterns of the configuration keys to redact with --mask-secrets, e.g. "*password*,*.credentials"`)
 
 	bite.CanPrintJSON(cmd)
 
 	return cmd
 }
 
+// NewConnectorTemplateCommand creates the `connector template` command
+func NewConnectorTemplateCommand() *cobra.Command {
+	var clusterName, name, class string
+
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Print a ready-to-edit connector configuration of a connector plugin",
+		Long: `Print a ready-to-edit connector configuration of a connector plugin as YAML,
+the required keys are empty and the keys of high importance are set to their default value,
+every key is documented by a comment. Edit it and pass it to the 'connector create --file'.`,
+		Example:          `connector template --cluster-name="cluster_name" --class="io.confluent.connect.jdbc.JdbcSourceConnector" > jdbc-source.yml`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName, "class": class}); err != nil {
+				return err
+			}
+
+			defs, err := config.Client.GetConnectorPluginConfigDef(clusterName, class)
+			if err != nil {
+				golog.Errorf("Failed to retrieve the config definition of [%s] in cluster [%s]. [%s]", class, clusterName, err.Error())
+				return err
+			}
+
+			template := api.NewConnectorConfigTemplate(class, defs)
+			return writeConnectorTemplate(cmd.OutOrStdout(), clusterName, name, template, defs)
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
+	cmd.Flags().StringVar(&class, "class", "", `The connector plugin class, i.e. "io.confluent.connect.jdbc.JdbcSourceConnector"`)
+	cmd.Flags().StringVar(&name, "name", "", `Connector name to set on the template`)
+
+	return cmd
+}
+
+// writeConnectorTemplate writes the "template" as the YAML file of the 'connector create --file' command,
+// the keys are sorted and documented by a comment taken from their "defs".
+func writeConnectorTemplate(w io.Writer, clusterName, name string, template api.ConnectorConfig, defs []api.ConnectorConfigDef) error {
+	docs := make(map[string]string, len(defs))
+	for _, def := range defs {
+		docs[def.Name] = strings.Join(strings.Fields(def.Documentation), " ")
+		if def.Required && def.DefaultValue == nil {
+			docs[def.Name] = strings.TrimSpace("(required) " + docs[def.Name])
+		}
+	}
+
+	keys := make([]string, 0, len(template))
+	for k := range template {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "clusterName: %s\nname: %s\nconfig:\n", yamlScalar(clusterName), yamlScalar(name))
+
+	for _, k := range keys {
+		if doc := docs[k]; doc != "" {
+			fmt.Fprintf(&b, "  # %s\n", doc)
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", yamlScalar(k), yamlScalar(fmt.Sprintf("%v", template[k])))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// yamlScalar returns "s" as a YAML scalar, quoted when necessary, i.e. an empty value.
+func yamlScalar(s string) string {
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return strconv.Quote(s)
+	}
+
+	return strings.TrimSuffix(string(b), "\n")
+}
+
 // NewConnectorGetStatusCommand creates the `connector status` command
 func NewConnectorGetStatusCommand() *cobra.Command {
 	var clusterName, name string
@@ -486,6 +693,38 @@ func NewConnectorGetStatusCommand() *cobra.Command {
 	return cmd
 }
 
+// NewConnectorDescribeCommand creates `connector describe` command
+func NewConnectorDescribeCommand() *cobra.Command {
+	var clusterName, name string
+
+	cmd := &cobra.Command{
+		Use:              "describe",
+		Short:            "Get the configuration, tasks and state of a connector",
+		Example:          `connector describe --cluster-name="cluster_name" --name="connector_name"`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName, "name": name}); err != nil {
+				return err
+			}
+
+			detail, err := config.Client.GetConnectorDetail(clusterName, name)
+			if err != nil {
+				golog.Errorf("Failed to retrieve details for connector [%s] in cluster [%s]. [%s]", name, clusterName, err.Error())
+				return err
+			}
+
+			return bite.PrintObject(cmd, detail)
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
+	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
 // NewConnectorPauseCommand creates the `connector pause` command
 func NewConnectorPauseCommand() *cobra.Command {
 	var clusterName, name string
@@ -550,12 +789,15 @@ func NewConnectorResumeCommand() *cobra.Command {
 
 // NewConnectorRestartCommand creates the `connector restart` command
 func NewConnectorRestartCommand() *cobra.Command {
-	var clusterName, name string
+	var (
+		clusterName, name        string
+		includeTasks, onlyFailed bool
+	)
 
 	cmd := &cobra.Command{
 		Use:              "restart",
 		Short:            "Restart a connector",
-		Example:          `connector restart --cluster-name="cluster_name" --name="connector_name"`,
+		Example:          `connector restart --cluster-name="cluster_name" --name="connector_name" [--include-tasks --only-failed]`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -563,8 +805,9 @@ func NewConnectorRestartCommand() *cobra.Command {
 				return err
 			}
 
-			if err := config.Client.RestartConnector(clusterName, name); err != nil {
+			if err := config.Client.RestartConnectorWithOptions(clusterName, name, includeTasks, onlyFailed); err != nil {
 				golog.Errorf("Failed to restart connector [%s] in cluster [%s]. [%s]", name, clusterName, err.Error())
+				return err
 			}
 
 			return bite.PrintInfo(cmd, "Connector [%s:%s] restarted", clusterName, name)
@@ -573,6 +816,39 @@ func NewConnectorRestartCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name"`)
 	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
+	cmd.Flags().BoolVar(&includeTasks, "include-tasks", false, "Restart the connector's tasks too")
+	cmd.Flags().BoolVar(&onlyFailed, "only-failed", false, "Restart only the connector and tasks that are in a FAILED state")
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
+// NewConnectorResetTopicsCommand creates `connector reset-topics` command
+func NewConnectorResetTopicsCommand() *cobra.Command {
+	var clusterName, name string
+
+	cmd := &cobra.Command{
+		Use:              "reset-topics",
+		Short:            "Reset the set of active topics of a connector",
+		Example:          `connector reset-topics --cluster-name="cluster_name" --name="connector_name"`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName, "name": name}); err != nil {
+				return err
+			}
+
+			if err := config.Client.ResetConnectorActiveTopics(clusterName, name); err != nil {
+				golog.Errorf("Failed to reset the active topics of connector [%s] in cluster [%s]. [%s]", name, clusterName, err.Error())
+				return err
+			}
+
+			return bite.PrintInfo(cmd, "Connector [%s:%s] active topics reset", clusterName, name)
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
+	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
 	bite.CanBeSilent(cmd)
 
 	return cmd
@@ -717,6 +993,10 @@ func NewConnectorDeleteCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete connector [%s:%s]?", clusterName, name); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteConnector(clusterName, name); err != nil {
 				golog.Errorf("Failed to delete connector [%s] in cluster [%s]. [%s]", name, clusterName, err.Error())
 				return err
@@ -729,6 +1009,7 @@ func NewConnectorDeleteCommand() *cobra.Command {
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
 	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
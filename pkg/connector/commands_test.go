@@ -0,0 +1,203 @@
+package connector
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/test"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestConnectorsCommandShowsState(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/proxy-connect/dev/connectors" && len(r.URL.Query()["expand"]) == 2:
+			w.Write([]byte(`{"sink": {
+				"info": {"name": "sink", "config": {"connector.class": "FileStreamSink"}, "tasks": [{"connector": "sink", "task": 0}]},
+				"status": {"name": "sink", "connector": {"state": "RUNNING", "worker_id": "w1"}, "tasks": [{"id": 0, "state": "RUNNING"}]}
+			}}`))
+		case r.URL.Path == "/api/proxy-connect/dev/connectors":
+			w.Write([]byte(`["sink"]`))
+		default:
+			t.Errorf("unexpected request to [%s]", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	var outputValue string
+	cmd := NewConnectorsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "--cluster-name", "dev")
+	assert.Nil(t, err)
+
+	var connectors []map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(output), &connectors))
+	assert.Len(t, connectors, 1)
+	assert.Equal(t, "sink", connectors[0]["name"])
+	assert.Equal(t, "RUNNING", connectors[0]["state"])
+	assert.Len(t, connectors[0]["tasks"], 1)
+
+	cmd = NewConnectorsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	output, err = test.ExecuteCommand(cmd, "--cluster-name", "dev")
+	assert.Nil(t, err)
+
+	var yamlConnectors []map[string]interface{}
+	assert.Nil(t, yaml.Unmarshal([]byte(output), &yamlConnectors))
+	if assert.Len(t, yamlConnectors, 1) {
+		assert.Equal(t, "sink", yamlConnectors[0]["name"])
+		assert.Equal(t, "RUNNING", yamlConnectors[0]["state"])
+	}
+}
+
+func TestInstallConnectorPluginCommand(t *testing.T) {
+	supported := true
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !supported {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/proxy-connect/dev/connector-plugins/install", r.URL.Path)
+
+		file, header, err := r.FormFile("file")
+		if assert.Nil(t, err) {
+			defer file.Close()
+			body, _ := io.ReadAll(file)
+			assert.Equal(t, "my-connector.jar", header.Filename)
+			assert.Equal(t, "jar contents", string(body))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	archive := filepath.Join(t.TempDir(), "my-connector.jar")
+	assert.Nil(t, os.WriteFile(archive, []byte("jar contents"), 0644))
+
+	output, err := test.ExecuteCommand(NewInstallConnectorPluginCommand(), "--cluster-name", "dev", "--file", archive)
+	assert.Nil(t, err)
+	test.CheckStringContains(t, output, "Connector plugin [my-connector.jar] installed on cluster [dev]")
+
+	supported = false
+	_, err = test.ExecuteCommand(NewInstallConnectorPluginCommand(), "--cluster-name", "dev", "--file", archive)
+	assert.True(t, errors.Is(err, api.ErrPluginInstallNotSupported))
+}
+
+func TestConnectorTemplateCommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/proxy-connect/dev/connector-plugins/JdbcSourceConnector/config/validate", r.URL.Path)
+
+		w.Write([]byte(`{"name": "JdbcSourceConnector", "configs": [
+			{"definition": {"name": "connection.url", "type": "STRING", "required": true, "default_value": null, "importance": "HIGH", "documentation": "JDBC connection URL."}},
+			{"definition": {"name": "mode", "type": "STRING", "required": false, "default_value": "bulk", "importance": "HIGH", "documentation": "The mode for updating a table\neach time it is polled."}},
+			{"definition": {"name": "batch.max.rows", "type": "INT", "required": false, "default_value": "100", "importance": "LOW", "documentation": "Maximum rows per batch."}}
+		]}`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	output, err := test.ExecuteCommand(NewConnectorTemplateCommand(), "--cluster-name", "dev", "--class", "JdbcSourceConnector", "--name", "jdbc")
+	assert.Nil(t, err)
+
+	expected := `clusterName: dev
+name: jdbc
+config:
+  # (required) JDBC connection URL.
+  connection.url: ""
+  connector.class: JdbcSourceConnector
+  # The mode for updating a table each time it is polled.
+  mode: bulk
+`
+	assert.Equal(t, expected, output)
+
+	var payload api.CreateUpdateConnectorPayload
+	assert.Nil(t, yaml.Unmarshal([]byte(output), &payload))
+	assert.Equal(t, "bulk", payload.Config["mode"])
+}
+
+func TestConnectorsRestartCommand(t *testing.T) {
+	var restarted []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/restart"):
+			assert.Equal(t, "true", r.URL.Query().Get("onlyFailed"))
+			restarted = append(restarted, strings.Split(r.URL.Path, "/")[5])
+		case r.URL.Path == "/api/proxy-connect/dev/connectors" && r.URL.Query().Get("expand") == "status":
+			w.Write([]byte(`{
+				"ok": {"status": {"name": "ok", "connector": {"state": "RUNNING"}}},
+				"broken": {"status": {"name": "broken", "connector": {"state": "FAILED"}}},
+				"broken-task": {"status": {"name": "broken-task", "connector": {"state": "RUNNING"}, "tasks": [{"id": 0, "state": "FAILED"}]}}
+			}`))
+		case r.URL.Path == "/api/proxy-connect/dev/connectors":
+			w.Write([]byte(`["ok", "broken", "broken-task"]`))
+		default:
+			t.Errorf("unexpected request to [%s %s]", r.Method, r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	cmd := NewConnectorsRestartCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "--cluster-name=dev", "--only-failed", "--pause=1ms")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"broken", "broken-task"}, restarted)
+
+	var results []api.RestartResult
+	assert.Nil(t, json.Unmarshal([]byte(output), &results))
+	assert.Equal(t, []api.RestartResult{
+		{Name: "ok", Skipped: true},
+		{Name: "broken", Restarted: true},
+		{Name: "broken-task", Restarted: true},
+	}, results)
+}
+
+func TestConnectorDeleteCommandWithYes(t *testing.T) {
+	var deleted bool
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/proxy-connect/dev/connectors/sink", r.URL.Path)
+		deleted = true
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	_, err = test.ExecuteCommand(NewConnectorDeleteCommand(), "--cluster-name", "dev", "--name", "sink", "--yes")
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+}
@@ -39,6 +39,8 @@ const (
 	AlertsSettingsPath         = "api/v1/alert/settings"
 	AlertEventsPath            = "api/v1/alert/events"
 	MetadataTopicsPath         = "api/v1/metadata/topics"
+	KafkaClusterPath           = "api/v1/kafka/cluster"
+	KafkaClusterMetricsPath    = "api/v1/kafka/cluster/metrics"
 
 	LicensePath    = "api/v1/license"
 	FileUploadPath = "api/v1/files"
@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +17,23 @@ const (
 	consumersCmdDescLong string = "APIs for managing Kafka consumer groups."
 	offsetsCmdDescLong   string = "APIs for managing Kafka consumer groups partition offsets."
 
+	getCmdDescLong string = "Print the coordinator, state and the per-member partition assignment of a consumer group."
+	getCmdExample  string = `
+  # Print the members of a consumer group and their assigned partitions
+  lenses-cli consumers get --group <group_name> --output json`
+
+	listCmdDescLong string = "Print the consumer groups which consume from a topic."
+	listCmdExample  string = `
+  # Print the consumer groups of a topic before deleting or reconfiguring it
+  lenses-cli consumers list --topic <topic_name>`
+
+	deleteCmdDescLong string = "Deletes an empty consumer group, groups with active members cannot be deleted."
+	deleteCmdExample  string = `
+  # Delete a stale consumer group
+  lenses-cli consumers delete --group <group_name>`
+	deleteCmdSuccess string = "Consumer group has been deleted"
+	deleteCmdFailure string = "Delete consumer group has failed"
+
 	updateSingleCmdDescLong string = "Updates consumer group offsets for a single partition of a single topic."
 	updateSingleCmdExample  string = `
   # Update a single topic's partition offset to the specified value
@@ -38,6 +59,15 @@ const (
 
   # Update all partitions of multiple topics to the latest offset available
   lenses-cli consumers offsets update-multiple-partitions --group <group_name> --topic <topic_name> --to-latest`
+	resetTimestampCmdDescLong string = "Resets consumer group offsets of a topic's partition, or of all its partitions, to the first records at or after a timestamp. The group must be inactive."
+	resetTimestampCmdExample  string = `
+  # Reprocess everything of a partition since a datetime (RFC3339 format)
+  lenses-cli consumers offsets reset-to-timestamp --group <group_name> --topic <topic_name> --partition <partition_id> --to-datetime 2021-06-01T09:00:00Z
+
+  # Reprocess everything of all the partitions since a unix timestamp in milliseconds
+  lenses-cli consumers offsets reset-to-timestamp --group <group_name> --topic <topic_name> --all-partitions --to-datetime 1622538000000`
+	resetTimestampCmdSuccess string = "Reset offsets to timestamp has succeeded"
+
 	updateMultipleCmdSuccess string = "Bulk update offsets for a consumer group has succeeded"
 	updateMultipleCmdFailure string = "Bulk update offsets for a consumer group has failed!"
 )
@@ -47,6 +77,7 @@ var errMissingSinglePartitionFlag = errors.New("required flags, \"to-offset\" or
 var errMissingMultiplePartitionsFlag = errors.New("required flags, \"to-datetime\" or \"to-earliest\" or \"to-latest\" not set")
 var errTopicMissing = errors.New("required flag \"topic\" not set")
 var errTopicsMissing = errors.New("required flags \"topic\" or \"all-topics\" not set")
+var errMissingPartitionFlag = errors.New("required flags \"partition\" or \"all-partitions\" not set")
 
 // NewRootCommand registers the `consumers` subcommand to Cobra and returns it
 func NewRootCommand() *cobra.Command {
@@ -58,6 +89,98 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newOffsetsCommand())
+	cmd.AddCommand(newGetCommand())
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newDeleteCommand())
+
+	return cmd
+}
+
+func newGetCommand() *cobra.Command {
+	var group string
+
+	cmd := &cobra.Command{
+		Use:              "get",
+		Short:            getCmdDescLong,
+		Long:             getCmdDescLong,
+		Example:          getCmdExample,
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			detail, err := config.Client.GetConsumerGroupDetail(group)
+			if err != nil {
+				return err
+			}
+
+			return bite.PrintObject(cmd, detail)
+		},
+	}
+
+	cmd.Flags().StringVarP(&group, "group", "g", "", "Consumer Group ID")
+	cmd.MarkFlagRequired("group")
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	var topic string
+
+	cmd := &cobra.Command{
+		Use:              "list",
+		Short:            listCmdDescLong,
+		Long:             listCmdDescLong,
+		Example:          listCmdExample,
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groups, err := config.Client.GetConsumerGroupsForTopic(topic)
+			if err != nil {
+				return err
+			}
+
+			if bite.ExpectsFeedback(cmd) && len(groups) == 0 {
+				return bite.PrintInfo(cmd, "No consumer groups consume from topic [%s]", topic)
+			}
+
+			return bite.PrintObject(cmd, groups)
+		},
+	}
+
+	cmd.Flags().StringVar(&topic, "topic", "", "The topic name")
+	cmd.MarkFlagRequired("topic")
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+func newDeleteCommand() *cobra.Command {
+	var group string
+
+	cmd := &cobra.Command{
+		Use:              "delete",
+		Short:            deleteCmdDescLong,
+		Long:             deleteCmdDescLong,
+		Example:          deleteCmdExample,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.Confirm(cmd, "Delete consumer group [%s]?", group); err != nil {
+				return err
+			}
+
+			if err := config.Client.DeleteConsumerGroup(group); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", deleteCmdFailure, err)
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), deleteCmdSuccess)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&group, "group", "g", "", "Consumer Group ID")
+	cmd.MarkFlagRequired("group")
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
@@ -78,6 +201,7 @@ func newOffsetsCommand() *cobra.Command {
 	cmd.MarkPersistentFlagRequired("group")
 	cmd.AddCommand(newOffsetsUpdateSinglePartition())
 	cmd.AddCommand(newOffsetsUpdateMultiplePartition())
+	cmd.AddCommand(newOffsetsResetToTimestamp())
 
 	return cmd
 }
@@ -197,3 +321,66 @@ func newOffsetsUpdateMultiplePartition() *cobra.Command {
 
 	return cmd
 }
+
+func newOffsetsResetToTimestamp() *cobra.Command {
+	var (
+		partition     int
+		allPartitions bool
+		toDatetime    string
+	)
+	cmd := &cobra.Command{
+		Use:           "reset-to-timestamp",
+		Long:          resetTimestampCmdDescLong,
+		Example:       resetTimestampCmdExample,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topicsList, _ := cmd.Flags().GetStringSlice("topic")
+			if len(topicsList) == 0 {
+				return errTopicMissing
+			}
+			if len(topicsList) > 1 {
+				return errMultipleTopics
+			}
+
+			if allPartitions {
+				partition = api.AllPartitions
+			} else if !cmd.Flags().Changed("partition") {
+				return errMissingPartitionFlag
+			}
+
+			ts, err := parseDatetime(toDatetime)
+			if err != nil {
+				return err
+			}
+
+			groupID, _ := cmd.Flags().GetString("group")
+			if err := config.Client.ResetConsumerGroupToTimestamp(groupID, topicsList[0], partition, ts); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), resetTimestampCmdSuccess)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&partition, "partition", 0, "The partition ID")
+	cmd.Flags().BoolVar(&allPartitions, "all-partitions", false, "Reset all the partitions of the topic")
+	cmd.Flags().StringVar(&toDatetime, "to-datetime", "", "The target RFC3339 datetime or unix timestamp in milliseconds")
+	cmd.MarkFlagRequired("to-datetime")
+
+	return cmd
+}
+
+// parseDatetime parses the "value" as RFC3339 datetime or as unix timestamp in milliseconds.
+func parseDatetime(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --to-datetime value [%s], expected RFC3339 datetime or unix timestamp in milliseconds", value)
+	}
+
+	return t, nil
+}
@@ -2,6 +2,7 @@ package consumers
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"testing"
 
@@ -256,3 +257,95 @@ func TestContextCommands(t *testing.T) {
 
 	test.RunCommandTests(t, scenarios)
 }
+
+func TestDeleteConsumerGroup(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/consumers/active-group" {
+			w.Write([]byte(`{"id":"active-group","state":"Stable","consumers":[{"topic":"foo","partition":0,"consumerId":"c1"}]}`))
+			return
+		}
+		if r.Method == http.MethodGet && r.URL.Path == "/api/consumers/joining-group" {
+			w.Write([]byte(`{"id":"joining-group","state":"PreparingRebalance","consumers":[]}`))
+			return
+		}
+		if r.Method == http.MethodDelete && r.URL.Path != "/api/consumers/stale-group" {
+			t.Errorf("unexpected delete of [%s]", r.URL.Path)
+		}
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"id":"stale-group","state":"Empty","consumers":[]}`))
+			return
+		}
+		w.Write([]byte(nil))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	out, err := test.ExecuteCommand(NewRootCommand(), "delete", "--group", "stale-group", "--yes")
+	assert.Nil(t, err)
+	test.CheckStringContains(t, out, deleteCmdSuccess)
+
+	out, err = test.ExecuteCommand(NewRootCommand(), "delete", "--group", "active-group")
+	assert.NotNil(t, err)
+	test.CheckStringContains(t, out, "has [1] active members")
+
+	out, err = test.ExecuteCommand(NewRootCommand(), "delete", "--group", "joining-group")
+	assert.NotNil(t, err)
+	test.CheckStringContains(t, out, "is [PreparingRebalance]")
+}
+
+func TestResetToTimestamp(t *testing.T) {
+	var resets []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/consumers/active-group":
+			w.Write([]byte(`{"id":"active-group","state":"Stable","consumers":[{"topic":"foo","partition":0,"consumerId":"c1"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/consumers/joining-group":
+			w.Write([]byte(`{"id":"joining-group","state":"CompletingRebalance","consumers":[]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/consumers/stale-group":
+			w.Write([]byte(`{"id":"stale-group","state":"Empty","consumers":[]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/topics/foo/offsets":
+			assert.Equal(t, "1622538000000", r.URL.Query().Get("timestamp"))
+			w.Write([]byte(`[{"partition":0,"offset":42},{"partition":1,"offset":-1}]`))
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			resets = append(resets, r.URL.Path+" "+string(body))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	_, err = test.ExecuteCommand(NewRootCommand(), "offsets", "reset-to-timestamp", "--group", "active-group", "--topic", "foo", "--all-partitions", "--to-datetime", "1622538000000")
+	assert.NotNil(t, err)
+	test.CheckStringContains(t, err.Error(), "is active")
+	assert.Empty(t, resets)
+
+	_, err = test.ExecuteCommand(NewRootCommand(), "offsets", "reset-to-timestamp", "--group", "joining-group", "--topic", "foo", "--all-partitions", "--to-datetime", "1622538000000")
+	assert.NotNil(t, err)
+	test.CheckStringContains(t, err.Error(), "is [CompletingRebalance]")
+	assert.Empty(t, resets)
+
+	_, err = test.ExecuteCommand(NewRootCommand(), "offsets", "reset-to-timestamp", "--group", "stale-group", "--topic", "foo", "--to-datetime", "1622538000000")
+	assert.Equal(t, errMissingPartitionFlag, err)
+
+	out, err := test.ExecuteCommand(NewRootCommand(), "offsets", "reset-to-timestamp", "--group", "stale-group", "--topic", "foo", "--partition", "0", "--to-datetime", "2021-06-01T09:00:00Z")
+	assert.Nil(t, err)
+	test.CheckStringContains(t, out, resetTimestampCmdSuccess)
+	assert.Equal(t, []string{`/api/consumers/stale-group/offsets/topics/foo/partitions/0 {"type":"absolute","offset":42}`}, resets)
+
+	resets = nil
+	_, err = test.ExecuteCommand(NewRootCommand(), "offsets", "reset-to-timestamp", "--group", "stale-group", "--topic", "foo", "--all-partitions", "--to-datetime", "1622538000000")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`/api/consumers/stale-group/offsets/topics/foo/partitions/0 {"type":"absolute","offset":42}`,
+		`/api/consumers/stale-group/offsets/topics/foo/partitions/1 {"type":"end"}`,
+	}, resets)
+
+	_, err = test.ExecuteCommand(NewRootCommand(), "offsets", "reset-to-timestamp", "--group", "stale-group", "--topic", "foo", "--partition", "7", "--to-datetime", "1622538000000")
+	assert.NotNil(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const metadataLong = `Description:
@@ -100,12 +101,13 @@ func ListDatasetsCmd(cl datasetsLister) *cobra.Command {
 	var query string
 	hasRecords := newDefaultingOptionalBool("any")
 	compacted := newDefaultingOptionalBool("any")
-	var connections []string
+	var connections, tags []string
 
 	cmd := &cobra.Command{
-		Use:              "list",
-		Short:            "Lists the datasets",
-		Example:          `dataset list --connections kafka --records empty`,
+		Use:   "list",
+		Short: "Lists the datasets",
+		Example: `dataset list --connections kafka --has-records=false
+dataset list --connection kafka --tag pii`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -113,6 +115,7 @@ func ListDatasetsCmd(cl datasetsLister) *cobra.Command {
 				HasRecords:  hasRecords.optBool(),
 				Compacted:   compacted.optBool(),
 				Connections: connections,
+				Tags:        tags,
 			}
 			if query != "" {
 				params.Query = &query
@@ -147,6 +150,14 @@ func ListDatasetsCmd(cl datasetsLister) *cobra.Command {
 	cmd.Flags().Var(&hasRecords, "has-records", "Record filter. List only datasets with non-zero, zero or any number of records. Allowed values: "+hasRecords.allowedVals())
 	cmd.Flags().Var(&compacted, "compacted", "Compaction filter. Lists only topics that are compacted, non-compacted or any compaction state. Implies Kafka source type. Allowed values: "+compacted.allowedVals())
 	cmd.Flags().StringSliceVar(&connections, "connections", nil, "Connection names to filter by. All connections will be included when no value is supplied.")
+	// --connection is an alias of --connections, both fill the same filter.
+	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "connection" {
+			name = "connections"
+		}
+		return pflag.NormalizedName(name)
+	})
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Tag names to filter by, can be defined multiple times. All tags will be included when no value is supplied.")
 	cmd.Flags()
 
 	return cmd
@@ -251,10 +262,7 @@ func RemoveDatasetDescriptionCmd() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			//Setting the description to empty string will result in the field being omitted from the submitted JSON
-			//which the backend will handle by unsetting the description record (see `UpdateDatasetDescription`'s
-			//`omitempty` annotation)
-			if err := config.Client.UpdateDatasetDescription(connection, name, ""); err != nil {
+			if err := config.Client.ClearDatasetDescription(connection, name); err != nil {
 				return err
 			}
 			return bite.PrintInfo(cmd, "Dataset description has been removed")
@@ -270,17 +278,27 @@ func RemoveDatasetDescriptionCmd() *cobra.Command {
 	return cmd
 }
 
-// RemoveDatasetTagsCmd unsets a dataset description
+// RemoveDatasetTagsCmd removes all or some of the dataset tags
 func RemoveDatasetTagsCmd() *cobra.Command {
 	var connection, name string
+	var tags []string
 
 	cmd := &cobra.Command{
-		Use:              "remove-tags [CONNECTION] [NAME]",
-		Short:            "Remove all tags associated to a dataset",
+		Use:   "remove-tags [CONNECTION] [NAME]",
+		Short: "Remove all tags associated to a dataset, or only the given ones",
+		Example: `dataset remove-tags --connection kafka --name mytopic
+dataset remove-tags --connection kafka --name mytopic --tag t1 --tag t2`,
 		Long:             metadataLong,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(tags) > 0 {
+				if err := config.Client.RemoveDatasetTags(connection, name, tags); err != nil {
+					return err
+				}
+				return bite.PrintInfo(cmd, "Dataset tags [%s] have been removed", strings.Join(tags, ", "))
+			}
+
 			if err := config.Client.UpdateDatasetTags(connection, name, []string{}); err != nil {
 				return err
 			}
@@ -290,6 +308,7 @@ func RemoveDatasetTagsCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&connection, "connection", "", "Name of the connection")
 	cmd.Flags().StringVar(&name, "name", "", "Name of the dataset")
+	cmd.Flags().StringArrayVar(&tags, "tag", []string{}, "tag to remove, can be defined multiple times, all tags are removed when no value is supplied")
 	cmd.MarkFlagRequired("connection")
 	cmd.MarkFlagRequired("name")
 
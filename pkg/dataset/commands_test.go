@@ -229,6 +229,42 @@ func TestDatasetRemoveTags(t *testing.T) {
 	config.Client = nil
 }
 
+func TestDatasetRemoveSomeTags(t *testing.T) {
+	var payload *api.UpdateDatasetTags
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/datasets/kafka/topicName":
+			w.Write([]byte(`{"sourceType": "Kafka", "name": "topicName", "tags": [{"name": "pii"}, {"name": "gdpr"}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/datasets/kafka/topicName/tags":
+			payload = new(api.UpdateDatasetTags)
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			json.Unmarshal(body, payload)
+		default:
+			http.Error(w, "unexpected call", http.StatusNotImplemented)
+		}
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, _ := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	config.Client = client
+
+	_, err := test.ExecuteCommand(RemoveDatasetTagsCmd(), "--connection=kafka", "--name=topicName", "--tag=pii", "--tag=missing")
+	assert.NoError(t, err)
+	require.NotNil(t, payload)
+	assert.Equal(t, []api.DatasetTag{{Name: "gdpr"}}, payload.Tags)
+
+	// removing only tags that are not set is a no-op.
+	payload = nil
+	_, err = test.ExecuteCommand(RemoveDatasetTagsCmd(), "--connection=kafka", "--name=topicName", "--tag=missing")
+	assert.NoError(t, err)
+	assert.Nil(t, payload)
+	config.Client = nil
+}
+
 func TestNewDatasetUpdateMetadataCmdFailureNoConnection(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(datasetResponse))
@@ -338,6 +374,14 @@ func TestListDatasetsCmd(t *testing.T) {
 			givenArgs:    []string{"--output=plain", "--connections=a", "--connections=b,c"},
 			expectParams: api.ListDatasetsParameters{Connections: []string{"a", "b", "c"}},
 		},
+		{
+			givenArgs:    []string{"--output=plain", "--connection=kafka", "--tag=pii", "--tag=gdpr"},
+			expectParams: api.ListDatasetsParameters{Connections: []string{"kafka"}, Tags: []string{"pii", "gdpr"}},
+		},
+		{
+			givenArgs:    []string{"--output=plain", "--connection=a", "--connections=b"},
+			expectParams: api.ListDatasetsParameters{Connections: []string{"a", "b"}},
+		},
 		{
 			givenArgs: []string{"--output=plain"},
 			givenMatches: []api.DatasetMatch{
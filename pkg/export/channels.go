@@ -21,15 +21,7 @@ func writeChannels(cmd *cobra.Command, channelsPath, channelType, channelName st
 	if channelName != "" {
 		for _, channel := range channels.Values {
 			if channelName == channel.Name {
-				// fileName := fmt.Sprintf("%s-channel-%s.%s", channelType, strings.ToLower(channel.Name), strings.ToLower(bite.GetOutPutFlag(cmd)))
-				// subDir := channelType+"-channels"
-
-				// utils.WriteFile(landscapeDir, subDir, fileName, strings.ToUpper(bite.GetOutPutFlag(cmd)), channel)
-				// fmt.Fprintf(cmd.OutOrStdout(), "exporting [%s] %s channel to base directory [%s]\n", channelName, channelType, landscapeDir)
-
-				// return nil
-
-				writeChannelToFile(cmd, channelType, channel.Name, channel)
+				return writeChannelToFile(cmd, channelType, channel.Name, channel)
 			}
 		}
 
@@ -45,7 +37,9 @@ func writeChannels(cmd *cobra.Command, channelsPath, channelType, channelName st
 	}
 
 	for _, channelForExport := range channelsForExport {
-		writeChannelToFile(cmd, channelType, channelForExport.Name, channelForExport)
+		if err := writeChannelToFile(cmd, channelType, channelForExport.Name, channelForExport); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -55,7 +49,9 @@ func writeChannelToFile(cmd *cobra.Command, channelType, channelName string, cha
 	fileName := fmt.Sprintf("%s-channel-%s.%s", channelType, strings.ToLower(channelName), strings.ToLower(bite.GetOutPutFlag(cmd)))
 	subDir := channelType + "-channels"
 
-	utils.WriteFile(landscapeDir, subDir, fileName, strings.ToUpper(bite.GetOutPutFlag(cmd)), channel)
+	if err := utils.WriteFile(landscapeDir, subDir, fileName, strings.ToUpper(bite.GetOutPutFlag(cmd)), channel); err != nil {
+		return fmt.Errorf("failed to export %s channel [%s]: [%v]", channelType, channelName, err)
+	}
 
 	fmt.Fprintf(cmd.OutOrStdout(), "exported %s channel [%s] to [%s]\n", channelType, channelName, fileName)
 
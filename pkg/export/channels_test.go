@@ -0,0 +1,29 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/bite"
+	"github.com/spf13/cobra"
+)
+
+func TestWriteChannelToFileError(t *testing.T) {
+	defer func(dir string) { landscapeDir = dir }(landscapeDir)
+
+	// a file in place of the landscape directory fails the write.
+	landscapeDir = filepath.Join(t.TempDir(), "landscape")
+	if err := os.WriteFile(landscapeDir, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var output string
+	cmd := &cobra.Command{}
+	bite.RegisterOutPutFlag(cmd, &output)
+	cmd.Flags().Set("output", "yaml")
+
+	if err := writeChannelToFile(cmd, "alert", "slack", struct{}{}); err == nil {
+		t.Error("got no error, want the error of the failed write")
+	}
+}
@@ -70,6 +70,8 @@ export serviceaccounts --dir serviceaccounts`,
 	}
 
 	cmd.MarkPersistentFlagRequired("dir")
+	cmd.PersistentFlags().IntVar(&concurrency, "concurrency", 4, "Maximum number of resources to export concurrently")
+	cmd.PersistentFlags().BoolVar(&showProgress, "progress", false, "Print the export progress to the standard error")
 	cmd.AddCommand(NewExportAclsCommand())
 	cmd.AddCommand(NewExportAlertsCommand())
 	cmd.AddCommand(NewExportConnectorsCommand())
@@ -55,6 +55,12 @@ func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string,
 		return err
 	}
 
+	type clusterConnector struct {
+		cluster, name string
+	}
+
+	var selected []clusterConnector
+
 	for _, cluster := range clusters {
 
 		connectorNames, err := client.GetConnectors(cluster)
@@ -77,33 +83,55 @@ func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string,
 				continue
 			}
 
-			connector, err := client.GetConnector(cluster, connectorName)
-			if err != nil {
-				return err
-			}
+			selected = append(selected, clusterConnector{cluster, connectorName})
+		}
+	}
 
-			if connector.Config[connectorClassKey] == sqlConnectorClass {
-				continue
-			}
+	// the dependents are written after all connectors, in order, as they share files,
+	// only the ids of the exported connectors are kept, each connector is written to its file as soon as it's fetched.
+	exported := make([]string, len(selected))
 
-			request := connector.ConnectorAsRequest()
+	err = exportConcurrently(len(selected), concurrency, newProgress(cmd, "connectors"), func(i int) error {
+		cluster, connectorName := selected[i].cluster, selected[i].name
 
-			output := strings.ToUpper(bite.GetOutPutFlag(cmd))
-			fileName := fmt.Sprintf("connector-%s-%s.%s", strings.ToLower(cluster), strings.ToLower(connectorName), strings.ToLower(output))
+		connector, err := client.GetConnector(cluster, connectorName)
+		if err != nil {
+			return err
+		}
 
-			if output == "TABLE" {
-				output = "YAML"
-			}
+		if connector.Config[connectorClassKey] == sqlConnectorClass {
+			return nil
+		}
 
-			golog.Debugf("Exporting connector [%s.%s] to [%s%s]", cluster, connectorName, landscapeDir, fileName)
-			if err := utils.WriteFile(landscapeDir, pkg.ConnectorsPath, fileName, output, request); err != nil {
-				return err
-			}
+		request := connector.ConnectorAsRequest()
+
+		output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+		fileName := fmt.Sprintf("connector-%s-%s.%s", strings.ToLower(cluster), strings.ToLower(connectorName), strings.ToLower(output))
+
+		if output == "TABLE" {
+			output = "YAML"
+		}
+
+		golog.Debugf("Exporting connector [%s.%s] to [%s%s]", cluster, connectorName, landscapeDir, fileName)
+		if err := utils.WriteFile(landscapeDir, pkg.ConnectorsPath, fileName, output, request); err != nil {
+			return err
+		}
+
+		exported[i] = fmt.Sprintf("%s:%s", connector.ClusterName, connector.Name)
+		return nil
+	})
 
-			if dependents {
-				handleDependents(cmd, client, fmt.Sprintf("%s:%s", connector.ClusterName, connector.Name))
+	if err != nil {
+		return err
+	}
+
+	if dependents {
+		for _, id := range exported {
+			if id != "" {
+				handleDependents(cmd, client, id)
 			}
 		}
 	}
+
 	return nil
 }
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+var concurrency int
+var showProgress bool
+
+// Progress reports the number of the resources exported so far out of their total,
+// it is never called concurrently.
+type Progress func(current, total int)
+
+// newProgress returns a `Progress` which prints, i.e. "exported 120/450 topics", to the standard error,
+// it returns nil if the `--progress` flag is not set.
+func newProgress(cmd *cobra.Command, kind string) Progress {
+	if !showProgress {
+		return nil
+	}
+
+	w := cmd.ErrOrStderr()
+	return func(current, total int) {
+		fmt.Fprintf(w, "exported %d/%d %s\n", current, total, kind)
+	}
+}
+
+// exportConcurrently calls "fn" for every index of [0, total) using up to "workers" goroutines.
+// Each call should write its own resource file, so the files written do not depend on the completion order.
+// All calls are completed even if some of them fail, the returned error is the one of the lowest index.
+func exportConcurrently(total, workers int, progress Progress, fn func(i int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	if workers > total {
+		workers = total
+	}
+
+	var (
+		errs    = make([]error, total)
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		done    int
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(i)
+
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done, total)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < total; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
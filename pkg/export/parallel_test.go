@@ -0,0 +1,51 @@
+package export
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestExportConcurrently(t *testing.T) {
+	var calls int32
+	var reported []int
+
+	progress := func(current, total int) {
+		if total != 10 {
+			t.Errorf("got total `%v`, want `%v`", total, 10)
+		}
+		reported = append(reported, current)
+	}
+
+	err := exportConcurrently(10, 3, progress, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 7 || i == 4 {
+			return fmt.Errorf("failed %d", i)
+		}
+		return nil
+	})
+
+	if calls != 10 {
+		t.Errorf("got `%v` calls, want `%v`", calls, 10)
+	}
+
+	if err == nil || err.Error() != "failed 4" {
+		t.Errorf("got error `%v`, want the error of the lowest index `failed 4`", err)
+	}
+
+	for i, current := range reported {
+		if current != i+1 {
+			t.Fatalf("got progress `%v`, want it to increase by one", reported)
+		}
+	}
+
+	if len(reported) != 10 {
+		t.Errorf("got `%v` progress reports, want `%v`", len(reported), 10)
+	}
+}
+
+func TestExportConcurrentlyEmpty(t *testing.T) {
+	if err := exportConcurrently(0, 4, nil, func(int) error { return fmt.Errorf("unexpected call") }); err != nil {
+		t.Error(err)
+	}
+}
@@ -62,12 +62,10 @@ func WriteSchemas(cmd *cobra.Command, client *api.Client, name string) error {
 	if err != nil {
 		return err
 	}
-	for _, sub := range subjects {
-		if err := writeSchema(output, client, sub.Name); err != nil {
-			return err
-		}
-	}
-	return nil
+
+	return exportConcurrently(len(subjects), concurrency, newProgress(cmd, "schemas"), func(i int) error {
+		return writeSchema(output, client, subjects[i].Name)
+	})
 }
 
 func writeSchema(outputFormat string, client *api.Client, name string) error {
@@ -103,16 +103,12 @@ func writeTopicsAsRequest(cmd *cobra.Command, requests []api.CreateTopicPayload)
 	// write topics
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
-	for _, topic := range requests {
-
+	return exportConcurrently(len(requests), concurrency, newProgress(cmd, "topics"), func(i int) error {
+		topic := requests[i]
 		fileName := fmt.Sprintf("topic-%s.%s", strings.ToLower(topic.TopicName), strings.ToLower(output))
 
-		if err := utils.WriteFile(landscapeDir, pkg.TopicsPath, fileName, output, topic); err != nil {
-			return err
-		}
-	}
-
-	return nil
+		return utils.WriteFile(landscapeDir, pkg.TopicsPath, fileName, output, topic)
+	})
 }
 
 func getTopicConfigOverrides(configs []api.KV) api.KV {
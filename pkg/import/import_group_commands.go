@@ -11,6 +11,7 @@ func NewImportGroupCommand() *cobra.Command {
 		Use:   "import",
 		Short: "import a landscape",
 		Example: `
+import all --dir my-landscape --continue-on-error
 import acls --landscape my-acls-dir
 import alert-settings --landscape my-acls-dir
 import connectors --landscape my-acls-dir
@@ -27,6 +28,7 @@ import serviceaccounts --dir serviceaccounts`,
 		TraverseChildren: true,
 	}
 
+	cmd.AddCommand(NewImportAllCommand())
 	cmd.AddCommand(NewImportAclsCommand())
 	cmd.AddCommand(NewImportAlertSettingsCommand())
 	cmd.AddCommand(NewImportConnectionsCommand())
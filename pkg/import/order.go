@@ -0,0 +1,199 @@
+package imports
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/v5/pkg"
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// importStep describes the import of a single resource kind of a landscape,
+// it is applied only after all the steps it depends on.
+type importStep struct {
+	name      string
+	dir       string
+	dependsOn []string
+	load      func(client *api.Client, cmd *cobra.Command, loadpath string) error
+}
+
+// landscapeImportSteps returns the import steps of all the resource kinds which reference each other,
+// i.e. topics are created after their schemas and connectors after the topics they read from or write to.
+func landscapeImportSteps() []importStep {
+	return []importStep{
+		{name: "schemas", dir: pkg.SchemasPath, load: ReadSchemas},
+		{name: "topics", dir: pkg.TopicsPath, dependsOn: []string{"schemas"}, load: loadTopics},
+		{name: "acls", dir: pkg.AclsPath, dependsOn: []string{"topics"}, load: loadAcls},
+		{name: "quotas", dir: pkg.QuotasPath, dependsOn: []string{"topics"}, load: loadQuotas},
+		{name: "processors", dir: pkg.SQLPath, dependsOn: []string{"topics"}, load: loadProcessors},
+		{name: "connectors", dir: pkg.ConnectorsPath, dependsOn: []string{"topics", "processors"},
+			load: func(client *api.Client, cmd *cobra.Command, loadpath string) error {
+				return loadConnectors(client, cmd, loadpath, "0s", 5)
+			}},
+		{name: "alert-settings", dir: pkg.AlertSettingsPath, dependsOn: []string{"topics", "processors", "connectors"},
+			load: func(client *api.Client, cmd *cobra.Command, loadpath string) error {
+				if err := loadProducerAlertSettings(client, cmd, loadpath); err != nil {
+					return err
+				}
+				return loadConsumerAlertSettings(client, cmd, loadpath)
+			}},
+	}
+}
+
+// orderImportSteps sorts the "steps" so that every step comes after the steps it depends on,
+// steps without a dependency between them keep their given order.
+// It returns an error on an unknown dependency or a dependency cycle.
+func orderImportSteps(steps []importStep) ([]importStep, error) {
+	pending := make(map[string]int, len(steps))
+	for _, step := range steps {
+		pending[step.name] = len(step.dependsOn)
+	}
+
+	for _, step := range steps {
+		for _, dep := range step.dependsOn {
+			if _, ok := pending[dep]; !ok {
+				return nil, fmt.Errorf("import step [%s] depends on the unknown step [%s]", step.name, dep)
+			}
+		}
+	}
+
+	ordered := make([]importStep, 0, len(steps))
+	applied := make(map[string]bool, len(steps))
+
+	for len(ordered) < len(steps) {
+		progressed := false
+
+		for _, step := range steps {
+			if applied[step.name] || pending[step.name] > 0 {
+				continue
+			}
+
+			ordered = append(ordered, step)
+			applied[step.name] = true
+			progressed = true
+
+			for _, dependent := range steps {
+				for _, dep := range dependent.dependsOn {
+					if dep == step.name {
+						pending[dependent.name]--
+					}
+				}
+			}
+			// start over so the given order is kept between the steps that became ready.
+			break
+		}
+
+		if !progressed {
+			var cycle []string
+			for _, step := range steps {
+				if !applied[step.name] {
+					cycle = append(cycle, step.name)
+				}
+			}
+			return nil, fmt.Errorf("import steps [%s] have cyclic dependencies", strings.Join(cycle, ", "))
+		}
+	}
+
+	return ordered, nil
+}
+
+// importStepResult is the outcome of an import step, see `runImportSteps`.
+type importStepResult struct {
+	Step   string `json:"step" yaml:"step" header:"Step"`
+	Status string `json:"status" yaml:"status" header:"Status"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty" header:"Error"`
+}
+
+const (
+	importStatusImported = "imported"
+	importStatusSkipped  = "skipped"
+	importStatusFailed   = "failed"
+	importStatusNotRun   = "not run"
+)
+
+// runImportSteps applies the "steps" in their dependency order, a step is skipped if its directory does not exist under "dir".
+// If "continueOnError" is false it stops at the first failed step and the rest are reported as not run.
+func runImportSteps(client *api.Client, cmd *cobra.Command, dir string, steps []importStep, continueOnError bool) ([]importStepResult, error) {
+	ordered, err := orderImportSteps(steps)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]importStepResult, 0, len(ordered))
+	stopped := false
+
+	for _, step := range ordered {
+		result := importStepResult{Step: step.name}
+
+		loadpath := filepath.Join(dir, step.dir)
+
+		if stopped {
+			result.Status = importStatusNotRun
+		} else if _, err := os.Stat(loadpath); os.IsNotExist(err) {
+			result.Status = importStatusSkipped
+		} else if err := step.load(client, cmd, loadpath); err != nil {
+			result.Status = importStatusFailed
+			result.Error = err.Error()
+			stopped = !continueOnError
+		} else {
+			result.Status = importStatusImported
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// NewImportAllCommand creates `import all` command
+func NewImportAllCommand() *cobra.Command {
+	var path string
+	var continueOnError bool
+
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "import all the resources of a landscape in their dependency order",
+		Long: `Import all the resources of a landscape in their dependency order:
+schemas, topics, acls and quotas, processors, connectors and alert-settings.
+Resources without a directory in the landscape are skipped.`,
+		Example:          `import all --dir my-landscape --continue-on-error`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results, err := runImportSteps(config.Client, cmd, path, landscapeImportSteps(), continueOnError)
+			if err != nil {
+				return err
+			}
+
+			var failed []string
+			for _, result := range results {
+				if result.Status == importStatusFailed {
+					failed = append(failed, result.Step)
+				}
+			}
+
+			if err := bite.PrintObject(cmd, results); err != nil {
+				return err
+			}
+
+			if len(failed) > 0 {
+				return fmt.Errorf("failed to import [%s]", strings.Join(failed, ", "))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Continue importing the next resources when a resource fails to import")
+
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	cmd.Flags().Set("silent", "true")
+	return cmd
+}
@@ -0,0 +1,146 @@
+package imports
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// fixtureImportSteps returns steps of a landscape where every resource
+// can only be applied if the resources it depends on are already present.
+func fixtureImportSteps(present map[string]bool) []importStep {
+	step := func(name string, dependsOn ...string) importStep {
+		return importStep{
+			name:      name,
+			dir:       name,
+			dependsOn: dependsOn,
+			load: func(client *api.Client, cmd *cobra.Command, loadpath string) error {
+				for _, dep := range dependsOn {
+					if !present[dep] {
+						return fmt.Errorf("%s: missing %s", name, dep)
+					}
+				}
+				present[name] = true
+				return nil
+			},
+		}
+	}
+
+	// given in reverse, a flat apply fails.
+	return []importStep{
+		step("alert-settings", "topics", "processors", "connectors"),
+		step("connectors", "topics", "processors"),
+		step("processors", "topics"),
+		step("quotas", "topics"),
+		step("acls", "topics"),
+		step("topics", "schemas"),
+		step("schemas"),
+	}
+}
+
+func makeLandscape(t *testing.T, dirs ...string) string {
+	dir := t.TempDir()
+	for _, d := range dirs {
+		if err := os.Mkdir(filepath.Join(dir, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestOrderImportSteps(t *testing.T) {
+	ordered, err := orderImportSteps(landscapeImportSteps())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, step := range ordered {
+		names = append(names, step.name)
+	}
+
+	expected := "schemas,topics,acls,quotas,processors,connectors,alert-settings"
+	if got := strings.Join(names, ","); got != expected {
+		t.Errorf("got `%v`, want `%v`", got, expected)
+	}
+}
+
+func TestOrderImportStepsErrors(t *testing.T) {
+	if _, err := orderImportSteps([]importStep{{name: "topics", dependsOn: []string{"schemas"}}}); err == nil {
+		t.Error("expected an error for an unknown dependency")
+	}
+
+	cyclic := []importStep{
+		{name: "a", dependsOn: []string{"b"}},
+		{name: "b", dependsOn: []string{"a"}},
+	}
+	if _, err := orderImportSteps(cyclic); err == nil {
+		t.Error("expected an error for cyclic dependencies")
+	}
+}
+
+func TestRunImportStepsFlatApplyFails(t *testing.T) {
+	present := make(map[string]bool)
+	for _, step := range fixtureImportSteps(present) {
+		if err := step.load(nil, nil, ""); err != nil {
+			return
+		}
+	}
+
+	t.Fatal("expected the fixture to fail when applied in the given order")
+}
+
+func TestRunImportSteps(t *testing.T) {
+	present := make(map[string]bool)
+	dir := makeLandscape(t, "schemas", "topics", "acls", "quotas", "processors", "connectors", "alert-settings")
+
+	results, err := runImportSteps(nil, nil, dir, fixtureImportSteps(present), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range results {
+		if result.Status != importStatusImported {
+			t.Errorf("got `%v`, want step `%s` to be imported", result, result.Step)
+		}
+	}
+
+	if len(present) != 7 {
+		t.Errorf("got `%v`, want all resources to be present", present)
+	}
+}
+
+func TestRunImportStepsContinueOnError(t *testing.T) {
+	// without the schemas directory the topics, and so all their dependents, fail.
+	dir := makeLandscape(t, "topics", "acls", "quotas", "processors", "connectors", "alert-settings")
+
+	results, err := runImportSteps(nil, nil, dir, fixtureImportSteps(make(map[string]bool)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := make(map[string]string)
+	for _, result := range results {
+		statuses[result.Step] = result.Status
+	}
+
+	if statuses["schemas"] != importStatusSkipped || statuses["topics"] != importStatusFailed || statuses["acls"] != importStatusNotRun {
+		t.Errorf("got `%v`, want import to stop after the failed topics", statuses)
+	}
+
+	results, err = runImportSteps(nil, nil, dir, fixtureImportSteps(make(map[string]bool)), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range results[1:] {
+		if result.Status != importStatusFailed {
+			t.Errorf("got `%v`, want step `%s` to be attempted and fail", result, result.Step)
+		}
+	}
+}
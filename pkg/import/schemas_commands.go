@@ -52,13 +52,15 @@ func NewImportSchemasCmd() *cobra.Command {
 	return cmd
 }
 
-// ReadSchemas to read the files and import one by one
+// ReadSchemas to read the files and import them,
+// schemas are registered after the subjects they reference and the unchanged ones are skipped.
 func ReadSchemas(client *api.Client, cmd *cobra.Command, filePath string) error {
 	files, err := utils.FindFiles(filePath)
 	if err != nil {
 		return err
 	}
 
+	var schemas []api.SchemaAsRequest
 	for _, file := range files {
 		var schema api.WriteSchemaReq
 		var fileName = file.Name()
@@ -69,11 +71,23 @@ func ReadSchemas(client *api.Client, cmd *cobra.Command, filePath string) error
 
 		schemaName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
 
-		if err := client.WriteSchema(schemaName, schema); err != nil {
-			return errors.Wrapf(err, "Could not import Schemas [%s]", fileName)
+		same, err := client.LookupSchema(schemaName, schema)
+		if err != nil {
+			return errors.Wrapf(err, "Could not look up Schema [%s]", schemaName)
 		}
-		golog.Infof("imported schema from file '%s'", filePath)
+
+		if same {
+			golog.Infof("schema '%s' is already registered, skipping", schemaName)
+			continue
+		}
+
+		schemas = append(schemas, api.SchemaAsRequest{Subject: schemaName, WriteSchemaReq: schema})
+	}
+
+	ids, err := client.RegisterSchemas(schemas)
+	for subject := range ids {
+		golog.Infof("imported schema '%s' from '%s'", subject, filePath)
 	}
 
-	return nil
+	return errors.Wrap(err, "Could not import Schemas")
 }
@@ -55,18 +55,14 @@ func loadTopics(client *api.Client, cmd *cobra.Command, loadpath string) error {
 	// (all other keys are disregarded, e.g. "defaultValue", "documentation", "isDefault", etc.)
 	type simplyfiedTopicPayload struct {
 		partitions int
-		configs    map[string]string
+		configs    api.KV
 	}
 	simplyfiedRemoteTopics := make(map[string]simplyfiedTopicPayload)
 
 	for _, topic := range remoteTopics {
-		configMap := make(map[string]string)
-		for _, conf := range topic.Configs {
-			configMap[fmt.Sprintf("%v", conf["name"])] = fmt.Sprintf("%v", conf["originalValue"])
-		}
 		simplyfiedRemoteTopics[topic.TopicName] = simplyfiedTopicPayload{
 			topic.Partitions,
-			configMap,
+			topic.ConfigKV(),
 		}
 	}
 
@@ -92,19 +88,15 @@ func loadTopics(client *api.Client, cmd *cobra.Command, loadpath string) error {
 				golog.Infof("Updated topic '%s' partitions with new value '%v'", topicFromFile.TopicName, topicFromFile.Partitions)
 			}
 
-			// compare the config from imported file with the config on the remote server
-			for k, v := range topicFromFile.Configs {
-
-				// If at least one config value is different then perform a single PUT on all config
-				if v != topicValue.configs[k] {
-					if err := client.UpdateTopicConfig(topicFromFile.TopicName, []api.KV{topicFromFile.Configs}); err != nil {
-						return err
-					}
-
-					golog.Infof("Updated topic '%s' config", topicFromFile.TopicName)
-					break
+			// compare the config from imported file with the config on the remote server,
+			// if at least one config value is different then perform a single PUT on all config.
+			// Server managed keys, i.e. `message.format.version`, are not counted as a change.
+			if diff := api.TopicConfigDiff(topicValue.configs, topicFromFile.Configs); len(diff) > 0 {
+				if err := client.UpdateTopicConfig(topicFromFile.TopicName, []api.KV{topicFromFile.Configs}); err != nil {
+					return err
 				}
 
+				golog.Infof("Updated topic '%s' config", topicFromFile.TopicName)
 			}
 		} else {
 			// If topic doesn't exist on the remote server then import it as new
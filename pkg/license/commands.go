@@ -7,11 +7,13 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -21,11 +23,13 @@ func NewLicenseGroupCommand() *cobra.Command {
 		Use:   "license",
 		Short: "View or update Lenses license",
 		Example: `lenses-cli license get
-lenses-cli license update --license-file <license.json>`,
+lenses-cli license update --license-file <license.json>
+lenses-cli license check --warn-days 30`,
 	}
 
 	cmd.AddCommand(NewLicenseGetCommand())
 	cmd.AddCommand(NewLicenseUpdateCommand())
+	cmd.AddCommand(NewLicenseCheckCommand())
 	return cmd
 }
 
@@ -50,6 +54,42 @@ func NewLicenseGetCommand() *cobra.Command {
 	return cmd
 }
 
+// NewLicenseCheckCommand creates the `license check` subcommand,
+// it fails when the license expires within the given days so it can be used by CI or cron jobs.
+func NewLicenseCheckCommand() *cobra.Command {
+	var warnDays int
+
+	cmd := &cobra.Command{
+		Use:           "check",
+		Short:         "Fail if the active Lenses license expires within the given days",
+		Example:       `lenses-cli license check --warn-days 30`,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if warnDays < 0 {
+				return fmt.Errorf("--warn-days should be a positive number")
+			}
+
+			lc, err := config.Client.GetLicenseInfo()
+			if err != nil {
+				return err
+			}
+
+			expiresAt := lc.ExpiresAt.Format("02 Jan 2006 15:04")
+			if lc.ExpiresWithin(time.Duration(warnDays) * 24 * time.Hour) {
+				fmt.Fprintln(cmd.ErrOrStderr(), utils.Yellow(fmt.Sprintf("Warning: the license of client [%s] expires at [%s]", lc.ClientID, expiresAt)))
+				return fmt.Errorf("license expires within %d days", warnDays)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "License of client [%s] is valid until [%s]\n", lc.ClientID, expiresAt)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&warnDays, "warn-days", 30, "Fail when the license expires within that number of days")
+
+	return cmd
+}
+
 // NewLicenseUpdateCommand creates the `license update` subcommand
 func NewLicenseUpdateCommand() *cobra.Command {
 	var licenseFilePath string
@@ -164,3 +164,36 @@ func TestParseLicenseFile(t *testing.T) {
 		})
 	}
 }
+
+func TestLicenseCheckCommand(t *testing.T) {
+	tenDaysExpiryLicense := time.Now().AddDate(0, 0, 10).UnixNano() / int64(time.Millisecond)
+
+	testsLicenseCheckCmd := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{"license expires within the window", []string{"--warn-days", "30"}, true},
+		{"license expires after the window", []string{"--warn-days", "5"}, false},
+	}
+
+	payload, _ := json.Marshal(api.LicenseInfo{ClientID: "Studio Beta", Expiry: tenDaysExpiryLicense})
+	for _, tt := range testsLicenseCheckCmd {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(payload)
+		})
+		httpClient, teardown := test.TestingHTTPClient(h)
+		defer teardown()
+		client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+		assert.Nil(t, err)
+		config.Client = client
+
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewLicenseCheckCommand()
+			_, err := test.ExecuteCommand(cmd, tt.args...)
+			if tt.expectError != (err != nil) {
+				t.Errorf("got `%v`, want error: `%v`", err, tt.expectError)
+			}
+		})
+	}
+}
@@ -3,11 +3,16 @@ package management
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 
 	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -34,6 +39,9 @@ func NewGroupsCommand() *cobra.Command {
 	root.AddCommand(NewDeleteGroupCommand())
 	root.AddCommand(NewUpdateGroupCommand())
 	root.AddCommand(NewCloneGroupCommand())
+	root.AddCommand(NewGroupPermissionsCommand())
+	root.AddCommand(NewExportGroupsRBACCommand())
+	root.AddCommand(NewImportGroupsRBACCommand())
 	return root
 }
 
@@ -155,6 +163,10 @@ func NewDeleteGroupCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete group [%s]?", name); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteGroup(name); err != nil {
 				return fmt.Errorf("Failed to delete group [%s]. [%s]", name, err.Error())
 			}
@@ -165,6 +177,7 @@ func NewDeleteGroupCommand() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Group name")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 	return cmd
 }
 
@@ -234,3 +247,226 @@ func addCreateUpdateFlags(cmd *cobra.Command, namespacesRaw *string, group *api.
 	bite.Prepend(cmd, bite.FileBind(&group))
 	bite.CanBeSilent(cmd)
 }
+
+// NewGroupPermissionsCommand creates `groups permissions`
+func NewGroupPermissionsCommand() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:              "permissions",
+		Short:            "Print the data namespaces and the permissions a group grants",
+		Example:          "groups permissions --name MyGroup",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"name": name}); err != nil {
+				return err
+			}
+
+			permissions, err := config.Client.GetGroupPermissions(name)
+			if err != nil {
+				return fmt.Errorf("Failed to find group [%s]. [%s]", name, err.Error())
+			}
+			return bite.PrintObject(cmd, permissions)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Group name")
+	bite.CanPrintJSON(cmd)
+	return cmd
+}
+
+// NewExportGroupsRBACCommand creates `groups export`
+func NewExportGroupsRBACCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the permissions of all the groups to a single file",
+		Long: `Export the data namespaces and the permissions of all the groups to a single YAML or JSON file,
+based on its extension, i.e. to review the RBAC model as code. See "groups import" to apply it back.`,
+		Example:          "groups export -f rbac.yaml",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"file": file}); err != nil {
+				return err
+			}
+
+			groups, err := config.Client.GetGroups()
+			if err != nil {
+				return fmt.Errorf("Failed to find groups. [%s]", err.Error())
+			}
+
+			sort.Slice(groups, func(i, j int) bool {
+				return groups[i].Name < groups[j].Name
+			})
+
+			permissions := make([]api.GroupPermissions, len(groups))
+			for i, group := range groups {
+				permissions[i] = api.NewGroupPermissions(group)
+			}
+
+			var b []byte
+			switch filepath.Ext(file) {
+			case ".yml", ".yaml":
+				b, err = utils.ToYaml(permissions)
+			default:
+				b, err = json.MarshalIndent(permissions, "", "  ")
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(file, b, 0644); err != nil {
+				return err
+			}
+
+			return bite.PrintInfo(cmd, "%d groups exported to [%s]", len(permissions), file)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "The YAML or JSON file to export to")
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+// groupReconcileResult is the outcome of a group of the `groups import`.
+type groupReconcileResult struct {
+	Group  string `json:"group" yaml:"group" header:"Group"`
+	Action string `json:"action" yaml:"action" header:"Action"`
+}
+
+const (
+	groupActionCreated   = "created"
+	groupActionUpdated   = "updated"
+	groupActionUnchanged = "unchanged"
+	groupActionDeleted   = "deleted"
+)
+
+// normalizeGroupPermissions returns the "permissions" with their empty lists as nil,
+// so the permissions of the box and of a file compare equal whether they omit a list or set it empty.
+func normalizeGroupPermissions(permissions api.GroupPermissions) api.GroupPermissions {
+	nilIfEmpty := func(values []string) []string {
+		if len(values) == 0 {
+			return nil
+		}
+		return values
+	}
+
+	var namespaces []api.Namespace
+	for _, namespace := range permissions.Namespaces {
+		namespace.Wildcards = nilIfEmpty(namespace.Wildcards)
+		namespace.Permissions = nilIfEmpty(namespace.Permissions)
+		namespaces = append(namespaces, namespace)
+	}
+
+	permissions.Namespaces = namespaces
+	permissions.ApplicationPermissions = nilIfEmpty(permissions.ApplicationPermissions)
+	permissions.AdminPermissions = nilIfEmpty(permissions.AdminPermissions)
+	permissions.ConnectClustersPermissions = nilIfEmpty(permissions.ConnectClustersPermissions)
+	return permissions
+}
+
+// reconcileGroups creates or updates the groups to grant the "desired" permissions,
+// groups which are not part of the "desired" ones are deleted only if "prune" is true.
+func reconcileGroups(client *api.Client, desired []api.GroupPermissions, prune bool) ([]groupReconcileResult, error) {
+	groups, err := client.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]api.GroupPermissions, len(groups))
+	for _, group := range groups {
+		current[group.Name] = api.NewGroupPermissions(group)
+	}
+
+	var results []groupReconcileResult
+	wanted := make(map[string]bool, len(desired))
+
+	for _, permissions := range desired {
+		if permissions.Name == "" {
+			return results, fmt.Errorf("a group without a name found")
+		}
+		wanted[permissions.Name] = true
+
+		group := permissions.AsGroup()
+		existing, found := current[permissions.Name]
+		switch {
+		case !found:
+			if err := client.CreateGroup(&group); err != nil {
+				return results, fmt.Errorf("Failed to create group [%s]. [%s]", group.Name, err.Error())
+			}
+			results = append(results, groupReconcileResult{group.Name, groupActionCreated})
+		case reflect.DeepEqual(normalizeGroupPermissions(existing), normalizeGroupPermissions(permissions)):
+			results = append(results, groupReconcileResult{group.Name, groupActionUnchanged})
+		default:
+			if err := client.UpdateGroup(&group); err != nil {
+				return results, fmt.Errorf("Failed to update group [%s]. [%s]", group.Name, err.Error())
+			}
+			results = append(results, groupReconcileResult{group.Name, groupActionUpdated})
+		}
+	}
+
+	if prune {
+		for _, group := range groups {
+			if wanted[group.Name] {
+				continue
+			}
+
+			if err := client.DeleteGroup(group.Name); err != nil {
+				return results, fmt.Errorf("Failed to delete group [%s]. [%s]", group.Name, err.Error())
+			}
+			results = append(results, groupReconcileResult{group.Name, groupActionDeleted})
+		}
+	}
+
+	return results, nil
+}
+
+// NewImportGroupsRBACCommand creates `groups import`
+func NewImportGroupsRBACCommand() *cobra.Command {
+	var (
+		file  string
+		prune bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Reconcile the groups with the permissions of a file",
+		Long: `Create or update the groups to grant the permissions of a YAML or JSON file, as written by "groups export".
+Groups which are not in the file are kept, unless --prune is set.`,
+		Example:          "groups import -f rbac.yaml [--prune]",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"file": file}); err != nil {
+				return err
+			}
+
+			var desired []api.GroupPermissions
+			if err := bite.TryReadFile(file, &desired); err != nil {
+				return fmt.Errorf("Failed to load groups from [%s]. [%s]", file, err.Error())
+			}
+
+			if prune {
+				if err := utils.Confirm(cmd, "Delete the groups which are not in [%s]?", file); err != nil {
+					return err
+				}
+			}
+
+			results, err := reconcileGroups(config.Client, desired, prune)
+			if printErr := bite.PrintObject(cmd, results); printErr != nil {
+				return printErr
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "The YAML or JSON file to import from")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Delete the groups which are not in the file")
+	bite.CanPrintJSON(cmd)
+	utils.CanSkipConfirmation(cmd)
+	return cmd
+}
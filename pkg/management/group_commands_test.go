@@ -3,6 +3,9 @@ package management
 import (
 	"encoding/json"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/lensesio/lenses-go/v5/pkg/api"
@@ -472,3 +475,78 @@ func TestGroupCloneSuccess(t *testing.T) {
 	assert.Equal(t, "Group [MyGroup] cloned to [MyClonedGroup].\n", output)
 	config.Client = nil
 }
+
+func TestGroupsExportImportRBAC(t *testing.T) {
+	groups := map[string]api.Group{
+		"dev":  {Name: "dev", ScopedPermissions: []string{"ViewConnectors"}, Namespaces: []api.Namespace{{Wildcards: []string{"*"}, Permissions: []string{"ShowTopic"}, Connection: "kafka"}}},
+		"prod": {Name: "prod", AdminPermissions: []string{"ViewAuditLogs"}, UserAccountsCount: 3},
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			var list []api.Group
+			for _, group := range groups {
+				list = append(list, group)
+			}
+			json.NewEncoder(w).Encode(list)
+		case http.MethodPost, http.MethodPut:
+			var group api.Group
+			json.NewDecoder(r.Body).Decode(&group)
+			groups[group.Name] = group
+		case http.MethodDelete:
+			delete(groups, strings.TrimPrefix(r.URL.Path, "/api/v1/group/"))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	file := filepath.Join(t.TempDir(), "rbac.yaml")
+	_, err = test.ExecuteCommand(NewGroupsCommand(), "export", "-f", file)
+	assert.Nil(t, err)
+
+	b, err := os.ReadFile(file)
+	assert.Nil(t, err)
+	test.CheckStringContains(t, string(b), "applicationPermissions:\n  - ViewConnectors")
+	assert.NotContains(t, string(b), "userAccounts")
+
+	// drift from the exported model.
+	groups["dev"] = api.Group{Name: "dev", ScopedPermissions: []string{"ManageConnectors"}}
+	delete(groups, "prod")
+	groups["extra"] = api.Group{Name: "extra"}
+
+	var outputValue string
+	cmd := NewGroupsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "import", "-f", file, "--prune", "-y")
+	assert.Nil(t, err)
+
+	var results []map[string]string
+	assert.Nil(t, json.NewDecoder(strings.NewReader(output)).Decode(&results))
+	assert.Equal(t, []map[string]string{
+		{"group": "dev", "action": "updated"},
+		{"group": "prod", "action": "created"},
+		{"group": "extra", "action": "deleted"},
+	}, results)
+
+	assert.Equal(t, []string{"ViewConnectors"}, groups["dev"].ScopedPermissions)
+	assert.Equal(t, []string{"ViewAuditLogs"}, groups["prod"].AdminPermissions)
+	assert.NotContains(t, groups, "extra")
+
+	output, err = test.ExecuteCommand(cmd, "import", "-f", file)
+	assert.Nil(t, err)
+	test.CheckStringContains(t, output, `"action":"unchanged"`)
+
+	config.Client = nil
+}
+
+func TestNormalizeGroupPermissions(t *testing.T) {
+	fromBox := api.GroupPermissions{Name: "dev", Namespaces: []api.Namespace{{Wildcards: []string{"*"}, Permissions: []string{}}}, AdminPermissions: []string{}}
+	fromFile := api.GroupPermissions{Name: "dev", Namespaces: []api.Namespace{{Wildcards: []string{"*"}}}}
+
+	assert.Equal(t, normalizeGroupPermissions(fromFile), normalizeGroupPermissions(fromBox))
+}
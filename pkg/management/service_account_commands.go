@@ -7,6 +7,7 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -138,6 +139,10 @@ func NewDeleteServiceAccountCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete service account [%s]?", name); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteServiceAccount(name); err != nil {
 				return fmt.Errorf("Failed to delete service account [%s]. [%s]", name, err.Error())
 			}
@@ -148,6 +153,7 @@ func NewDeleteServiceAccountCommand() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Service account name")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 	return cmd
 }
 
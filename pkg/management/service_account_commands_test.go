@@ -18,7 +18,10 @@ const serviceAccountsOkResp = `
     "owner": "paul",
     "groups": [
       "foo"
-    ]
+    ],
+    "token": "4cbddcfd-a5ca-4d6e-acc5-4f5db3c9548f",
+    "createdAt": 1600000000000,
+    "tokenActive": true
   },
   {
     "name": "sam",
@@ -62,6 +65,10 @@ func TestServiceAccountsCommandSuccess(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, 3, len(serviceAccounts))
+	assert.Equal(t, int64(1600000000000), serviceAccounts[0].CreatedAt)
+	assert.True(t, serviceAccounts[0].TokenActive)
+	assert.Empty(t, serviceAccounts[0].Token)
+	assert.NotContains(t, output, "4cbddcfd")
 
 	config.Client = nil
 }
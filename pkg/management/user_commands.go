@@ -24,16 +24,16 @@ users --groups Group1 --groups Group2
 		TraverseChildren: true,
 		SilenceErrors:    true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			users, err := config.Client.GetUsers()
+			users, err := config.Client.GetManagementUsers()
 			if err != nil {
 				golog.Errorf("Failed to find users. [%s]", err.Error())
 				return err
 			}
 
 			if len(groupNames) > 0 {
-				var filteredUsers []api.UserMember
+				var filteredUsers []api.ManagementUser
 				for _, user := range users {
-					var filteredUser api.UserMember
+					var filteredUser api.ManagementUser
 					for _, group := range groupNames {
 						if utils.StringInSlice(group, user.Groups) {
 							filteredUser = user
@@ -56,6 +56,8 @@ users --groups Group1 --groups Group2
 	root.AddCommand(NewDeleteUserCommand())
 	root.AddCommand(NewUpdateUserCommand())
 	root.AddCommand(NewPasswordUserCommand())
+	root.AddCommand(NewGetUserGroupsCommand())
+	root.AddCommand(NewSetUserGroupsCommand())
 	return root
 }
 
@@ -70,7 +72,7 @@ func NewGetUserCommand() *cobra.Command {
 		TraverseChildren: true,
 		SilenceErrors:    true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			user, err := config.Client.GetUser(userName)
+			user, err := config.Client.GetManagementUser(userName)
 			if err != nil {
 				return fmt.Errorf("Failed to find user. [%s]", err.Error())
 			}
@@ -83,6 +85,64 @@ func NewGetUserCommand() *cobra.Command {
 	return cmd
 }
 
+// NewGetUserGroupsCommand creates `users groups`
+func NewGetUserGroupsCommand() *cobra.Command {
+	var username string
+
+	cmd := &cobra.Command{
+		Use:              "groups",
+		Short:            "Print the groups a user belongs to",
+		Example:          "users groups --username=johndoe",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"username": username}); err != nil {
+				return err
+			}
+
+			groups, err := config.Client.GetUserGroups(username)
+			if err != nil {
+				return fmt.Errorf("Failed to find user groups. [%s]", err.Error())
+			}
+			return bite.PrintObject(cmd, groups)
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", `User username`)
+	bite.CanPrintJSON(cmd)
+	return cmd
+}
+
+// NewSetUserGroupsCommand creates `users set-groups`
+func NewSetUserGroupsCommand() *cobra.Command {
+	var username string
+	var groups []string
+
+	cmd := &cobra.Command{
+		Use:              "set-groups",
+		Short:            "Replace the groups a user belongs to, the groups must exist",
+		Example:          "users set-groups --username=johndoe --groups=MyGroup --groups=OtherGroup",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"username": username, "groups": groups}); err != nil {
+				return err
+			}
+
+			if err := config.Client.SetUserGroups(username, groups); err != nil {
+				return fmt.Errorf("Failed to set the groups of user [%s]. [%s]", username, err.Error())
+			}
+
+			return bite.PrintInfo(cmd, "User [%s] groups set to %v", username, groups)
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "User username")
+	cmd.Flags().StringArrayVar(&groups, "groups", []string{}, "User groups")
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
 // NewCreateUserCommand creates a new user
 func NewCreateUserCommand() *cobra.Command {
 	var user api.UserMember
@@ -182,6 +242,10 @@ func NewDeleteUserCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete user [%s]?", username); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteUser(username); err != nil {
 				return fmt.Errorf("Failed to delete user [%s]. [%s]", username, err.Error())
 			}
@@ -192,6 +256,7 @@ func NewDeleteUserCommand() *cobra.Command {
 	cmd.Flags().StringVar(&username, "username", "", "User username")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 	return cmd
 }
 
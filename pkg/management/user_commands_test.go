@@ -87,6 +87,34 @@ func TestFilteredUsersCommandSuccess(t *testing.T) {
 	config.Client = nil
 }
 
+func TestUsersCommandHidesPassword(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"username": "sam", "groups": ["foo"], "password": "secret", "lastLogin": 1700000000000}]`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	cmd := NewUsersCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd)
+	assert.Nil(t, err)
+	assert.NotContains(t, output, "secret")
+
+	var users []api.ManagementUser
+	err = json.Unmarshal([]byte(output), &users)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(users))
+	assert.Equal(t, int64(1700000000000), users[0].LastLogin)
+	assert.Equal(t, []string{"foo"}, users[0].Groups)
+
+	config.Client = nil
+}
+
 func TestUsersCommandHttpFail(t *testing.T) {
 	//setup http client
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -366,3 +394,44 @@ func TestUsersUpdatePaswordSuccess(t *testing.T) {
 	assert.Equal(t, "User password [spiros] updated.\n", output)
 	config.Client = nil
 }
+
+func TestUsersSetGroupsCommand(t *testing.T) {
+	var updated api.UserMember
+
+	//setup http client
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name":"foo"},{"name":"bar"}]`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"username":"sam","email":"sam@landoop.com","groups":["foo"]}`))
+		default:
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	cmd := NewUsersCommand()
+	output, err := test.ExecuteCommand(cmd, "set-groups", "--username=sam", "--groups=foo", "--groups=bar")
+	assert.Nil(t, err)
+	assert.Equal(t, "User [sam] groups set to [foo bar]\n", output)
+	assert.Equal(t, []string{"foo", "bar"}, updated.Groups)
+	assert.Equal(t, "sam@landoop.com", updated.Email)
+
+	updated = api.UserMember{}
+	cmd = NewUsersCommand()
+	_, err = test.ExecuteCommand(cmd, "set-groups", "--username=sam", "--groups=foo", "--groups=baz")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "groups [baz] do not exist")
+	assert.Empty(t, updated.Username)
+
+	config.Client = nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -297,6 +298,10 @@ func NewDeletePolicyCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete policy [%s]?", id); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeletePolicy(id); err != nil {
 				golog.Errorf("Failed to delete policy [%s]. [%s]", id, err.Error())
 				return err
@@ -309,5 +314,6 @@ func NewDeletePolicyCommand() *cobra.Command {
 	cmd.Flags().StringVar(&id, "id", "", "Policy id")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 	return cmd
 }
@@ -1,6 +1,7 @@
 package processor
 
 import (
+	"fmt"
 	"net/url"
 	"sort"
 	"strings"
@@ -15,16 +16,24 @@ import (
 
 // NewGetProcessorsCommand creates `processors` command
 func NewGetProcessorsCommand() *cobra.Command {
-	var name, clusterName, namespace string
+	var name, clusterName, namespace, state string
 
 	cmd := &cobra.Command{
-		Use:              "processors",
-		Short:            "List of all available processors",
-		Example:          `processors`,
+		Use:   "processors",
+		Short: "List of all available processors",
+		Example: `processors
+processors --state=FAILED`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			result, err := config.Client.GetProcessors()
+			var result api.ProcessorsResult
+			var err error
+
+			if state != "" {
+				result.Streams, err = config.Client.GetProcessorsByState(state)
+			} else {
+				result, err = config.Client.GetProcessors()
+			}
 			if err != nil {
 				golog.Errorf("Failed to retrieve processors. [%s]", err.Error())
 				return err
@@ -60,6 +69,14 @@ func NewGetProcessorsCommand() *cobra.Command {
 				final = append(final, processor)
 			}
 
+			if utils.GetQuietFlag(cmd) {
+				names := make([]string, len(final))
+				for i, processor := range final {
+					names[i] = processor.Name
+				}
+				return utils.PrintNames(cmd, names)
+			}
+
 			return bite.PrintObject(cmd, final)
 		},
 	}
@@ -68,11 +85,15 @@ func NewGetProcessorsCommand() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Select by processor name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", "Select by cluster name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&namespace, "namespace", "", "Select by namespace, available only in KUBERNETES mode")
+	cmd.Flags().StringVar(&state, "state", "", fmt.Sprintf("Select by deployment state, one of: [%s]", strings.Join(api.ProcessorStates, ", ")))
 	// example: lenses-cli processors --query="[?ClusterName == 'IN_PROC'].Name | sort(@) | {Processor_Names_IN_PROC: join(', ', @)}"
 	bite.CanPrintJSON(cmd)
+	utils.CanBeQuiet(cmd)
 
 	cmd.AddCommand(NewProcessorsLogsCommand())
 	cmd.AddCommand(NewListDeploymentTargetsCommand())
+	cmd.AddCommand(NewProcessorsScaleCommand())
+	cmd.AddCommand(NewProcessorsLintCommand())
 
 	return cmd
 }
@@ -80,23 +101,46 @@ func NewGetProcessorsCommand() *cobra.Command {
 // NewProcessorsLogsCommand creates `processors logs` command
 func NewProcessorsLogsCommand() *cobra.Command {
 	var (
-		clusterName, podName, namespace string
-		follow                          bool
-		lines                           int
+		clusterName, podName, namespace, name string
+		follow                                bool
+		lines                                 int
 	)
 
 	cmd := &cobra.Command{
-		Use:              "logs",
-		Short:            "Retrieve LSQL Processor logs. Available only in KUBERNETES execution mode",
-		Example:          `processors logs --cluster-name=cluster-name --namespace=nameSpace --podName=runnerStateID [--follow --lines=50]`,
+		Use:   "logs",
+		Short: "Retrieve LSQL Processor logs. Available only in KUBERNETES execution mode",
+		Long: `Retrieve LSQL Processor logs. Available only in KUBERNETES execution mode.
+Give the --name of the processor instead of a --podName to retrieve the logs of all its pods, each line is prefixed with its pod.`,
+		Example: `processors logs --cluster-name=cluster-name --namespace=nameSpace --podName=runnerStateID [--follow --lines=50]
+processors logs --cluster-name=cluster-name --namespace=nameSpace --name=processorName [--follow --lines=50]`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName, "namespace": namespace, "podName": podName}); err != nil {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName, "namespace": namespace}); err != nil {
 				return err
 			}
 
+			if podName == "" && name == "" {
+				return fmt.Errorf(`required flag "podName" or "name" not set`)
+			}
+
 			golog.SetTimeFormat("")
+
+			if podName == "" {
+				handler := func(pod, level, log string) error {
+					log, _ = url.QueryUnescape(log) // for LSQL lines.
+					utils.RichLog(level, fmt.Sprintf("[%s] %s", pod, log))
+					return nil
+				}
+
+				if err := config.Client.GetProcessorLogsAll(clusterName, namespace, name, follow, lines, handler); err != nil {
+					golog.Errorf("Failed to retrieve logs for processor [%s]. [%s]", name, err.Error())
+					return err
+				}
+
+				return nil
+			}
+
 			handler := func(level, log string) error {
 				log, _ = url.QueryUnescape(log) // for LSQL lines.
 				utils.RichLog(level, log)
@@ -115,11 +159,121 @@ func NewProcessorsLogsCommand() *cobra.Command {
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", "Select by cluster name, available only in KUBERNETES mode")
 	cmd.Flags().StringVar(&namespace, "namespace", "", "Select by namespace, available only in KUBERNETES mode")
 	cmd.Flags().StringVar(&podName, "podName", "", "Kubernetes pod name to view the logs for")
+	cmd.Flags().StringVar(&name, "name", "", "Processor name to view the logs of all its pods for, instead of a --podName")
 	cmd.Flags().BoolVar(&follow, "follow", false, "Tail the log")
 	cmd.Flags().IntVar(&lines, "lines", 100, "View the last n")
 	return cmd
 }
 
+// NewProcessorsScaleCommand creates `processors scale` command
+func NewProcessorsScaleCommand() *cobra.Command {
+	var (
+		clusterName, namespace string
+		runners                int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Scale all the processors of a namespace",
+		Long: `Scale all the processors of a cluster namespace to the same number of runners, i.e. to scale down an environment off-hours.
+Without --yes it lists the processors that would be scaled and asks for confirmation, or fails when not run from a terminal.`,
+		Example:          `processors scale --cluster-name="clusterName" --namespace="namespace" --runners=1 --yes`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"cluster-name": clusterName, "runners": runners}); err != nil {
+				return err
+			}
+
+			if runners < 1 {
+				return fmt.Errorf("invalid runners [%d], the processors need at least one runner", runners)
+			}
+
+			if !utils.GetYesFlag(cmd) {
+				processors, err := config.Client.GetProcessorsInNamespace(clusterName, namespace)
+				if err != nil {
+					return err
+				}
+
+				if err := bite.PrintObject(cmd, processors); err != nil {
+					return err
+				}
+
+				// unlike deletions, scripts must always confirm the scaling explicitly.
+				if !utils.IsInteractive() {
+					return fmt.Errorf("%d processors of [%s/%s] would be scaled to [%d] runners, use --yes to confirm", len(processors), clusterName, namespace, runners)
+				}
+
+				if err := utils.Confirm(cmd, "Scale %d processors of [%s/%s] to [%d] runners?", len(processors), clusterName, namespace, runners); err != nil {
+					return err
+				}
+			}
+
+			results, err := config.Client.ScaleProcessorsInNamespace(clusterName, namespace, runners)
+			if printErr := bite.PrintObject(cmd, results); printErr != nil {
+				return printErr
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", "Cluster name of the processors")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the processors, empty in CONNECT mode")
+	cmd.Flags().IntVar(&runners, "runners", 0, "Number of runners to scale each processor to")
+	utils.CanSkipConfirmation(cmd)
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// NewProcessorsLintCommand creates `processors lint` command
+func NewProcessorsLintCommand() *cobra.Command {
+	var failOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Validate the SQL of all the processors",
+		Long: `Validate the SQL of all the processors and list the errors and warnings, i.e. to find the processors
+which use deprecated SQL before upgrading the streaming engine. It fails if any processor has an error.`,
+		Example:          `processors lint [--fail-on-warning]`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			issues, err := config.Client.CheckProcessorsSQL()
+			if err != nil {
+				return err
+			}
+
+			if bite.ExpectsFeedback(cmd) && len(issues) == 0 {
+				return bite.PrintInfo(cmd, "No SQL issues found")
+			}
+
+			if err := bite.PrintObject(cmd, issues); err != nil {
+				return err
+			}
+
+			failed := make(map[string]bool)
+			for _, issue := range issues {
+				if issue.Severity == "error" || failOnWarning {
+					failed[issue.Name] = true
+				}
+			}
+
+			if len(failed) > 0 {
+				return fmt.Errorf("%d processors need attention", len(failed))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&failOnWarning, "fail-on-warning", false, "Fail on warnings too, not only on errors")
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
 // NewProcessorGroupCommand creates `processor` command
 func NewProcessorGroupCommand() *cobra.Command {
 	root := &cobra.Command{
@@ -137,6 +291,7 @@ func NewProcessorGroupCommand() *cobra.Command {
 	root.AddCommand(NewProcessorResumeCommand())
 	root.AddCommand(NewProcessorUpdateRunnersCommand())
 	root.AddCommand(NewProcessorDeleteCommand())
+	root.AddCommand(NewProcessorMetricsCommand())
 
 	return root
 }
@@ -171,15 +326,52 @@ func NewProcessorViewCommand() *cobra.Command {
 	return cmd
 }
 
+// NewProcessorMetricsCommand creates `processor metrics` command
+func NewProcessorMetricsCommand() *cobra.Command {
+	var processorID, processorName, clusterName, namespace string
+
+	cmd := &cobra.Command{
+		Use:              "metrics",
+		Short:            "View the throughput and the consumer lag of a processor",
+		Example:          `processor metrics --id="processor_id" (or --name="processor_name") --cluster-name="clusterName" --namespace="namespace"`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			identifier, err := config.Client.LookupProcessorIdentifier(processorID, processorName, clusterName, namespace)
+			if err != nil {
+				return err
+			}
+
+			metrics, err := config.Client.GetProcessorMetrics(identifier)
+			if err != nil {
+				golog.Errorf("Failed to retrieve the metrics of processor [%s]. [%s]", identifier, err.Error())
+				return err
+			}
+
+			return bite.PrintObject(cmd, metrics)
+		},
+	}
+
+	cmd.Flags().StringVar(&processorID, "id", "", "Processor ID")
+	cmd.Flags().StringVar(&processorName, "name", "", "Processor name")
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Cluster name the processor is in`)
+	cmd.Flags().StringVar(&namespace, "namespace", "", `Namespace the processor is in`)
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
 // NewProcessorCreateCommand creates `processor create` command
 func NewProcessorCreateCommand() *cobra.Command {
 	// the processorName and sql are the required.
 	var processor api.CreateProcessorFilePayload
+	var validate bool
 
 	cmd := &cobra.Command{
-		Use:              `create`,
-		Short:            "Create a processor",
-		Example:          `processor create --name="processor_name" --sql="" --runners=1 --cluster-name="" --namespace="" pipeline="" --id=""`,
+		Use:   `create`,
+		Short: "Create a processor",
+		Example: `processor create --name="processor_name" --sql="" --runners=1 --cluster-name="" --namespace="" pipeline="" --id=""
+processor create --name="processor_name" --sql="" --validate`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -187,6 +379,17 @@ func NewProcessorCreateCommand() *cobra.Command {
 				return err
 			}
 
+			if validate {
+				validation, err := config.Client.ValidateProcessorSQL(processor.SQL)
+				if err != nil {
+					return err
+				}
+
+				if !validation.IsValid {
+					return fmt.Errorf("invalid SQL for processor [%s] at line %d, column %d: %s", processor.Name, validation.Line, validation.Column, validation.Message)
+				}
+			}
+
 			err := config.Client.CreateProcessor(processor.Name, processor.SQL, processor.Runners, processor.ClusterName, processor.Namespace, processor.Pipeline, processor.ProcessorID)
 
 			if err != nil {
@@ -205,6 +408,7 @@ func NewProcessorCreateCommand() *cobra.Command {
 	cmd.Flags().IntVar(&processor.Runners, "runners", 1, "Number of runners/instance to deploy")
 	cmd.Flags().StringVar(&processor.Pipeline, "pipeline", "", `A label to apply to kubernetes processors, defaults to processor name`)
 	cmd.Flags().StringVar(&processor.ProcessorID, "id", "", `The processor identifier, it is used as the underlying Kafka consumer group`)
+	cmd.Flags().BoolVar(&validate, "validate", false, "Validate the SQL before creating the processor, it is not created if the SQL is invalid")
 
 	bite.Prepend(cmd, bite.FileBind(&processor))
 	bite.CanBeSilent(cmd)
@@ -212,6 +416,15 @@ func NewProcessorCreateCommand() *cobra.Command {
 	return cmd
 }
 
+// processorRef returns the "id", or the "name" if the id is not set, to refer to a processor in the messages of its commands.
+func processorRef(id, name string) string {
+	if id != "" {
+		return id
+	}
+
+	return name
+}
+
 // NewProcessorPauseCommand creates `processor pause` command
 func NewProcessorPauseCommand() *cobra.Command {
 	var processorID, processorName, clusterName, namespace string
@@ -223,12 +436,15 @@ func NewProcessorPauseCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			identifier, err := config.Client.LookupProcessorIdentifier(processorID, processorName, clusterName, namespace)
-			if err != nil {
-				return err
+			var err error
+			if processorID != "" {
+				err = config.Client.StopProcessor(processorID)
+			} else {
+				err = config.Client.StopProcessorByName(processorName, clusterName, namespace)
 			}
 
-			if err := config.Client.StopProcessor(identifier); err != nil {
+			identifier := processorRef(processorID, processorName)
+			if err != nil {
 				golog.Errorf("Failed to stop processor [%s]. [%s]", identifier, err.Error())
 				return err
 			}
@@ -257,12 +473,15 @@ func NewProcessorResumeCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			identifier, err := config.Client.LookupProcessorIdentifier(processorID, processorName, clusterName, namespace)
-			if err != nil {
-				return err
+			var err error
+			if processorID != "" {
+				err = config.Client.ResumeProcessor(processorID)
+			} else {
+				err = config.Client.ResumeProcessorByName(processorName, clusterName, namespace)
 			}
 
-			if err := config.Client.ResumeProcessor(identifier); err != nil {
+			identifier := processorRef(processorID, processorName)
+			if err != nil {
 				golog.Errorf("Failed to start processor [%s]. [%s]", identifier, err.Error())
 				return err
 			}
@@ -300,12 +519,15 @@ func NewProcessorUpdateRunnersCommand() *cobra.Command {
 				return err
 			}
 
-			identifier, err := config.Client.LookupProcessorIdentifier(processorID, processorName, clusterName, namespace)
-			if err != nil {
-				return err
+			var err error
+			if processorID != "" {
+				err = config.Client.UpdateProcessorRunners(processorID, runners)
+			} else {
+				err = config.Client.UpdateProcessorRunnersByName(processorName, clusterName, namespace, runners)
 			}
 
-			if err := config.Client.UpdateProcessorRunners(identifier, runners); err != nil {
+			identifier := processorRef(processorID, processorName)
+			if err != nil {
 				golog.Errorf("Failed to scale processor [%s] to [%d]. [%s]", identifier, runners, err.Error())
 				return err
 			}
@@ -335,20 +557,26 @@ func NewProcessorDeleteCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			identifier, err := config.Client.LookupProcessorIdentifier(processorID, processorName, clusterName, namespace)
-			if err != nil {
-				return err
+			identifier := processorRef(processorID, processorName)
+			if identifier == "" {
+				return fmt.Errorf("processor name or id is missing")
 			}
 
-			// delete the processor based on the identifier, based on the current running mode.
-			if err := config.Client.DeleteProcessor(identifier); err != nil {
-				golog.Errorf("Failed to delete processor [%s]. [%s]", identifier, err.Error())
+			if err := utils.Confirm(cmd, "Delete processor [%s]?", identifier); err != nil {
 				return err
 			}
 
-			// change the printed value to the processor name if available.
-			if processorName != "" {
-				identifier = processorName
+			// the name is resolved to the identifier of the current running mode.
+			var err error
+			if processorID != "" {
+				err = config.Client.DeleteProcessor(processorID)
+			} else {
+				err = config.Client.DeleteProcessorByName(processorName, clusterName, namespace)
+			}
+
+			if err != nil {
+				golog.Errorf("Failed to delete processor [%s]. [%s]", identifier, err.Error())
+				return err
 			}
 
 			return bite.PrintInfo(cmd, "Processor [%s] deleted", identifier)
@@ -363,6 +591,7 @@ func NewProcessorDeleteCommand() *cobra.Command {
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Cluster name the processor is in`)
 	cmd.Flags().StringVar(&namespace, "namespace", "", `Namespace the processor is in`)
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
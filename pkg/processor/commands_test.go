@@ -281,3 +281,269 @@ func TestNewProcessorCreateValidationErrors(t *testing.T) {
 
 	config.Client = nil
 }
+
+func TestNewProcessorCreateCommandValidate(t *testing.T) {
+	var created bool
+
+	//setup http client
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sql/presentation") {
+			w.Write([]byte(`{"lints":[{"start":21,"end":25,"text":"Invalid syntax","type":"error"}]}`))
+			return
+		}
+
+		created = true
+		w.Write([]byte(string(processorRegisteredAsJSON)))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	cmd := NewProcessorCreateCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+
+	_, err = test.ExecuteCommand(cmd, "--name=p1", "--sql=SET autocreate=true;\nINSERT INTO b SELEC * FROM a", "--validate")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid SQL for processor [p1] at line 2, column 1: Invalid syntax", err.Error())
+	}
+	assert.False(t, created)
+
+	config.Client = nil
+}
+
+func TestProcessorMetricsCommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config" {
+			w.Write([]byte(`{"lenses.sql.execution.mode": "IN_PROC"}`))
+			return
+		}
+
+		assert.Equal(t, "/api/v1/streams/processor-id/metrics", r.URL.Path)
+		w.Write([]byte(`{"uptime": 60000, "runners": [
+			{"runnerId": "r1", "messagesInPerSecond": 10, "messagesOutPerSecond": 8, "lag": 5},
+			{"runnerId": "r2", "messagesInPerSecond": 2.5, "messagesOutPerSecond": 2, "lag": 1}
+		]}`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	cmd := NewProcessorMetricsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "--id=processor-id")
+	assert.Nil(t, err)
+
+	var metrics api.ProcessorMetrics
+	assert.Nil(t, json.Unmarshal([]byte(output), &metrics))
+	assert.Equal(t, "processor-id", metrics.ProcessorID)
+	assert.Equal(t, 12.5, metrics.MessagesInPerSecond)
+	assert.Equal(t, 10.0, metrics.MessagesOutPerSecond)
+	assert.Equal(t, int64(6), metrics.ConsumerLag)
+	assert.Len(t, metrics.Runners, 2)
+
+	config.Client = nil
+}
+
+func TestProcessorStopCommandByName(t *testing.T) {
+	var stopped []string
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/config":
+			w.Write([]byte(`{"lenses.sql.execution.mode": "CONNECT"}`))
+		case r.Method == http.MethodPut:
+			stopped = append(stopped, r.URL.Path)
+		default:
+			w.Write([]byte(`{"streams": [
+				{"id": "p1", "name": "one", "clusterName": "dev"},
+				{"id": "p2", "name": "one", "clusterName": "prod"}
+			]}`))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	output, err := test.ExecuteCommand(NewProcessorPauseCommand(), "--name=one", "--cluster-name=prod")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "Processor [one] stopped")
+
+	_, err = test.ExecuteCommand(NewProcessorPauseCommand(), "--id=p1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/api/v1/streams/p2/stop", "/api/v1/streams/p1/stop"}, stopped)
+
+	_, err = test.ExecuteCommand(NewProcessorPauseCommand(), "--name=one")
+	assert.NotNil(t, err)
+	assert.Len(t, stopped, 2)
+
+	_, err = test.ExecuteCommand(NewProcessorPauseCommand(), "--name=onee", "--cluster-name=prod")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "processor [onee] not found in cluster [prod]")
+	assert.Len(t, stopped, 2)
+
+	config.Client = nil
+}
+
+func TestProcessorsScaleCommand(t *testing.T) {
+	var scaled []string
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if strings.Contains(r.URL.Path, "bad") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			scaled = append(scaled, r.URL.Path)
+			return
+		}
+
+		w.Write([]byte(`{"streams": [
+			{"id": "p1", "name": "one", "clusterName": "aks", "namespace": "dev"},
+			{"id": "bad", "name": "two", "clusterName": "aks", "namespace": "dev"},
+			{"id": "p3", "name": "three", "clusterName": "aks", "namespace": "prod"}
+		]}`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	var outputValue string
+	cmd := NewGetProcessorsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	_, err = test.ExecuteCommand(cmd, "scale", "--cluster-name=aks", "--namespace=dev", "--runners=1")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "use --yes to confirm")
+	assert.Empty(t, scaled)
+
+	_, err = test.ExecuteCommand(NewGetProcessorsCommand(), "scale", "--cluster-name=aks", "--namespace=dev", "--runners=-1", "--yes")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid runners [-1]")
+	assert.Empty(t, scaled)
+
+	_, err = config.Client.ScaleProcessorsInNamespace("aks", "dev", -1)
+	assert.NotNil(t, err)
+	assert.Empty(t, scaled)
+
+	cmd = NewGetProcessorsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "scale", "--cluster-name=aks", "--namespace=dev", "--runners=2", "--yes")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "[two]")
+	assert.Equal(t, []string{"/api/v1/streams/p1/scale/2"}, scaled)
+
+	// the results are printed before the command's error and its usage.
+	var results []api.ProcessorScaleResult
+	assert.Nil(t, json.NewDecoder(strings.NewReader(output)).Decode(&results))
+	assert.Len(t, results, 2)
+	assert.Empty(t, results[0].Error)
+	assert.NotEmpty(t, results[1].Error)
+
+	config.Client = nil
+}
+
+func TestProcessorsLintCommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/streams" {
+			w.Write([]byte(`{"streams": [
+				{"id": "3", "name": "valid", "sql": "INSERT INTO c SELECT STREAM * FROM a"},
+				{"id": "2", "name": "deprecated", "sql": "SET autocreate=true;\nINSERT INTO b SELECT * FROM a"},
+				{"id": "1", "name": "broken", "sql": "INSERT INTO b SELEC * FROM a"}
+			]}`))
+			return
+		}
+
+		var req api.SQLValidationRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch {
+		case strings.Contains(req.SQL, "SELEC *"):
+			w.Write([]byte(`{"lints":[{"start":14,"end":19,"text":"Invalid syntax","type":"error"}]}`))
+		case strings.Contains(req.SQL, "autocreate"):
+			w.Write([]byte(`{"lints":[{"start":0,"end":20,"text":"Deprecated setting","type":"warning"}]}`))
+		default:
+			w.Write([]byte(`{"lints":[]}`))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	cmd := NewProcessorsLintCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+
+	output, err := test.ExecuteCommand(cmd)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "1 processors need attention", err.Error())
+	}
+
+	var issues []api.ProcessorSQLIssue
+	assert.Nil(t, json.NewDecoder(strings.NewReader(output)).Decode(&issues))
+	assert.Equal(t, []api.ProcessorSQLIssue{
+		{ProcessorID: "1", Name: "broken", Severity: "error", Line: 1, Column: 15, Message: "Invalid syntax"},
+		{ProcessorID: "2", Name: "deprecated", Severity: "warning", Line: 1, Column: 1, Message: "Deprecated setting"},
+	}, issues)
+
+	cmd = NewProcessorsLintCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	_, err = test.ExecuteCommand(cmd, "--fail-on-warning")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "2 processors need attention", err.Error())
+	}
+
+	config.Client = nil
+}
+
+func TestGetProcessorsByStateCommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/config":
+			w.Write([]byte(`{"lenses.sql.execution.mode": "IN_PROC"}`))
+		case "/api/v1/streams":
+			w.Write([]byte(`{"streams": [
+				{"id": "1", "name": "enrich", "state": {"deploymentStatus": "RUNNING"}},
+				{"id": "2", "name": "dedup", "state": {"deploymentStatus": "FAILED"}}
+			]}`))
+		default:
+			t.Errorf("unexpected path `%s`", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	cmd := NewGetProcessorsCommand()
+	output, err := test.ExecuteCommand(cmd, "--state=failed", "--quiet")
+	assert.Nil(t, err)
+	assert.Equal(t, "dedup", strings.TrimSpace(output))
+
+	cmd = NewGetProcessorsCommand()
+	_, err = test.ExecuteCommand(cmd, "--state=broken")
+	assert.NotNil(t, err)
+
+	config.Client = nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/lensesio/lenses-go/v5/pkg"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -25,11 +26,22 @@ func NewGetQuotasCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, quotas)
+			if utils.GetQuietFlag(cmd) {
+				names := make([]string, len(quotas))
+				for i, quota := range quotas {
+					names[i] = quota.EntityName
+				}
+				return utils.PrintNames(cmd, names)
+			}
+
+			return utils.PrintObject(cmd, quotas)
 		},
 	}
 
 	bite.CanPrintJSON(cmd)
+	utils.CanSelectColumns(cmd)
+	utils.CanUseTemplate(cmd)
+	utils.CanBeQuiet(cmd)
 
 	return cmd
 }
@@ -130,6 +142,18 @@ func NewQuotaUsersSubGroupCommand() *cobra.Command {
 
 			var user, clientID = quota.User, quota.ClientID
 
+			target := "the default user quota"
+			if user != "" {
+				target = "the quota of user [" + user + "]"
+				if clientID != "" {
+					target += " for client [" + clientID + "]"
+				}
+			}
+
+			if err := utils.Confirm(cmd, "%s %s?", quotaDeleteAction(args), target); err != nil {
+				return err
+			}
+
 			if user != "" {
 				if clientID != "" {
 					if clientID == "all" || clientID == "*" {
@@ -169,6 +193,7 @@ func NewQuotaUsersSubGroupCommand() *cobra.Command {
 	deleteCommand.Flags().StringVar(&quota.User, "quota-user", "", "Quota user")
 	deleteCommand.Flags().StringVar(&quota.ClientID, "quota-client", "", "Quota client")
 	bite.CanBeSilent(deleteCommand)
+	utils.CanSkipConfirmation(deleteCommand)
 
 	rootSub.AddCommand(deleteCommand)
 
@@ -252,6 +277,15 @@ func NewQuotaClientsSubGroupCommand() *cobra.Command {
 
 			// bite.FriendlyError(cmd, errResourceNotAccessibleMessage, "unable to %s quota, user has no rights for this action", actionMsg)
 
+			target := "the default client quota"
+			if id := quota.ClientID; id != "" && id != "all" && id != "*" {
+				target = "the quota of client [" + id + "]"
+			}
+
+			if err := utils.Confirm(cmd, "%s %s?", quotaDeleteAction(args), target); err != nil {
+				return err
+			}
+
 			if id := quota.ClientID; id != "" && id != "all" && id != "*" {
 				if err := client.DeleteQuotaForClient(id, args...); err != nil {
 					golog.Errorf("Failed to delete quota for client [%s]. [%s]", quota.ClientID, err.Error())
@@ -272,12 +306,23 @@ func NewQuotaClientsSubGroupCommand() *cobra.Command {
 
 	deleteCommand.Flags().StringVar(&quota.ClientID, "quota-client", "", "Quota client")
 	bite.CanBeSilent(deleteCommand)
+	utils.CanSkipConfirmation(deleteCommand)
 
 	rootSub.AddCommand(deleteCommand)
 
 	return rootSub
 }
 
+// quotaDeleteAction returns the action of a quota delete command for the confirmation prompt,
+// the "configs" arguments remove only these properties of the quota.
+func quotaDeleteAction(configs []string) string {
+	if len(configs) > 0 {
+		return "Remove the [" + strings.Join(configs, ", ") + "] properties of"
+	}
+
+	return "Delete"
+}
+
 // CreateQuotaForClients creates quotas for clients
 func CreateQuotaForClients(cmd *cobra.Command, client *api.Client, quota api.CreateQuotaPayload) error {
 	if id := quota.ClientID; id != "" && id != "all" && id != "*" && strings.HasPrefix(quota.QuotaType, "CLIENT") {
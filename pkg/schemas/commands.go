@@ -26,7 +26,9 @@ func NewSchemasCmd() *cobra.Command {
 			- Create or Update a particular Schema.
 			- Delete a "Schema" or a "Version".
 			- Set the Schema "Compatibility".
+			- Reset the Schema "Compatibility" to the default one.
 			- Set the Default "Compatibility".
+			- View or set the Registry or Schema "Mode".
 		`),
 		Example: heredoc.Doc(`
 		$ lenses-cli schema-registry
@@ -37,9 +39,12 @@ func NewSchemasCmd() *cobra.Command {
 
 	rootCmd.AddCommand(ViewSubjectsCmd())
 	rootCmd.AddCommand(ViewSchemaCmd())
+	rootCmd.AddCommand(ViewTopicSchemasCmd())
 	rootCmd.AddCommand(WriteSchemaCmd())
 	rootCmd.AddCommand(SetSchemaCompatibility())
+	rootCmd.AddCommand(ResetSchemaCompatibility())
 	rootCmd.AddCommand(SetGlobalCompatibility())
+	rootCmd.AddCommand(SchemaMode())
 	rootCmd.AddCommand(RemoveSchemaVersion())
 	rootCmd.AddCommand(RemoveSchema())
 
@@ -61,12 +66,21 @@ func ViewSubjectsCmd() *cobra.Command {
 				return err
 			}
 
+			if utils.GetQuietFlag(cmd) {
+				names := make([]string, len(subjects))
+				for i, subject := range subjects {
+					names[i] = subject.Name
+				}
+				return utils.PrintNames(cmd, names)
+			}
+
 			return bite.PrintObject(cmd, subjects)
 		},
 	}
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
+	utils.CanBeQuiet(cmd)
 
 	return cmd
 }
@@ -111,6 +125,44 @@ func ViewSchemaCmd() *cobra.Command {
 	return cmd
 }
 
+// ViewTopicSchemasCmd returns the key and value subjects of a topic
+func ViewTopicSchemasCmd() *cobra.Command {
+	var topic string
+	cmd := &cobra.Command{
+		Use: "topic-subjects",
+		Long: heredoc.Doc(`
+			Read the key and value subjects of a topic along with their
+			latest "version" and "schemaId".
+
+			When the server does not expose the topic linkage, the subjects
+			are resolved by the default "<topic>-key" and "<topic>-value" naming.
+		`),
+		Example: heredoc.Doc(`
+			$ lenses-cli schema-registry topic-subjects --topic="<TOPIC>"
+		`),
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schemas, err := config.Client.GetTopicSchemas(topic)
+			if err != nil {
+				return errors.Wrap(err, "✘ Error")
+			}
+			return bite.PrintJSON(cmd, schemas)
+		},
+		PostRun: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintln(os.Stderr, utils.Green("✓ Request succeeded!"))
+		},
+	}
+
+	cmd.Flags().StringVar(&topic, "topic", "", `Topic name`)
+	cmd.MarkFlagRequired("topic")
+
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
 // WriteSchemaCmd creates a schema if not exists, updates it otherwise.
 func WriteSchemaCmd() *cobra.Command {
 	var request api.WriteSchemaReq
@@ -193,6 +245,39 @@ func SetSchemaCompatibility() *cobra.Command {
 	return cmd
 }
 
+// ResetSchemaCompatibility removes the compatibility override of a schema
+func ResetSchemaCompatibility() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use: "reset",
+		Long: heredoc.Doc(`
+		Remove the Schema Compatibility override. The Schema will inherit
+		the default Schema Registry Compatibility afterwards.
+		`),
+		Example: heredoc.Doc(`
+		$ lenses-cli schema-registry reset --name="<NAME>"
+		`),
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := config.Client
+			err := client.DeleteSubjectCompatibilityLevel(name)
+
+			return errors.Wrap(err, "✘ Error")
+		},
+		PostRun: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintln(os.Stderr, utils.Green("✓ Request succeeded!"))
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Schema Name")
+
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
 // SetGlobalCompatibility sets the default compatibility
 func SetGlobalCompatibility() *cobra.Command {
 	var request api.SetGlobalCompatibilityReq
@@ -229,10 +314,71 @@ func SetGlobalCompatibility() *cobra.Command {
 	return cmd
 }
 
+// SchemaMode prints or sets the mode of the schema registry or of a schema
+func SchemaMode() *cobra.Command {
+	var name, mode string
+
+	cmd := &cobra.Command{
+		Use: "mode",
+		Long: heredoc.Doc(`
+		Print the mode of the Schema Registry, or of a Schema if its name is given,
+		or set it if a mode is given.
+
+		Options: "READWRITE", "READONLY", "IMPORT"
+		`),
+		Example: heredoc.Doc(`
+		$ lenses-cli schema-registry mode [--name="<NAME>"]
+		$ lenses-cli schema-registry mode [--name="<NAME>"] --set="IMPORT"
+		`),
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := config.Client
+
+			if mode != "" {
+				var err error
+				if name != "" {
+					err = client.SetSubjectMode(name, mode)
+				} else {
+					err = client.SetSchemaRegistryMode(mode)
+				}
+				if err != nil {
+					return errors.Wrap(err, "✘ Error")
+				}
+
+				fmt.Fprintln(os.Stderr, utils.Green("✓ Request succeeded!"))
+				return nil
+			}
+
+			var (
+				current string
+				err     error
+			)
+			if name != "" {
+				current, err = client.GetSubjectMode(name)
+			} else {
+				current, err = client.GetSchemaRegistryMode()
+			}
+			if err != nil {
+				return errors.Wrap(err, "✘ Error")
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), current)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Schema Name, the Schema Registry's mode is used if not set")
+	cmd.Flags().StringVar(&mode, "set", "", "The mode to set")
+
+	return cmd
+}
+
 // RemoveSchemaVersion removes a particular version of a schema
 func RemoveSchemaVersion() *cobra.Command {
 	var name string
 	var version string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use: "remove-version",
@@ -241,15 +387,32 @@ func RemoveSchemaVersion() *cobra.Command {
 		but remove a specific version of it.
 
 		Note, that this will perform a soft removal of the Schema. Not a permanent one.
+
+		If other schemas reference this version the removal is refused,
+		use the "--force" flag to remove it anyway.
 		`),
 		Example: heredoc.Doc(`
 		$ lenses-cli schema-registry remove-version --name="<NAME>" --version="<VERSION>"
+		$ lenses-cli schema-registry remove-version --name="<NAME>" --version="<VERSION>" --force
 		`),
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := config.Client
-			err := client.RemoveSchemaVersion(name, version)
+
+			if err := utils.Confirm(cmd, "Remove version [%s] of schema [%s]?", version, name); err != nil {
+				return err
+			}
+
+			if force {
+				return errors.Wrap(client.RemoveSchemaVersion(name, version), "✘ Error")
+			}
+
+			err := client.RemoveSchemaVersionSafe(name, version)
+			if refErr, ok := err.(*api.SchemaVersionReferencedError); ok {
+				fmt.Fprintln(cmd.ErrOrStderr(), utils.Yellow("! "+refErr.Error()))
+				return fmt.Errorf("✘ Error: schema version is referenced by other schemas, use --force to remove it anyway")
+			}
 
 			return errors.Wrap(err, "✘ Error")
 		},
@@ -260,9 +423,11 @@ func RemoveSchemaVersion() *cobra.Command {
 
 	cmd.Flags().StringVar(&name, "name", "", "Schema Name")
 	cmd.Flags().StringVar(&version, "version", "", "Schema Version")
+	cmd.Flags().BoolVar(&force, "force", false, "Remove the version even if it is referenced by other schemas")
 
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("version")
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
@@ -284,6 +449,11 @@ func RemoveSchema() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := config.Client
+
+			if err := utils.Confirm(cmd, "Remove schema [%s] and all its versions?", name); err != nil {
+				return err
+			}
+
 			err := client.RemoveSchema(name)
 
 			return errors.Wrap(err, "✘ Error")
@@ -296,6 +466,7 @@ func RemoveSchema() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Schema Name")
 
 	cmd.MarkFlagRequired("name")
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
@@ -0,0 +1,58 @@
+package schemas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveSchemaCommandWithYes(t *testing.T) {
+	var removed bool
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/v1/sr/default/subject/orders-value", r.URL.Path)
+		removed = true
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	_, err = test.ExecuteCommand(RemoveSchema(), "--name", "orders-value", "--yes")
+	assert.Nil(t, err)
+	assert.True(t, removed)
+}
+
+func TestRemoveSchemaVersionCommandReferenced(t *testing.T) {
+	var removed bool
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			removed = true
+			return
+		}
+
+		assert.Equal(t, "/api/v1/sr/default/subject/orders-value/version/latest/referencedby", r.URL.Path)
+		w.Write([]byte(`[42]`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	output, err := test.ExecuteCommand(RemoveSchemaVersion(), "--name", "orders-value", "--version", "latest", "--yes")
+	assert.NotNil(t, err)
+	assert.Contains(t, output, "is referenced by schema ids [42]")
+	assert.False(t, removed)
+
+	_, err = test.ExecuteCommand(RemoveSchemaVersion(), "--name", "orders-value", "--version", "latest", "--yes", "--force")
+	assert.Nil(t, err)
+	assert.True(t, removed)
+}
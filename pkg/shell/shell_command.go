@@ -1,9 +1,8 @@
 package shell
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"io/ioutil"
 
 	"github.com/c-bata/go-prompt"
 	"github.com/kataras/golog"
@@ -19,15 +18,33 @@ var sqlHistoryPath = fmt.Sprintf("%s/history", api.DefaultConfigurationHomeDir)
 // NewInteractiveCommand creates `shell` command
 func NewInteractiveCommand() *cobra.Command {
 
+	var scriptFile string
+
 	cmd := &cobra.Command{
-		Use:              "shell",
-		Short:            "shell",
-		Example:          `shell`,
+		Use:   "shell",
+		Short: "shell",
+		Example: `shell
+shell --file script.lsql`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
+		Annotations:      map[string]string{config.StreamingAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := config.Client
 
+			if scriptFile != "" {
+				script, err := ioutil.ReadFile(scriptFile)
+				if err != nil {
+					return err
+				}
+
+				statements := sql.SplitStatements(string(script))
+				if len(statements) == 0 {
+					return fmt.Errorf("no statements found in [%s]", scriptFile)
+				}
+
+				return sql.NewExecutor(cmd, client, sqlHistoryPath).ExecuteScript(statements)
+			}
+
 			sql.InteractiveShell = true
 
 			fmt.Printf(`
@@ -43,24 +60,11 @@ Crtl+D to exit
 
 `, client.Config.Host, client.User.Name, config.Manager.Config.CurrentContext)
 
-			var histories []string
-
-			if _, err := os.Stat(sqlHistoryPath); os.IsExist(err) {
-				file, err := os.Open(sqlHistoryPath)
-				if err != nil {
-					golog.Warnf("Unable to open command history. [%s]", err.Error())
-				}
-				defer file.Close()
-
-				scanner := bufio.NewScanner(file)
-				for scanner.Scan() {
-					histories = append(histories, scanner.Text())
-				}
-
-				if err := scanner.Err(); err != nil {
-					golog.Fatal(err)
-				}
+			histories, err := sql.LoadHistory(sqlHistoryPath)
+			if err != nil {
+				golog.Warnf("Unable to open command history. [%s]", err.Error())
 			}
+
 			executor := sql.NewExecutor(cmd, client, sqlHistoryPath)
 
 			p := prompt.New(
@@ -80,6 +84,9 @@ Crtl+D to exit
 
 		},
 	}
+
+	cmd.Flags().StringVar(&scriptFile, "file", "", "Execute the statements of a script file non-interactively and exit")
+
 	bite.CanPrintJSON(cmd)
 
 	return cmd
@@ -214,6 +214,7 @@ func runSQL(cmd *cobra.Command, sql string, meta bool, keys bool, keysOnly bool,
 		// kill -SIGTERM XXXX
 		syscall.SIGTERM,
 	)
+	defer signal.Stop(ch)
 
 	return conn.Wait(ch)
 }
@@ -155,22 +155,8 @@ func (e *Executor) Execute(sql string) {
 
 			runSQL(e.interactiveCmd, finalQ, sqlMeta, sqlKeys, sqlKeysOnly, sqlLiveStream, sqlStats)
 
-			file, err := os.Create(e.sqlHistoryPath)
-			if err != nil {
-				golog.Fatalf("Cannot open file [%s]. [%s]", e.sqlHistoryPath, err.Error())
-			}
-			defer file.Close()
-
-			_, errS := file.WriteString(sql)
-
-			if errS != nil {
-				golog.Fatalf("Error writing history to file [%s]. [%s]", e.sqlHistoryPath, err.Error())
-			}
-
-			_, errF := file.WriteString(finalQ)
-
-			if errF != nil {
-				golog.Fatalf("Error writing history to file [%s]. [%s]", e.sqlHistoryPath, err.Error())
+			if err := AppendHistory(e.sqlHistoryPath, finalQ); err != nil {
+				golog.Warnf("Error writing history to file [%s]. [%s]", e.sqlHistoryPath, err.Error())
 			}
 
 			sqlQuery = ""
@@ -185,3 +171,85 @@ func (e *Executor) Execute(sql string) {
 	}
 	return
 }
+
+// SplitStatements splits a script into its statements, the statements are separated by ";".
+// Separators inside quoted values are ignored, "--" and "/* */" comments are dropped
+// and empty statements are skipped.
+func SplitStatements(script string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+		quote      rune
+		runes      = []rune(script)
+	)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			// skip to the end of the line, the new line is kept as a separator.
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			if i < len(runes) {
+				current.WriteRune('\n')
+			}
+			continue
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/') {
+				i++
+			}
+			i++ // the closing '/'.
+			current.WriteRune(' ')
+			continue
+		case r == ';':
+			if stmt := strings.TrimSpace(current.String()); stmt != "" {
+				statements = append(statements, stmt+";")
+			}
+			current.Reset()
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	if stmt := strings.TrimSpace(current.String()); stmt != "" {
+		statements = append(statements, stmt+";")
+	}
+
+	return statements
+}
+
+// ExecuteScript validates and runs the statements one by one, non-interactively,
+// it stops on the first failure.
+func (e *Executor) ExecuteScript(statements []string) error {
+	for i, stmt := range statements {
+		fmt.Fprintf(e.interactiveCmd.OutOrStderr(), "[%d/%d] %s\n", i+1, len(statements), stmt)
+
+		validation, err := e.client.ValidateSQL(stmt, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, lint := range validation.Lints {
+			lintType := strings.ToLower(lint.Type)
+			if lintType == "error" || lintType == "warning" {
+				return fmt.Errorf("statement [%d]: validation error: [%s]", i+1, lint.Text)
+			}
+		}
+
+		if err = runSQL(e.interactiveCmd, stmt, sqlMeta, sqlKeys, sqlKeysOnly, sqlLiveStream, sqlStats); err != nil {
+			return fmt.Errorf("statement [%d]: %v", i+1, err)
+		}
+	}
+
+	return nil
+}
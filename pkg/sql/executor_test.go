@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitStatements(t *testing.T) {
+	script := `
+SELECT * FROM cc_payments LIMIT 10;
+INSERT INTO foo SELECT STREAM * FROM bar WHERE name = 'a;b';
+
+SET defaults.topic.autocreate=true`
+
+	assert.Equal(t, []string{
+		"SELECT * FROM cc_payments LIMIT 10;",
+		"INSERT INTO foo SELECT STREAM * FROM bar WHERE name = 'a;b';",
+		"SET defaults.topic.autocreate=true;",
+	}, SplitStatements(script))
+
+	assert.Empty(t, SplitStatements(" ; ;\n"))
+
+	script = `
+-- the payments; all of them
+SELECT * FROM cc_payments /* no; limit */ WHERE id = '--1';
+/* unterminated; comment`
+
+	assert.Equal(t, []string{
+		"SELECT * FROM cc_payments   WHERE id = '--1';",
+	}, SplitStatements(script))
+	// a comment-only tail is not a statement.
+	assert.Equal(t, []string{"SELECT 1;"}, SplitStatements("SELECT 1;\n-- done;\n"))
+}
+
+func TestAddHistoryEntry(t *testing.T) {
+	defer func(max int) { MaxHistoryEntries = max }(MaxHistoryEntries)
+	MaxHistoryEntries = 3
+
+	history := []string{"a;", "b;", "c;"}
+
+	history = addHistoryEntry(history, " a; ")
+	assert.Equal(t, []string{"b;", "c;", "a;"}, history)
+
+	history = addHistoryEntry(history, "d;")
+	assert.Equal(t, []string{"c;", "a;", "d;"}, history)
+
+	history = addHistoryEntry(history, "  ")
+	assert.Equal(t, []string{"c;", "a;", "d;"}, history)
+
+	history = addHistoryEntry(history, "SELECT *\r\n  FROM topic\n\n  LIMIT 1;\n")
+	assert.Equal(t, []string{"a;", "d;", "SELECT * FROM topic LIMIT 1;"}, history)
+}
+
+func TestAppendHistoryMultiLineStatement(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history")
+
+	assert.Nil(t, AppendHistory(historyPath, "SELECT *\nFROM topic;"))
+	assert.Nil(t, AppendHistory(historyPath, "SET a=1;"))
+
+	history, err := LoadHistory(historyPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"SELECT * FROM topic;", "SET a=1;"}, history)
+}
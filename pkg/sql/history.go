@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
+)
+
+// MaxHistoryEntries is the maximum number of statements kept in the shell's history file.
+var MaxHistoryEntries = 500
+
+// LoadHistory reads the shell's history file, one statement per line.
+// A missing history file results to an empty history.
+func LoadHistory(historyPath string) ([]string, error) {
+	lines, err := utils.ReadLines(historyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// historyEntry joins the lines of a multi-line statement with a space,
+// the same way the shell joins the lines of a statement, so it is stored as a single history entry.
+func historyEntry(statement string) string {
+	var lines []string
+	for _, line := range strings.Split(statement, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, " ")
+}
+
+// addHistoryEntry appends the "entry" to the "history", a previous occurrence
+// of the same entry is removed and the oldest entries are dropped when the history
+// grows bigger than the `MaxHistoryEntries`.
+func addHistoryEntry(history []string, entry string) []string {
+	entry = historyEntry(entry)
+	if entry == "" {
+		return history
+	}
+
+	deduped := make([]string, 0, len(history)+1)
+	for _, h := range history {
+		if h != entry {
+			deduped = append(deduped, h)
+		}
+	}
+	deduped = append(deduped, entry)
+
+	if MaxHistoryEntries > 0 && len(deduped) > MaxHistoryEntries {
+		deduped = deduped[len(deduped)-MaxHistoryEntries:]
+	}
+
+	return deduped
+}
+
+// AppendHistory adds a statement to the shell's history file,
+// a multi-line statement is stored as a single line, see `LoadHistory`.
+func AppendHistory(historyPath string, entry string) error {
+	history, err := LoadHistory(historyPath)
+	if err != nil {
+		return err
+	}
+
+	if err = utils.CreateDirectory(filepath.Dir(historyPath)); err != nil {
+		return err
+	}
+
+	history = addHistoryEntry(history, entry)
+	return os.WriteFile(historyPath, []byte(strings.Join(history, "\n")+"\n"), 0600)
+}
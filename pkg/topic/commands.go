@@ -1,14 +1,20 @@
 package topic
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/v5/pkg/api"
 	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -22,19 +28,37 @@ type topicView struct {
 // NewTopicsGroupCommand creates `topics` command
 func NewTopicsGroupCommand() *cobra.Command {
 	var namesOnly, unwrap bool
+	var namespace string
 
 	root := &cobra.Command{
 		Use:           "topics",
 		Short:         "List all available topics",
-		Example:       "topics",
+		Example:       "topics --namespace=finance-*",
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := config.Client
 
+			if utils.GetQuietFlag(cmd) {
+				namesOnly, unwrap = true, true
+			}
+
+			var (
+				topics []api.Topic
+				err    error
+			)
+			if namespace != "" {
+				topics, err = client.GetTopicsInNamespace(namespace)
+			} else {
+				topics, err = client.GetTopics()
+			}
+			if err != nil {
+				return err
+			}
+
 			if namesOnly {
-				topicNames, err := client.GetTopicsNames()
-				if err != nil {
-					return err
+				topicNames := make([]string, len(topics))
+				for i := range topics {
+					topicNames[i] = topics[i].TopicName
 				}
 				sort.Strings(topicNames)
 
@@ -49,11 +73,6 @@ func NewTopicsGroupCommand() *cobra.Command {
 				return bite.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", topicNames))
 			}
 
-			topics, err := client.GetTopics()
-			if err != nil {
-				return err
-			}
-
 			sort.Slice(topics, func(i, j int) bool {
 				return topics[i].TopicName < topics[j].TopicName
 			})
@@ -64,7 +83,7 @@ func NewTopicsGroupCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, topics)
-			return bite.PrintObject(cmd, topicsView, func(t topicView) bool {
+			return utils.PrintObject(cmd, topicsView, func(t topicView) bool {
 				return !t.IsControlTopic // on JSON we print everything.
 			})
 		},
@@ -72,25 +91,49 @@ func NewTopicsGroupCommand() *cobra.Command {
 
 	root.Flags().BoolVar(&namesOnly, "names", false, "Print topic names only")
 	root.Flags().BoolVar(&unwrap, "unwrap", false, "--unwrap")
+	root.Flags().StringVar(&namespace, "namespace", "", "List only the topics of a data namespace, i.e. finance-*")
 
 	bite.CanPrintJSON(root)
+	utils.CanSelectColumns(root)
+	utils.CanUseTemplate(root)
+	utils.CanBeQuiet(root)
 
 	root.AddCommand(NewGetAvailableTopicConfigKeysCommand())
 	root.AddCommand(NewTopicsMetadataSubgroupCommand())
+	root.AddCommand(NewTopicsReassignmentsCommand())
+	root.AddCommand(NewTopicsLagSnapshotCommand())
 
 	return root
 }
 
 // NewGetAvailableTopicConfigKeysCommand creates `topics keys` command
 func NewGetAvailableTopicConfigKeysCommand() *cobra.Command {
-	var unwrap bool
+	var unwrap, withDefaults bool
 
 	cmd := &cobra.Command{
 		Use:           "keys",
 		Short:         "List all available config keys for topics",
-		Example:       "topics keys",
+		Example:       "topics keys [--defaults]",
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if withDefaults {
+				defaults, err := config.Client.GetTopicConfigDefaults()
+				if err != nil {
+					return err
+				}
+
+				entries := make([]api.TopicConfigDefault, 0, len(defaults))
+				for _, d := range defaults {
+					entries = append(entries, d)
+				}
+
+				sort.Slice(entries, func(i, j int) bool {
+					return entries[i].Key < entries[j].Key
+				})
+
+				return bite.PrintObject(cmd, entries)
+			}
+
 			keys, err := config.Client.GetAvailableTopicConfigKeys()
 			if err != nil {
 				return err
@@ -111,6 +154,104 @@ func NewGetAvailableTopicConfigKeysCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&unwrap, "unwrap", false, "--unwrap Display the names separated by new lines, disables the Table or JSON view")
+	cmd.Flags().BoolVar(&withDefaults, "defaults", false, "List the config keys along with their default values and types")
+
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// NewTopicsLagSnapshotCommand creates `topics lag-snapshot` command
+func NewTopicsLagSnapshotCommand() *cobra.Command {
+	var (
+		topics           []string
+		asCSV, noHeaders bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lag-snapshot",
+		Short: "Print a point-in-time snapshot of the consumers lag and the throughput of topics",
+		Example: `topics lag-snapshot --topic orders --topic payments
+topics lag-snapshot --topic orders --csv --no-headers >> orders-lag.csv`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(topics) == 0 {
+				return fmt.Errorf("at least one --topic is required")
+			}
+
+			snapshots, err := config.Client.GetTopicLagSnapshots(topics)
+			if err != nil {
+				return err
+			}
+
+			if asCSV {
+				return writeTopicLagSnapshotsCSV(cmd.OutOrStdout(), snapshots, !noHeaders)
+			}
+
+			return bite.PrintObject(cmd, snapshots)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&topics, "topic", nil, "Topic to take a snapshot of, can be defined multiple times")
+	cmd.Flags().BoolVar(&asCSV, "csv", false, "Print the snapshot as CSV")
+	cmd.Flags().BoolVar(&noHeaders, "no-headers", false, "Do not print the CSV header, i.e to append to the file of a previous run")
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// writeTopicLagSnapshotsCSV writes the snapshots as CSV, one row per topic.
+func writeTopicLagSnapshotsCSV(w io.Writer, snapshots []api.TopicLagSnapshot, withHeader bool) error {
+	csvWriter := csv.NewWriter(w)
+	if withHeader {
+		header := []string{"timestamp", "topic", "consumerGroups", "totalLag", "maxLag", "messagesPerSecond", "totalMessages"}
+		if err := csvWriter.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range snapshots {
+		record := []string{
+			strconv.FormatInt(s.Timestamp, 10),
+			s.Topic,
+			strconv.Itoa(s.ConsumerGroups),
+			strconv.FormatInt(s.TotalLag, 10),
+			strconv.FormatInt(s.MaxLag, 10),
+			strconv.FormatInt(s.MessagesPerSecond, 10),
+			strconv.FormatInt(s.TotalMessages, 10),
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// NewTopicsReassignmentsCommand creates `topics reassignments` command
+func NewTopicsReassignmentsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "reassignments",
+		Short:         "List the partition reassignments in progress",
+		Example:       "topics reassignments",
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reassignments, err := config.Client.GetPartitionReassignments()
+			if err != nil {
+				return err
+			}
+
+			if bite.ExpectsFeedback(cmd) && len(reassignments) == 0 {
+				// do not throw error, it's not an error.
+				return bite.PrintInfo(cmd, "No partition reassignments in progress")
+			}
+
+			return bite.PrintObject(cmd, reassignments)
+		},
+	}
 
 	bite.CanPrintJSON(cmd)
 
@@ -190,6 +331,10 @@ func NewTopicMetadataDeleteCommand() *cobra.Command {
 				return err
 			}
 
+			if err := utils.Confirm(cmd, "Delete the metadata of topic [%s]?", topicName); err != nil {
+				return err
+			}
+
 			if err := config.Client.DeleteTopicMetadata(topicName); err != nil {
 				golog.Errorf("Failed to delete topic metadata [%s]. [%s]", topicName, err.Error())
 				return err
@@ -202,6 +347,7 @@ func NewTopicMetadataDeleteCommand() *cobra.Command {
 	cmd.Flags().StringVar(&topicName, "name", "", "Topic to delete")
 
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
@@ -223,6 +369,12 @@ func NewTopicMetadataCreateCommand() *cobra.Command {
 				return err
 			}
 
+			if meta.ValueType != "" {
+				if err := confirmFormatChange(cmd, meta.TopicName, meta.ValueType); err != nil {
+					return err
+				}
+			}
+
 			if err := client.CreateOrUpdateTopicMetadata(meta); err != nil {
 				return fmt.Errorf("Failed to update topic metadata for [%s]. [%s]", meta.TopicName, err.Error())
 			}
@@ -232,25 +384,56 @@ func NewTopicMetadataCreateCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&meta.TopicName, "name", "", "Topic name to update/create metadata for")
-	cmd.Flags().StringVar(&meta.KeyType, "key-type", "", "Topic keyType")
-	cmd.Flags().StringVar(&meta.ValueType, "value-type", "", "Topic's value type")
+	cmd.Flags().StringVar(&meta.KeyType, "key-type", "", fmt.Sprintf("Topic keyType, one of %v", api.TopicDataFormats))
+	cmd.Flags().StringVar(&meta.ValueType, "value-type", "", fmt.Sprintf("Topic's value type, one of %v", api.TopicDataFormats))
 	cmd.Flags().StringVar(&meta.KeySchemaRaw, "key-schema", "", "Topic's key schema")
 	cmd.Flags().StringVar(&meta.ValueSchemaRaw, "value-schema", "", "Topic's value schema")
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	bite.Prepend(cmd, bite.FileBind(&meta))
 
 	return cmd
 }
 
+// confirmFormatChange warns about the processors, connectors and apps which read from the topic
+// and asks to confirm the change of its value format to the "valueType".
+// The change is not blocked if the impact cannot be resolved, i.e. the topic does not exist yet.
+func confirmFormatChange(cmd *cobra.Command, topicName, valueType string) error {
+	impacts, err := config.Client.GetTopicFormatChangeImpact(topicName, valueType)
+	if err != nil {
+		golog.Debugf("Failed to resolve the format change impact of topic [%s]. [%s]", topicName, err.Error())
+		return nil
+	}
+
+	var affected []string
+	for _, group := range [][]api.ImpactsDetails{impacts.Processors, impacts.Connectors, impacts.Apps} {
+		for _, detail := range group {
+			affected = append(affected, fmt.Sprintf("%s [%s]", strings.ToLower(detail.Type), detail.Name))
+		}
+	}
+
+	if len(affected) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(cmd.ErrOrStderr(), utils.Yellow(fmt.Sprintf("Warning: changing the value format of topic [%s] to [%s] affects %s",
+		topicName, valueType, strings.Join(affected, ", "))))
+
+	return utils.Confirm(cmd, "Change the value format of topic [%s] to [%s]?", topicName, valueType)
+}
+
 // NewTopicGroupCommand creates `topic` command
 func NewTopicGroupCommand() *cobra.Command {
-	var topicName string
+	var (
+		topicName string
+		placement bool
+	)
 
 	root := &cobra.Command{
 		Use:              "topic",
 		Short:            "Manage particular topic based on the topic name, retrieve it or create a new one",
-		Example:          `topic --name="existing_topic_name" or topic create --name="topic1" --replication=1 --partitions=1 --configs="{\"key\": \"value\"}"`,
+		Example:          `topic --name="existing_topic_name" [--placement] or topic create --name="topic1" --replication=1 --partitions=1 --configs="{\"key\": \"value\"}"`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -259,6 +442,16 @@ func NewTopicGroupCommand() *cobra.Command {
 				return err
 			}
 
+			if placement {
+				partitions, err := client.GetTopicReplicaPlacement(topicName)
+				if err != nil {
+					golog.Errorf("Failed to retrieve the replica placement of topic [%s]. [%s]", topicName, err.Error())
+					return err
+				}
+
+				return bite.PrintObject(cmd, sortedReplicaPlacement(partitions))
+			}
+
 			// default is the retrieval of the particular topic info.
 			topic, err := client.GetTopic(topicName)
 			if err != nil {
@@ -271,6 +464,7 @@ func NewTopicGroupCommand() *cobra.Command {
 	}
 
 	root.Flags().StringVar(&topicName, "name", "", "Topic name")
+	root.Flags().BoolVar(&placement, "placement", false, "Print the leader, replicas, in-sync and offline replicas of each partition instead")
 	bite.CanPrintJSON(root)
 
 	// subcommands
@@ -281,6 +475,20 @@ func NewTopicGroupCommand() *cobra.Command {
 	return root
 }
 
+// sortedReplicaPlacement returns the replica placement of the partitions sorted by partition.
+func sortedReplicaPlacement(partitions map[int]api.ReplicaPlacement) []api.ReplicaPlacement {
+	sorted := make([]api.ReplicaPlacement, 0, len(partitions))
+	for _, partition := range partitions {
+		sorted = append(sorted, partition)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Partition < sorted[j].Partition
+	})
+
+	return sorted
+}
+
 // NewTopicCreateCommand creates `topic create` command
 func NewTopicCreateCommand() *cobra.Command {
 	var (
@@ -339,19 +547,22 @@ func NewTopicDeleteCommand() *cobra.Command {
 		// and for records with offset.
 		fromPartition int
 		toOffset      int64
+		// or for records older than a time.
+		before string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a topic",
 		Example: `topic delete --name="topic1" [--partition=0 --offset=1260]
+topic delete --name="topic1" --before="2021-05-17T00:00:00Z"
 topic delete [topic]...`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		// The "arguments OR flags behaviour" is not great but solely to
 		// remain compatible.
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) > 0 && (topicName != "" || fromPartition >= 0 || toOffset >= 0) {
+			if len(args) > 0 && (topicName != "" || fromPartition >= 0 || toOffset >= 0 || before != "") {
 				return fmt.Errorf("either specify only the names as arguments, OR the name [partition, offset] as flags")
 			}
 			if len(args) == 0 {
@@ -366,6 +577,10 @@ topic delete [topic]...`,
 
 			// Arguments style?
 			if len(args) > 0 {
+				if err := utils.Confirm(cmd, "Delete topics [%s]?", strings.Join(args, ", ")); err != nil {
+					return err
+				}
+
 				for _, topic := range args {
 					if err := client.DeleteTopic(topic); err != nil {
 						return fmt.Errorf("delete topic %q: %w", topic, err)
@@ -374,7 +589,39 @@ topic delete [topic]...`,
 				return nil
 			}
 
+			if before != "" {
+				beforeTime, err := parseBeforeTime(before)
+				if err != nil {
+					return err
+				}
+
+				if err := utils.Confirm(cmd, "Delete the records of topic [%s] before [%s]?", topicName, beforeTime.Format(time.RFC3339)); err != nil {
+					return err
+				}
+
+				results, err := client.DeleteTopicRecordsBefore(topicName, beforeTime)
+				if err != nil {
+					golog.Errorf("Failed to delete records topic [%s]. [%s]", topicName, err.Error())
+					return err
+				}
+
+				for _, result := range results {
+					if result.Skipped {
+						bite.PrintInfo(cmd, "Partition [%d] has no records before [%s], skipped", result.Partition, beforeTime.Format(time.RFC3339))
+						continue
+					}
+
+					bite.PrintInfo(cmd, "Records from topic [%s] and partition [%d] up to offset [%d], are marked for deletion", topicName, result.Partition, result.ToOffset)
+				}
+
+				return bite.PrintInfo(cmd, "This may take a few moments to have effect")
+			}
+
 			if fromPartition >= 0 && toOffset >= 0 {
+				if err := utils.Confirm(cmd, "Delete the records of topic [%s] and partition [%d] up to offset [%d]?", topicName, fromPartition, toOffset); err != nil {
+					return err
+				}
+
 				// delete records.
 				if err := client.DeleteTopicRecords(topicName, fromPartition, toOffset); err != nil {
 					golog.Errorf("Failed to delete records topic [%s]. [%s]", topicName, err.Error())
@@ -384,6 +631,10 @@ topic delete [topic]...`,
 				return bite.PrintInfo(cmd, "Records from topic [%s] and partition [%d] up to offset [%d], are marked for deletion. This may take a few moments to have effect", topicName, fromPartition, toOffset)
 			}
 
+			if err := utils.Confirm(cmd, "Delete topic [%s]?", topicName); err != nil {
+				return err
+			}
+
 			if err := client.DeleteTopic(topicName); err != nil {
 				golog.Errorf("Failed to delete topic [%s]. [%s]", topicName, err.Error())
 				return err
@@ -398,11 +649,27 @@ topic delete [topic]...`,
 	// negative default values because 0 is valid value.
 	cmd.Flags().IntVar(&fromPartition, "partition", -1, "Deletes records from a specific partition (offset must set)")
 	cmd.Flags().Int64Var(&toOffset, "offset", -1, "Deletes records from a specific offset (partition must set)")
+	cmd.Flags().StringVar(&before, "before", "", "Deletes records older than a time from all partitions, RFC3339 datetime or unix timestamp in milliseconds")
 	bite.CanBeSilent(cmd)
+	utils.CanSkipConfirmation(cmd)
 
 	return cmd
 }
 
+// parseBeforeTime parses the `--before` flag value as RFC3339 datetime or as unix timestamp in milliseconds.
+func parseBeforeTime(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --before value [%s], expected RFC3339 datetime or unix timestamp in milliseconds", value)
+	}
+
+	return t, nil
+}
+
 // NewTopicUpdateCommand creates `topic update` command
 func NewTopicUpdateCommand() *cobra.Command {
 	var (
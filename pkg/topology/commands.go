@@ -0,0 +1,66 @@
+package topology
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lensesio/bite"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// NewTopologyGroupCommand creates `topology` command
+func NewTopologyGroupCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:              "topology",
+		Short:            "Work with the topology of topics, processors, connectors and apps",
+		Example:          `topology export --format dot`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+	}
+
+	root.AddCommand(NewTopologyExportCommand())
+
+	return root
+}
+
+// NewTopologyExportCommand creates `topology export` command
+func NewTopologyExportCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the whole topology graph",
+		Example: `topology export --format dot > topology.dot
+topology export --format mermaid > topology.mmd
+topology export --format json`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topology, err := config.Client.GetTopology()
+			if err != nil {
+				return err
+			}
+
+			switch strings.ToLower(format) {
+			case "dot":
+				_, err = io.WriteString(cmd.OutOrStdout(), topology.ToDOT())
+				return err
+			case "mermaid":
+				_, err = io.WriteString(cmd.OutOrStdout(), topology.ToMermaid())
+				return err
+			case "json", "yaml":
+				cmd.Flags().Set(bite.GetOutPutFlagKey(), strings.ToUpper(format))
+				return bite.PrintObject(cmd, topology)
+			default:
+				return fmt.Errorf("unsupported format [%s], available formats are: [dot, mermaid, json, yaml]", format)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "dot", "The export format: dot, mermaid, json or yaml")
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package topology
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/v5/pkg/api"
+	config "github.com/lensesio/lenses-go/v5/pkg/configs"
+	"github.com/lensesio/lenses-go/v5/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const topologyResponse = `{
+	"nodes": [
+		{"id": "TOPIC-orders", "name": "orders", "type": "TOPIC"},
+		{"id": "PROCESSOR-enrich", "name": "enrich", "type": "PROCESSOR"},
+		{"id": "TOPIC-enriched", "name": "enriched", "type": "TOPIC"}
+	],
+	"edges": [
+		{"source": "TOPIC-orders", "target": "PROCESSOR-enrich"},
+		{"source": "PROCESSOR-enrich", "target": "TOPIC-enriched"}
+	]
+}`
+
+func TestTopologyExportCommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/topology", r.URL.Path)
+		w.Write([]byte(topologyResponse))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	cmd := NewTopologyExportCommand()
+	output, err := test.ExecuteCommand(cmd, "--format", "dot")
+	assert.Nil(t, err)
+
+	test.CheckStringContains(t, output, `digraph topology {`)
+	test.CheckStringContains(t, output, `"TOPIC-orders" [label="orders", shape=box];`)
+	test.CheckStringContains(t, output, `"PROCESSOR-enrich" [label="enrich", shape=ellipse];`)
+	test.CheckStringContains(t, output, `"PROCESSOR-enrich" -> "TOPIC-enriched";`)
+
+	cmd = NewTopologyExportCommand()
+	output, err = test.ExecuteCommand(cmd, "--format", "mermaid")
+	assert.Nil(t, err)
+
+	test.CheckStringContains(t, output, `flowchart LR`)
+	test.CheckStringContains(t, output, `n1(["enrich"])`)
+	test.CheckStringContains(t, output, `n1 --> n2`)
+
+	cmd = NewTopologyExportCommand()
+	_, err = test.ExecuteCommand(cmd, "--format", "png")
+	assert.NotNil(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/kataras/golog"
 
@@ -40,9 +41,96 @@ func NewGetConfigurationContextsCommand() *cobra.Command {
 
 	bite.CanBeSilent(cmd)
 
+	cmd.AddCommand(NewContextsCheckCommand())
+
+	return cmd
+}
+
+// contextCheck is the connectivity of a configuration context, as reported by the `contexts check` command.
+type contextCheck struct {
+	Context       string `json:"context" yaml:"context" header:"Context"`
+	Current       bool   `json:"current" yaml:"current" header:"Current"`
+	Host          string `json:"host" yaml:"host" header:"Host"`
+	Reachable     bool   `json:"reachable" yaml:"reachable" header:"Reachable"`
+	Version       string `json:"version,omitempty" yaml:"version,omitempty" header:"Version"`
+	SecurityMode  string `json:"securityMode,omitempty" yaml:"securityMode,omitempty" header:"Security Mode"`
+	ExecutionMode string `json:"executionMode,omitempty" yaml:"executionMode,omitempty" header:"Execution Mode"`
+	Error         string `json:"error,omitempty" yaml:"error,omitempty" header:"Error"`
+}
+
+// NewContextsCheckCommand creates `contexts check` command
+func NewContextsCheckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the connectivity of all the contexts and compare their versions and modes",
+		Long: `Connect to the box of each configuration context and report whether it is reachable,
+its version, security mode and SQL execution mode, i.e. to compare the prod and staging boxes. It fails if any context is unreachable.`,
+		Example:       "contexts check [--output=json]",
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := config.Manager.ContextNames()
+			checks := make([]contextCheck, len(names))
+
+			var wg sync.WaitGroup
+			for i, name := range names {
+				wg.Add(1)
+				go func(i int, name string) {
+					defer wg.Done()
+					checks[i] = checkContext(name)
+				}(i, name)
+			}
+			wg.Wait()
+
+			if err := bite.PrintObject(cmd, checks); err != nil {
+				return err
+			}
+
+			var unreachable []string
+			for _, check := range checks {
+				if !check.Reachable {
+					unreachable = append(unreachable, check.Context)
+				}
+			}
+
+			if len(unreachable) > 0 {
+				return fmt.Errorf("unreachable contexts: [%s]", strings.Join(unreachable, ", "))
+			}
+
+			return nil
+		},
+	}
+
+	bite.CanPrintJSON(cmd)
+
 	return cmd
 }
 
+func checkContext(name string) contextCheck {
+	check := contextCheck{Context: name, Current: name == config.Manager.Config.CurrentContext}
+
+	cfg := *config.Manager.Config.Contexts[name]
+	cfg.FormatHost()
+	check.Host = cfg.Host
+
+	client, err := config.Manager.OpenContext(name)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	box, err := client.GetConfig()
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	check.Reachable = true
+	check.Version = box.Version
+	check.SecurityMode = box.SecurityMode
+	check.ExecutionMode = string(box.SQLExecutionMode)
+	return check
+}
+
 // NewConfigurationContextCommand creates `context` command
 func NewConfigurationContextCommand() *cobra.Command {
 	root := &cobra.Command{
@@ -1,6 +1,10 @@
 package user
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -97,3 +101,34 @@ func TestContextCommands(t *testing.T) {
 
 	test.RunCommandTests(t, scenarios)
 }
+
+func TestContextsCheckCommand(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/config", r.URL.Path)
+		w.Write([]byte(`{"lenses.version": "5.0.1", "lenses.security.mode": "BASIC", "lenses.sql.execution.mode": "KUBERNETES"}`))
+	}))
+	defer srv.Close()
+
+	test.SetupContext("staging", api.ClientConfig{Host: "http://127.0.0.1:1", Token: "secret", Timeout: "1s"}, api.BasicAuthentication{})
+	test.SetupContext("prod", api.ClientConfig{Host: srv.URL, Token: "secret"}, api.BasicAuthentication{})
+	defer test.ResetConfigManager()
+
+	cmd := NewContextsCheckCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unreachable contexts: [staging]", err.Error())
+	}
+
+	var checks []contextCheck
+	// the usage follows the failure.
+	assert.Nil(t, json.NewDecoder(strings.NewReader(output)).Decode(&checks))
+	if assert.Len(t, checks, 2) {
+		assert.Equal(t, contextCheck{Context: "prod", Current: true, Host: srv.URL, Reachable: true,
+			Version: "5.0.1", SecurityMode: "BASIC", ExecutionMode: "KUBERNETES"}, checks[0])
+		assert.Equal(t, "staging", checks[1].Context)
+		assert.False(t, checks[1].Reachable)
+		assert.NotEmpty(t, checks[1].Error)
+	}
+}
@@ -29,11 +29,36 @@ func NewUserGroupCommand() *cobra.Command {
 
 	bite.CanPrintJSON(root)
 
+	root.AddCommand(NewUserWhoAmICommand())
 	root.AddCommand(NewUserProfileGroupCommand())
 
 	return root
 }
 
+// NewUserWhoAmICommand creates `user whoami` command
+func NewUserWhoAmICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "whoami",
+		Short:            "Print the roles and the permissions of the authenticated user, as given by the Lenses server",
+		Example:          "user whoami --output=json",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := config.Client.WhoAmI()
+			if err != nil {
+				golog.Errorf("Failed to retrieve the authenticated user. [%s]", err.Error())
+				return err
+			}
+
+			return bite.PrintObject(cmd, user)
+		},
+	}
+
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
 // NewUserProfileGroupCommand creates `users profile` command
 func NewUserProfileGroupCommand() *cobra.Command {
 	rootSub := &cobra.Command{
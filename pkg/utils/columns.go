@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/lensesio/bite"
+	"github.com/lensesio/tableprinter"
+	"github.com/spf13/cobra"
+)
+
+const columnsFlagKey = "columns"
+
+// CanSelectColumns registers the `--columns` flag to a list command,
+// it accepts a comma separated list of header names, i.e. `--columns=name,partitions,replication`.
+func CanSelectColumns(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(columnsFlagKey, nil, "Comma separated list of the table columns to print, in order, e.g. --columns=name,partitions")
+}
+
+// GetColumnsFlag returns the value of the `--columns` flag, if any.
+func GetColumnsFlag(cmd *cobra.Command) []string {
+	columns, _ := cmd.Flags().GetStringSlice(columnsFlagKey)
+	return columns
+}
+
+// PrintObject works like `bite.PrintObject` but when the output is a table
+// and `--columns` is set, it prints only the selected columns and in the given order.
+// When `--template` is set, the results are rendered through it instead, see `CanUseTemplate`.
+func PrintObject(cmd *cobra.Command, v interface{}, tableOnlyFilters ...interface{}) error {
+	tmpl, err := GetTemplateFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	if tmpl != nil {
+		return PrintTemplate(cmd, tmpl, v)
+	}
+
+	columns := GetColumnsFlag(cmd)
+	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+	if len(columns) == 0 || output == "JSON" || output == "YAML" {
+		return bite.PrintObject(cmd, v, tableOnlyFilters...)
+	}
+
+	in := reflect.Indirect(reflect.ValueOf(v))
+	if !in.IsValid() {
+		return nil
+	}
+
+	parser := tableprinter.WhichParser(in.Type())
+	if parser == nil {
+		return bite.PrintObject(cmd, v, tableOnlyFilters...)
+	}
+
+	headers, rows, nums := parser.Parse(in, tableprinter.MakeFilters(in, tableOnlyFilters...))
+	headers, rows, nums, err = SelectColumns(columns, headers, rows, nums)
+	if err != nil {
+		return err
+	}
+
+	tableprinter.Render(cmd.OutOrStdout(), headers, rows, nums, true)
+	return nil
+}
+
+// SelectColumns filters and reorders the "headers" and the cells of each row based on the "columns" header names,
+// header names are matched case-insensitively. The "nums" (numeric cells positions) are remapped to the new positions.
+// It returns an error if any of the "columns" does not exist.
+func SelectColumns(columns []string, headers []string, rows [][]string, nums []int) ([]string, [][]string, []int, error) {
+	positions := make([]int, 0, len(columns))
+	for _, column := range columns {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			continue
+		}
+
+		pos := -1
+		for i, header := range headers {
+			if strings.EqualFold(header, column) {
+				pos = i
+				break
+			}
+		}
+
+		if pos == -1 {
+			return nil, nil, nil, fmt.Errorf("unknown column [%s], available columns are: [%s]", column, strings.ToLower(strings.Join(headers, ", ")))
+		}
+
+		positions = append(positions, pos)
+	}
+
+	newHeaders := make([]string, len(positions))
+	for i, pos := range positions {
+		newHeaders[i] = headers[pos]
+	}
+
+	newRows := make([][]string, len(rows))
+	for i, row := range rows {
+		newRow := make([]string, len(positions))
+		for j, pos := range positions {
+			if pos < len(row) {
+				newRow[j] = row[pos]
+			}
+		}
+		newRows[i] = newRow
+	}
+
+	var newNums []int
+	for _, num := range nums {
+		for i, pos := range positions {
+			if pos == num {
+				newNums = append(newNums, i)
+			}
+		}
+	}
+
+	return newHeaders, newRows, newNums, nil
+}
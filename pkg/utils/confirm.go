@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const yesFlagKey = "yes"
+
+// ErrNotConfirmed is returned by `Confirm` when the user declines a destructive command.
+var ErrNotConfirmed = errors.New("not confirmed, nothing changed")
+
+var (
+	// isInteractive reports whether the standard input is a terminal.
+	isInteractive = func() bool {
+		return term.IsTerminal(int(os.Stdin.Fd()))
+	}
+
+	askConfirmation = func(message string) (bool, error) {
+		var confirmed bool
+		err := survey.AskOne(&survey.Confirm{Message: message}, &confirmed)
+		return confirmed, err
+	}
+)
+
+// CanSkipConfirmation registers the `--yes/-y` flag to a destructive command, see `Confirm`.
+func CanSkipConfirmation(cmd *cobra.Command) {
+	cmd.Flags().BoolP(yesFlagKey, "y", false, "Skip the interactive confirmation, useful for scripting")
+}
+
+// GetYesFlag returns the value of the `--yes` flag.
+func GetYesFlag(cmd *cobra.Command) bool {
+	b, _ := cmd.Flags().GetBool(yesFlagKey)
+	return b
+}
+
+// IsInteractive reports whether the command can prompt the user, i.e. the standard input is a terminal.
+func IsInteractive() bool {
+	return isInteractive()
+}
+
+// Confirm prompts the user to confirm a destructive command, the prompt is formatted by "format" and "args".
+// The prompt is skipped when the `--yes` flag is set or the standard input is not a terminal, i.e. in scripts.
+// It returns `ErrNotConfirmed` if the user declines.
+func Confirm(cmd *cobra.Command, format string, args ...interface{}) error {
+	if GetYesFlag(cmd) || !isInteractive() {
+		return nil
+	}
+
+	confirmed, err := askConfirmation(fmt.Sprintf(format, args...))
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return ErrNotConfirmed
+	}
+
+	return nil
+}
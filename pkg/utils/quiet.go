@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// quietFlagKey has no shorthand, the `-q` is already taken by the jmespath `--query` flag.
+const quietFlagKey = "quiet"
+
+// CanBeQuiet registers the `--quiet` flag to a list command,
+// when set the command prints only the primary identifier of each result, one per line.
+func CanBeQuiet(cmd *cobra.Command) {
+	cmd.Flags().Bool(quietFlagKey, false, "Print only the names, one per line, useful for scripting")
+}
+
+// GetQuietFlag returns the value of the `--quiet` flag.
+func GetQuietFlag(cmd *cobra.Command) bool {
+	b, _ := cmd.Flags().GetBool(quietFlagKey)
+	return b
+}
+
+// PrintNames prints the "names" to the command's output, one per line.
+func PrintNames(cmd *cobra.Command, names []string) error {
+	out := cmd.OutOrStdout()
+	for _, name := range names {
+		if _, err := fmt.Fprintln(out, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
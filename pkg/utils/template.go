@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"text/template"
+
+	"github.com/lensesio/bite"
+	"github.com/spf13/cobra"
+)
+
+const templateFlagKey = "template"
+
+// CanUseTemplate registers the `--template` flag to a list or get command,
+// when set each result is rendered through a Go text/template, one per line, i.e. `--template='{{.TopicName}} {{.Partitions}}'`.
+// The template is parsed before the command runs, so an invalid template fails before any API call.
+func CanUseTemplate(cmd *cobra.Command) {
+	cmd.Flags().String(templateFlagKey, "", "Go text/template to render each result with, one per line, e.g. --template='{{.Name}}'")
+
+	bite.Prepend(cmd, func(cmd *cobra.Command, args []string) error {
+		_, err := GetTemplateFlag(cmd)
+		return err
+	})
+}
+
+// GetTemplateFlag parses and returns the `--template` flag, it returns nil if the flag is not set.
+func GetTemplateFlag(cmd *cobra.Command) (*template.Template, error) {
+	text, _ := cmd.Flags().GetString(templateFlagKey)
+	if text == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New(templateFlagKey).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %v", err)
+	}
+
+	return tmpl, nil
+}
+
+// PrintTemplate renders "v" through the "tmpl" to the command's output,
+// if "v" is a slice then each of its elements is rendered on its own line.
+func PrintTemplate(cmd *cobra.Command, tmpl *template.Template, v interface{}) error {
+	var items []interface{}
+
+	in := reflect.Indirect(reflect.ValueOf(v))
+	switch in.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < in.Len(); i++ {
+			items = append(items, in.Index(i).Interface())
+		}
+	case reflect.Invalid:
+		return nil
+	default:
+		items = append(items, v)
+	}
+
+	var b bytes.Buffer
+	for _, item := range items {
+		if err := tmpl.Execute(&b, item); err != nil {
+			return fmt.Errorf("--template: %v", err)
+		}
+		b.WriteByte('\n')
+	}
+
+	_, err := cmd.OutOrStdout().Write(b.Bytes())
+	return err
+}
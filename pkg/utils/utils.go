@@ -318,7 +318,14 @@ func WriteStringFile(fileName string, data []string) error {
 
 // WriteBytesFile write bytes to a file to basepath with filename and the given format
 func WriteBytesFile(landscapeDir, basePath, fileName string, data []byte) error {
+	return writeLandscapeFile(landscapeDir, basePath, fileName, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
 
+// writeLandscapeFile creates the landscape's basepath directory, if missing, and writes the file atomically, see `WriteFileAtomic`.
+func writeLandscapeFile(landscapeDir, basePath, fileName string, write func(w io.Writer) error) error {
 	dir := fmt.Sprintf("%s/%s", landscapeDir, basePath)
 
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -327,25 +334,46 @@ func WriteBytesFile(landscapeDir, basePath, fileName string, data []byte) error
 		}
 	}
 
-	path := fmt.Sprintf("%s/%s", dir, fileName)
-
-	file, err := os.OpenFile(
-		path,
-		os.O_WRONLY|os.O_TRUNC|os.O_CREATE,
-		0666,
-	)
+	return WriteFileAtomic(fmt.Sprintf("%s/%s", dir, fileName), write)
+}
 
+// WriteFileAtomic streams the "write" output to a hidden temporary file next to "path",
+// syncs it and renames it to "path" only if the whole write succeeded.
+// So a failed or interrupted write never leaves a truncated file at "path",
+// the hidden temporary files are also skipped on import, see `FindFiles`.
+func WriteFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
 	if err != nil {
-		golog.Fatal(err)
 		return err
 	}
-	defer file.Close()
 
-	_, writeErr := file.Write(data)
+	tmpPath := tmp.Name()
+	fail := func(err error) error {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
 
-	if writeErr != nil {
-		golog.Fatal(writeErr)
-		return writeErr
+	if err = write(tmp); err != nil {
+		return fail(err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		return fail(err)
+	}
+
+	if err = tmp.Chmod(0644); err != nil {
+		return fail(err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
 	}
 
 	return nil
@@ -360,26 +388,22 @@ func WriteFile(landscapeDir, basePath, fileName, format string, resource interfa
 	return WriteJSON(landscapeDir, basePath, fileName, resource)
 }
 
-// WriteJSON write JSON to a file to basepath with filename
+// WriteJSON write JSON to a file to basepath with filename,
+// the resource is encoded directly to the file.
 func WriteJSON(landscapeDir, basePath, fileName string, resource interface{}) error {
-
-	y, err := json.Marshal(resource)
-
-	if err != nil {
-		return err
-	}
-
-	return WriteBytesFile(landscapeDir, basePath, fileName, y)
+	return writeLandscapeFile(landscapeDir, basePath, fileName, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(resource)
+	})
 }
 
-// WriteYAML write YAMLto a file to basepath with filename
+// WriteYAML write YAMLto a file to basepath with filename,
+// the resource is encoded directly to the file.
 func WriteYAML(landscapeDir, basePath, fileName string, resource interface{}) error {
-
-	y, err := ToYaml(resource)
-
-	if err != nil {
-		return err
-	}
-
-	return WriteBytesFile(landscapeDir, basePath, fileName, y)
+	return writeLandscapeFile(landscapeDir, basePath, fileName, func(w io.Writer) error {
+		enc := yaml.NewEncoder(w)
+		if err := enc.Encode(resource); err != nil {
+			return err
+		}
+		return enc.Close()
+	})
 }
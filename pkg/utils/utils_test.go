@@ -1,6 +1,16 @@
 package utils
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
 
 func Test_isValidImportFile(t *testing.T) {
 	type args struct {
@@ -27,3 +37,142 @@ func Test_isValidImportFile(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectColumns(t *testing.T) {
+	headers := []string{"NAME", "PARTITIONS", "REPLICATION"}
+	rows := [][]string{{"a", "1", "3"}, {"b", "2", "1"}}
+	nums := []int{1, 2, 1, 2}
+
+	gotHeaders, gotRows, gotNums, err := SelectColumns([]string{"replication", "name"}, headers, rows, nums)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotHeaders, []string{"REPLICATION", "NAME"}) {
+		t.Errorf("got `%v`, want `%v`", gotHeaders, []string{"REPLICATION", "NAME"})
+	}
+
+	if want := [][]string{{"3", "a"}, {"1", "b"}}; !reflect.DeepEqual(gotRows, want) {
+		t.Errorf("got `%v`, want `%v`", gotRows, want)
+	}
+
+	if want := []int{0, 0}; !reflect.DeepEqual(gotNums, want) {
+		t.Errorf("got `%v`, want `%v`", gotNums, want)
+	}
+
+	if _, _, _, err = SelectColumns([]string{"unknown"}, headers, rows, nums); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestPrintTemplate(t *testing.T) {
+	type topic struct {
+		TopicName  string
+		Partitions int
+	}
+
+	calls := 0
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{
+			Use: "topics",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				calls++
+				return PrintObject(cmd, []topic{{"a", 1}, {"b", 3}})
+			},
+		}
+		CanUseTemplate(cmd)
+		return cmd
+	}
+
+	var out bytes.Buffer
+	cmd := newCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--template={{.TopicName}} {{.Partitions}}"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "a 1\nb 3\n"; out.String() != expected {
+		t.Errorf("got `%v`, want `%v`", out.String(), expected)
+	}
+
+	calls = 0
+	cmd = newCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--template={{.TopicName"})
+	if err := cmd.Execute(); err == nil || calls != 0 {
+		t.Errorf("got `%v` after `%d` calls, want a parse error before the command runs", err, calls)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	defer func(interactive func() bool, ask func(string) (bool, error)) {
+		isInteractive, askConfirmation = interactive, ask
+	}(isInteractive, askConfirmation)
+
+	var asked []string
+	answer := false
+	askConfirmation = func(message string) (bool, error) {
+		asked = append(asked, message)
+		return answer, nil
+	}
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "delete"}
+		CanSkipConfirmation(cmd)
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+
+	isInteractive = func() bool { return false }
+	if err := Confirm(newCmd(), "Delete topic [%s]?", "orders"); err != nil || len(asked) != 0 {
+		t.Errorf("got `%v`, `%v`, want no prompt when not interactive", err, asked)
+	}
+
+	isInteractive = func() bool { return true }
+	if err := Confirm(newCmd("-y"), "Delete topic [%s]?", "orders"); err != nil || len(asked) != 0 {
+		t.Errorf("got `%v`, `%v`, want no prompt with -y", err, asked)
+	}
+
+	if err := Confirm(newCmd(), "Delete topic [%s]?", "orders"); err != ErrNotConfirmed || len(asked) != 1 || asked[0] != "Delete topic [orders]?" {
+		t.Errorf("got `%v`, `%v`, want the declined prompt", err, asked)
+	}
+
+	answer = true
+	if err := Confirm(newCmd(), "Delete topic [%s]?", "orders"); err != nil {
+		t.Errorf("got `%v`, want the confirmed prompt", err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schema.yaml")
+
+	if err := WriteYAML(dir, ".", "schema.yaml", map[string]string{"name": "orders"}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil || string(b) != "name: orders\n" {
+		t.Fatalf("got `%s`, `%v`, want the encoded resource", b, err)
+	}
+
+	// a failed write keeps the previous file and leaves no temporary files behind.
+	err = WriteFileAtomic(path, func(w io.Writer) error {
+		w.Write([]byte("name: "))
+		return errors.New("connection reset")
+	})
+	if err == nil {
+		t.Fatal("expected the write error")
+	}
+
+	if b, _ = os.ReadFile(path); string(b) != "name: orders\n" {
+		t.Errorf("got `%s`, want the previous file untouched", b)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("got `%v`, want only the written file", entries)
+	}
+}